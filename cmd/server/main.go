@@ -13,33 +13,40 @@ import (
 	staticskills "clawvival/internal/adapter/skills/static"
 	worldruntime "clawvival/internal/adapter/world/runtime"
 	"clawvival/internal/app/action"
+	"clawvival/internal/app/agentmap"
 	"clawvival/internal/app/auth"
+	"clawvival/internal/app/message"
 	"clawvival/internal/app/observe"
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/replay"
+	"clawvival/internal/app/session"
 	"clawvival/internal/app/skills"
 	"clawvival/internal/app/status"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 
 	"github.com/cloudwego/hertz/pkg/app/server"
+	"gorm.io/gorm"
 )
 
 func main() {
-	stateRepo, credRepo, actionRepo, eventRepo, worldObjectRepo, resourceNodeRepo, sessionRepo, txManager := mustBuildRepos()
+	stateRepo, credRepo, actionRepo, eventRepo, worldObjectRepo, resourceNodeRepo, sessionRepo, creatureRepo, tradeRepo, messageRepo, agentLocator, mapRepo, txManager := mustBuildRepos()
 	worldProvider := buildWorldProviderFromEnv()
 	skillsProvider := staticskills.Provider{Root: resolveSkillsRoot()}
 	kpiRecorder := metricsinmem.NewRecorder()
+	rules := mustLoadRuleSet()
+	settle := survival.SettlementService{Rules: rules}
 
 	h := httpadapter.Handler{
 		RegisterUC: auth.RegisterUseCase{
 			Credentials: credRepo,
 			StateRepo:   stateRepo,
+			SessionRepo: sessionRepo,
 			TxManager:   txManager,
 			Now:         time.Now,
 		},
 		AuthUC:    auth.VerifyUseCase{Credentials: credRepo},
-		ObserveUC: observe.UseCase{StateRepo: stateRepo, ObjectRepo: worldObjectRepo, EventRepo: eventRepo, ResourceRepo: resourceNodeRepo, World: worldProvider, Now: time.Now},
+		ObserveUC: observe.UseCase{StateRepo: stateRepo, ObjectRepo: worldObjectRepo, EventRepo: eventRepo, ResourceRepo: resourceNodeRepo, MessageRepo: messageRepo, MapRepo: mapRepo, World: worldProvider, Settle: settle, Now: time.Now},
 		ActionUC: action.UseCase{
 			TxManager:    txManager,
 			StateRepo:    stateRepo,
@@ -48,15 +55,28 @@ func main() {
 			ObjectRepo:   worldObjectRepo,
 			ResourceRepo: resourceNodeRepo,
 			SessionRepo:  sessionRepo,
+			CreatureRepo: creatureRepo,
+			TradeRepo:    tradeRepo,
+			MapRepo:      mapRepo,
 			World:        worldProvider,
 			Metrics:      kpiRecorder,
-			Settle:       survival.SettlementService{},
+			Settle:       settle,
 			Now:          time.Now,
 		},
-		StatusUC: status.UseCase{StateRepo: stateRepo, EventRepo: eventRepo, World: worldProvider, Now: time.Now},
-		ReplayUC: replay.UseCase{Events: eventRepo},
-		SkillsUC: skills.UseCase{Provider: skillsProvider},
-		KPI:      kpiRecorder,
+		MessageUC: message.UseCase{
+			TxManager:   txManager,
+			StateRepo:   stateRepo,
+			EventRepo:   eventRepo,
+			MessageRepo: messageRepo,
+			Agents:      agentLocator,
+			Now:         time.Now,
+		},
+		StatusUC:  status.UseCase{StateRepo: stateRepo, EventRepo: eventRepo, ObjectRepo: worldObjectRepo, World: worldProvider, Rules: rules, Now: time.Now},
+		ReplayUC:  replay.UseCase{Events: eventRepo},
+		SessionUC: session.UseCase{StateRepo: stateRepo, Sessions: sessionRepo, Now: time.Now},
+		MapUC:     agentmap.UseCase{StateRepo: stateRepo, MapRepo: mapRepo},
+		SkillsUC:  skills.UseCase{Provider: skillsProvider},
+		KPI:       kpiRecorder,
 	}
 
 	s := server.Default(server.WithHostPorts(":8080"))
@@ -76,7 +96,25 @@ func resolveSkillsRoot() string {
 	return "./apps/web/public/skills"
 }
 
-func mustBuildRepos() (ports.AgentStateRepository, ports.AgentCredentialRepository, ports.ActionExecutionRepository, ports.EventRepository, ports.WorldObjectRepository, ports.AgentResourceNodeRepository, ports.AgentSessionRepository, ports.TxManager) {
+// RULESET_PATH points at a JSON rule set that replaces the embedded balance
+// defaults; the file is validated before the server starts.
+func mustLoadRuleSet() *survival.RuleSet {
+	path := strings.TrimSpace(os.Getenv("RULESET_PATH"))
+	if path == "" {
+		return survival.DefaultRuleSet()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("read ruleset: %v", err)
+	}
+	rules, err := survival.ParseRuleSet(data)
+	if err != nil {
+		log.Fatalf("load ruleset %s: %v", path, err)
+	}
+	return rules
+}
+
+func mustBuildRepos() (ports.AgentStateRepository, ports.AgentCredentialRepository, ports.ActionExecutionRepository, ports.EventRepository, ports.WorldObjectRepository, ports.AgentResourceNodeRepository, ports.AgentSessionRepository, ports.WorldCreatureRepository, ports.TradeOfferRepository, ports.MessageRepository, ports.AgentLocator, ports.AgentMapRepository, ports.TxManager) {
 	dsn := os.Getenv("DATABASE_URL")
 	if dsn == "" {
 		log.Fatal("DATABASE_URL is required")
@@ -85,7 +123,23 @@ func mustBuildRepos() (ports.AgentStateRepository, ports.AgentCredentialReposito
 	if err != nil {
 		log.Fatalf("open postgres: %v", err)
 	}
-	return gormrepo.NewAgentStateRepo(db), gormrepo.NewAgentCredentialRepo(db), gormrepo.NewActionExecutionRepo(db), gormrepo.NewEventRepo(db), gormrepo.NewWorldObjectRepo(db), gormrepo.NewAgentResourceNodeRepo(db), gormrepo.NewAgentSessionRepo(db), gormrepo.NewTxManager(db)
+	objectRepo, resourceNodeRepo := buildWorldReposFromEnv(db)
+	stateRepo := gormrepo.NewAgentStateRepo(db)
+	return stateRepo, gormrepo.NewAgentCredentialRepo(db), gormrepo.NewActionExecutionRepo(db), gormrepo.NewEventRepo(db), objectRepo, resourceNodeRepo, gormrepo.NewAgentSessionRepo(db), gormrepo.NewWorldCreatureRepo(db), gormrepo.NewTradeOfferRepo(db), gormrepo.NewMessageRepo(db), stateRepo, gormrepo.NewAgentMapRepo(db), gormrepo.NewTxManager(db)
+}
+
+// WORLD_MODE=shared makes built objects and resource depletion global so
+// agents can see each other's structures and compete for the same nodes.
+func buildWorldReposFromEnv(db *gorm.DB) (ports.WorldObjectRepository, ports.AgentResourceNodeRepository) {
+	switch mode := strings.TrimSpace(os.Getenv("WORLD_MODE")); mode {
+	case "", "private":
+		return gormrepo.NewWorldObjectRepo(db), gormrepo.NewAgentResourceNodeRepo(db)
+	case "shared":
+		return gormrepo.NewSharedWorldObjectRepo(db), gormrepo.NewSharedAgentResourceNodeRepo(db)
+	default:
+		log.Fatalf("unknown WORLD_MODE %q", mode)
+		return nil, nil
+	}
 }
 
 func buildWorldProviderFromEnv() ports.WorldProvider {
@@ -97,6 +151,11 @@ func buildWorldProviderFromEnv() ports.WorldProvider {
 		DayDuration:   time.Duration(daySeconds) * time.Second,
 		NightDuration: time.Duration(nightSeconds) * time.Second,
 	})
+	terrain, err := worldruntime.NewTerrainGenerator(os.Getenv("WORLD_TERRAIN"), int64(intEnv("WORLD_SEED", 0)))
+	if err != nil {
+		log.Fatalf("world terrain: %v", err)
+	}
+	cfg.Terrain = terrain
 	cfg.ThreatDay = intEnv("WORLD_THREAT_DAY", cfg.ThreatDay)
 	cfg.ThreatNight = intEnv("WORLD_THREAT_NIGHT", cfg.ThreatNight)
 
@@ -110,6 +169,7 @@ func buildWorldProviderFromEnv() ports.WorldProvider {
 		if db, err := gormrepo.OpenPostgres(dsn); err == nil {
 			cfg.ChunkStore = gormrepo.NewWorldChunkRepo(db)
 			cfg.ClockStateStore = gormrepo.NewWorldClockStateRepo(db)
+			cfg.CreatureStore = gormrepo.NewWorldCreatureRepo(db)
 		}
 	}
 
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS world_creatures (
+  id BIGSERIAL PRIMARY KEY,
+  chunk_x INTEGER NOT NULL,
+  chunk_y INTEGER NOT NULL,
+  epoch TEXT NOT NULL,
+  creatures JSONB NOT NULL DEFAULT '[]'::jsonb,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE(chunk_x, chunk_y, epoch)
+);
+
+CREATE INDEX IF NOT EXISTS idx_world_creatures_epoch ON world_creatures(epoch);
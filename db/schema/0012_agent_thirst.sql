@@ -0,0 +1,2 @@
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS thirst INTEGER NOT NULL DEFAULT 80;
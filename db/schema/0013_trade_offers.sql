@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS trade_offers (
+  id BIGSERIAL PRIMARY KEY,
+  offer_id TEXT NOT NULL UNIQUE,
+  from_agent_id TEXT NOT NULL,
+  to_agent_id TEXT NOT NULL,
+  offer_items TEXT NOT NULL DEFAULT '{}',
+  request_items TEXT NOT NULL DEFAULT '{}',
+  status TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_offers_from_agent_id ON trade_offers(from_agent_id);
+CREATE INDEX IF NOT EXISTS idx_trade_offers_to_agent_id ON trade_offers(to_agent_id);
+
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS escrow TEXT NOT NULL DEFAULT '{}';
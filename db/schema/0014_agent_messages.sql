@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS agent_messages (
+  id BIGSERIAL PRIMARY KEY,
+  message_id TEXT NOT NULL,
+  from_agent_id TEXT NOT NULL,
+  to_agent_id TEXT NOT NULL DEFAULT '',
+  mode TEXT NOT NULL,
+  channel TEXT NOT NULL DEFAULT '',
+  text TEXT NOT NULL,
+  x INTEGER NOT NULL,
+  y INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_messages_to_agent_created ON agent_messages(to_agent_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_agent_messages_from_agent_created ON agent_messages(from_agent_id, created_at DESC);
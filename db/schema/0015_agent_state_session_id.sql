@@ -0,0 +1,2 @@
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS session_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE world_objects
+  ADD COLUMN IF NOT EXISTS decay_checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,2 @@
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS freshness TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS equipment TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS agent_map_tiles (
+  id BIGSERIAL PRIMARY KEY,
+  agent_id TEXT NOT NULL,
+  session_id TEXT NOT NULL DEFAULT '',
+  x INTEGER NOT NULL,
+  y INTEGER NOT NULL,
+  terrain_type TEXT NOT NULL,
+  is_walkable BOOLEAN NOT NULL DEFAULT FALSE,
+  resource_type TEXT NOT NULL DEFAULT '',
+  object_id TEXT NOT NULL DEFAULT '',
+  object_type TEXT NOT NULL DEFAULT '',
+  last_seen_at TIMESTAMPTZ NOT NULL,
+  UNIQUE(agent_id, session_id, x, y)
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_map_tiles_agent_session ON agent_map_tiles(agent_id, session_id);
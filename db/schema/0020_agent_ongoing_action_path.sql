@@ -0,0 +1,2 @@
+ALTER TABLE agent_states
+  ADD COLUMN IF NOT EXISTS ongoing_action_path TEXT NOT NULL DEFAULT '[]';
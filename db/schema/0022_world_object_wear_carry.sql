@@ -0,0 +1,2 @@
+ALTER TABLE world_objects
+  ADD COLUMN IF NOT EXISTS wear_carry INTEGER NOT NULL DEFAULT 0;
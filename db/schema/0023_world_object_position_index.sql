@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_world_objects_position ON world_objects(x, y);
@@ -0,0 +1,5 @@
+ALTER TABLE trade_offers
+  ADD COLUMN IF NOT EXISTS offer_stacks TEXT NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS offer_tools TEXT NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_trade_offers_open_expiry ON trade_offers(from_agent_id, expires_at) WHERE status = 'open';
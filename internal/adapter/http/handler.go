@@ -8,10 +8,13 @@ import (
 	"strings"
 
 	"clawvival/internal/app/action"
+	"clawvival/internal/app/agentmap"
 	"clawvival/internal/app/auth"
+	"clawvival/internal/app/message"
 	"clawvival/internal/app/observe"
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/replay"
+	"clawvival/internal/app/session"
 	"clawvival/internal/app/skills"
 	"clawvival/internal/app/status"
 	"clawvival/internal/domain/survival"
@@ -30,8 +33,11 @@ type Handler struct {
 	AuthUC     auth.VerifyUseCase
 	ObserveUC  observe.UseCase
 	ActionUC   action.UseCase
+	MessageUC  message.UseCase
 	StatusUC   status.UseCase
 	ReplayUC   replay.UseCase
+	SessionUC  session.UseCase
+	MapUC      agentmap.UseCase
 	SkillsUC   skills.UseCase
 	KPI        kpiSnapshotProvider
 }
@@ -42,8 +48,12 @@ func (h Handler) RegisterRoutes(s *server.Hertz) {
 	agent.POST("/register", h.register)
 	agent.POST("/observe", h.observe)
 	agent.POST("/action", h.action)
+	agent.POST("/plan", h.plan)
+	agent.POST("/message", h.message)
 	agent.POST("/status", h.status)
 	agent.GET("/replay", h.replay)
+	agent.GET("/sessions", h.sessions)
+	agent.GET("/map", h.agentMap)
 
 	s.GET("/skills", h.skillsRoot)
 	s.GET("/skills/", h.skillsRoot)
@@ -65,6 +75,53 @@ type actionRequest struct {
 	IdempotencyKey string       `json:"idempotency_key"`
 	Intent         actionIntent `json:"intent"`
 	StrategyHash   string       `json:"strategy_hash,omitempty"`
+	DryRun         bool         `json:"dry_run,omitempty"`
+}
+
+type planRequest struct {
+	IdempotencyKey      string             `json:"idempotency_key"`
+	Steps               []actionIntent     `json:"steps"`
+	StrategyHash        string             `json:"strategy_hash,omitempty"`
+	Atomic              bool               `json:"atomic,omitempty"`
+	ContinueOnRejection bool               `json:"continue_on_rejection,omitempty"`
+	StopWhen            planStopConditions `json:"stop_when"`
+}
+
+type planStopConditions struct {
+	HPBelow     int `json:"hp_below,omitempty"`
+	HungerBelow int `json:"hunger_below,omitempty"`
+	EnergyBelow int `json:"energy_below,omitempty"`
+	ThirstBelow int `json:"thirst_below,omitempty"`
+}
+
+type planResponse struct {
+	Steps          []planStepResponse            `json:"steps"`
+	StepsSucceeded int                           `json:"steps_succeeded"`
+	StopReason     string                        `json:"stop_reason"`
+	UpdatedState   *survival.AgentStateAggregate `json:"updated_state,omitempty"`
+	Error          map[string]string             `json:"error,omitempty"`
+}
+
+// planStopError is reported when a plan stopped on a step that failed for a
+// reason other than a rejection.
+const planStopError = "error"
+
+type planStepResponse struct {
+	Index                  int                    `json:"index"`
+	Type                   string                 `json:"type"`
+	ResultCode             string                 `json:"result_code"`
+	WorldTimeBeforeSeconds int64                  `json:"world_time_before_seconds"`
+	WorldTimeAfterSeconds  int64                  `json:"world_time_after_seconds"`
+	Events                 []survival.DomainEvent `json:"events,omitempty"`
+	Settlement             map[string]any         `json:"settlement,omitempty"`
+	Error                  map[string]any         `json:"error,omitempty"`
+}
+
+type messageRequest struct {
+	Mode      string `json:"mode"`
+	Text      string `json:"text"`
+	ToAgentID string `json:"to_agent_id,omitempty"`
+	Channel   string `json:"channel,omitempty"`
 }
 
 type actionIntent struct {
@@ -80,7 +137,64 @@ type actionIntent struct {
 	BedID       string                `json:"bed_id,omitempty"`
 	FarmID      string                `json:"farm_id,omitempty"`
 	ContainerID string                `json:"container_id,omitempty"`
+	ObjectID    string                `json:"object_id,omitempty"`
+	Slot        string                `json:"slot,omitempty"`
 	Items       []survival.ItemAmount `json:"items,omitempty"`
+	ToAgentID   string                `json:"to_agent_id,omitempty"`
+	OfferID     string                `json:"offer_id,omitempty"`
+	// RequestItems are what a trade offer asks for in return for Items.
+	RequestItems []survival.ItemAmount `json:"request_items,omitempty"`
+}
+
+func (in actionIntent) toDomain() survival.ActionIntent {
+	return survival.ActionIntent{
+		Type:         survival.ActionType(in.Type),
+		Direction:    in.Direction,
+		TargetID:     in.TargetID,
+		RecipeID:     in.RecipeID,
+		Count:        in.Count,
+		ObjectType:   in.ObjectType,
+		Pos:          in.Pos,
+		ItemType:     in.ItemType,
+		RestMinutes:  in.RestMinutes,
+		BedID:        in.BedID,
+		FarmID:       in.FarmID,
+		ContainerID:  in.ContainerID,
+		ObjectID:     in.ObjectID,
+		Slot:         in.Slot,
+		Items:        in.Items,
+		ToAgentID:    in.ToAgentID,
+		OfferID:      in.OfferID,
+		RequestItems: in.RequestItems,
+	}
+}
+
+func (h Handler) message(c context.Context, ctx *app.RequestContext) {
+	agentID, err := h.requireAuthenticatedAgent(c, ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	var body messageRequest
+	if err := decodeJSON(ctx, &body); err != nil {
+		writeErrorBody(ctx, consts.StatusBadRequest, "invalid_json", "invalid json")
+		return
+	}
+
+	resp, err := h.MessageUC.Execute(c, message.Request{
+		AgentID:   agentID,
+		Mode:      body.Mode,
+		Text:      body.Text,
+		ToAgentID: body.ToAgentID,
+		Channel:   body.Channel,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(consts.StatusOK, resp)
 }
 
 func (h Handler) observe(c context.Context, ctx *app.RequestContext) {
@@ -124,22 +238,9 @@ func (h Handler) action(c context.Context, ctx *app.RequestContext) {
 	resp, err := h.ActionUC.Execute(c, action.Request{
 		AgentID:        agentID,
 		IdempotencyKey: body.IdempotencyKey,
-		Intent: survival.ActionIntent{
-			Type:        survival.ActionType(body.Intent.Type),
-			Direction:   body.Intent.Direction,
-			TargetID:    body.Intent.TargetID,
-			RecipeID:    body.Intent.RecipeID,
-			Count:       body.Intent.Count,
-			ObjectType:  body.Intent.ObjectType,
-			Pos:         body.Intent.Pos,
-			ItemType:    body.Intent.ItemType,
-			RestMinutes: body.Intent.RestMinutes,
-			BedID:       body.Intent.BedID,
-			FarmID:      body.Intent.FarmID,
-			ContainerID: body.Intent.ContainerID,
-			Items:       body.Intent.Items,
-		},
-		StrategyHash: body.StrategyHash,
+		Intent:         body.Intent.toDomain(),
+		StrategyHash:   body.StrategyHash,
+		DryRun:         body.DryRun,
 	})
 	if err != nil {
 		if writeActionRejectedFromErr(ctx, err) {
@@ -152,6 +253,115 @@ func (h Handler) action(c context.Context, ctx *app.RequestContext) {
 	ctx.JSON(consts.StatusOK, resp)
 }
 
+func (h Handler) plan(c context.Context, ctx *app.RequestContext) {
+	agentID, err := h.requireAuthenticatedAgent(c, ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	var body planRequest
+	if err := decodeJSON(ctx, &body); err != nil {
+		writeErrorBody(ctx, consts.StatusBadRequest, "invalid_json", "invalid json")
+		return
+	}
+	if hasJSONField(ctx.Request.Body(), "dt") {
+		writeActionRejected(ctx, consts.StatusBadRequest, "dt_managed_by_server", "dt is managed by server", false, []string{"REQUIREMENT_NOT_MET"}, map[string]any{"field": "dt"})
+		return
+	}
+	steps := make([]survival.ActionIntent, 0, len(body.Steps))
+	for _, step := range body.Steps {
+		steps = append(steps, step.toDomain())
+	}
+
+	resp, err := h.ActionUC.ExecutePlan(c, action.PlanRequest{
+		AgentID:             agentID,
+		IdempotencyKey:      body.IdempotencyKey,
+		Steps:               steps,
+		StrategyHash:        body.StrategyHash,
+		Atomic:              body.Atomic,
+		ContinueOnRejection: body.ContinueOnRejection,
+		StopWhen: action.PlanStopConditions{
+			HPBelow:     body.StopWhen.HPBelow,
+			HungerBelow: body.StopWhen.HungerBelow,
+			EnergyBelow: body.StopWhen.EnergyBelow,
+			ThirstBelow: body.StopWhen.ThirstBelow,
+		},
+	})
+	if err != nil {
+		rejection, ok := actionRejectionFromErr(err)
+		if !ok {
+			writeError(ctx, err)
+			return
+		}
+		var stepErr *action.PlanStepError
+		if errors.As(err, &stepErr) {
+			if rejection.details == nil {
+				rejection.details = map[string]any{}
+			}
+			rejection.details["step_index"] = stepErr.Index
+		}
+		writeActionRejected(ctx, rejection.status, rejection.code, err.Error(), false, rejection.blockedBy, rejection.details)
+		return
+	}
+
+	httpStatus, out := planResult(resp)
+	ctx.JSON(httpStatus, out)
+}
+
+// planResult renders per-step results. Steps that ran before a step failed
+// for a reason other than a rejection are committed, so they are reported
+// together with that error and its status instead of being dropped.
+// Resubmitting the plan with the same idempotency key replays them.
+func planResult(resp action.PlanResponse) (int, planResponse) {
+	httpStatus := consts.StatusOK
+	out := planResponse{Steps: make([]planStepResponse, 0, len(resp.Steps)), StopReason: string(resp.StopReason)}
+	for i, step := range resp.Steps {
+		item := planStepResponse{Index: step.Index, Type: string(step.Intent)}
+		if step.Err != nil {
+			rejection, ok := actionRejectionFromErr(step.Err)
+			if !ok {
+				errStatus, code, message := errorStatus(step.Err)
+				item.ResultCode = "ERROR"
+				item.Error = map[string]any{
+					"code":      code,
+					"message":   message,
+					"retryable": true,
+				}
+				out.Steps = append(out.Steps, item)
+				if out.Error == nil {
+					httpStatus = errStatus
+					out.Error = map[string]string{"code": code, "message": message}
+				}
+				if i == len(resp.Steps)-1 && resp.StopReason == action.PlanStopRejected {
+					out.StopReason = planStopError
+				}
+				continue
+			}
+			item.ResultCode = "REJECTED"
+			item.Error = map[string]any{
+				"code":       rejection.code,
+				"message":    step.Err.Error(),
+				"retryable":  false,
+				"blocked_by": rejection.blockedBy,
+				"details":    rejection.details,
+			}
+			out.Steps = append(out.Steps, item)
+			continue
+		}
+		item.ResultCode = string(step.Response.ResultCode)
+		item.WorldTimeBeforeSeconds = step.Response.WorldTimeBeforeSeconds
+		item.WorldTimeAfterSeconds = step.Response.WorldTimeAfterSeconds
+		item.Events = step.Response.Events
+		item.Settlement = step.Response.Settlement
+		out.Steps = append(out.Steps, item)
+		out.StepsSucceeded++
+		state := step.Response.UpdatedState
+		out.UpdatedState = &state
+	}
+	return httpStatus, out
+}
+
 func (h Handler) status(c context.Context, ctx *app.RequestContext) {
 	var body statusRequest
 	if err := decodeJSON(ctx, &body); err != nil {
@@ -197,6 +407,69 @@ func (h Handler) replay(c context.Context, ctx *app.RequestContext) {
 	ctx.JSON(consts.StatusOK, resp)
 }
 
+func (h Handler) sessions(c context.Context, ctx *app.RequestContext) {
+	agentID, err := requireReadableAgentID(ctx, "")
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	resp, err := h.SessionUC.Execute(c, session.Request{AgentID: agentID})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.JSON(consts.StatusOK, resp)
+}
+
+func (h Handler) agentMap(c context.Context, ctx *app.RequestContext) {
+	agentID, err := requireReadableAgentID(ctx, "")
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	bounds, err := mapBoundsFromQuery(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	resp, err := h.MapUC.Execute(c, agentmap.Request{
+		AgentID:   agentID,
+		SessionID: string(ctx.Query("session_id")),
+		Bounds:    bounds,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.JSON(consts.StatusOK, resp)
+}
+
+// mapBoundsFromQuery reads min_x, min_y, max_x and max_y; they are either all
+// set or all omitted.
+func mapBoundsFromQuery(ctx *app.RequestContext) (*agentmap.Bounds, error) {
+	keys := []string{"min_x", "min_y", "max_x", "max_y"}
+	values := make([]int, 0, len(keys))
+	for _, key := range keys {
+		raw := strings.TrimSpace(string(ctx.Query(key)))
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, agentmap.ErrInvalidRequest
+		}
+		values = append(values, v)
+	}
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case len(keys):
+		return &agentmap.Bounds{MinX: values[0], MinY: values[1], MaxX: values[2], MaxY: values[3]}, nil
+	default:
+		return nil, agentmap.ErrInvalidRequest
+	}
+}
+
 func (h Handler) skillsIndex(c context.Context, ctx *app.RequestContext) {
 	b, err := h.SkillsUC.Index(c)
 	if err != nil {
@@ -324,44 +597,63 @@ func (h Handler) requireAuthenticatedAgent(c context.Context, ctx *app.RequestCo
 }
 
 func writeError(ctx *app.RequestContext, err error) {
+	httpStatus, code, message := errorStatus(err)
+	writeErrorBody(ctx, httpStatus, code, message)
+}
+
+// errorStatus maps an error to the HTTP status, code and message written for it.
+func errorStatus(err error) (int, string, string) {
 	switch {
 	case errors.Is(err, ErrMissingAgentCredentials):
-		writeErrorBody(ctx, consts.StatusBadRequest, "missing_agent_credentials", err.Error())
+		return consts.StatusBadRequest, "missing_agent_credentials", err.Error()
 	case errors.Is(err, ErrMissingAgentIDHeader):
-		writeErrorBody(ctx, consts.StatusBadRequest, "missing_agent_id", err.Error())
+		return consts.StatusBadRequest, "missing_agent_id", err.Error()
 	case errors.Is(err, ErrMissingAgentID):
-		writeErrorBody(ctx, consts.StatusBadRequest, "missing_agent_id", err.Error())
+		return consts.StatusBadRequest, "missing_agent_id", err.Error()
 	case errors.Is(err, ErrMissingAgentKeyHeader):
-		writeErrorBody(ctx, consts.StatusBadRequest, "missing_agent_key", err.Error())
+		return consts.StatusBadRequest, "missing_agent_key", err.Error()
 	case errors.Is(err, auth.ErrInvalidCredentials):
-		writeErrorBody(ctx, consts.StatusUnauthorized, "invalid_agent_credentials", err.Error())
+		return consts.StatusUnauthorized, "invalid_agent_credentials", err.Error()
 	case errors.Is(err, action.ErrActionInvalidPosition):
-		writeErrorBody(ctx, consts.StatusConflict, "action_invalid_position", err.Error())
+		return consts.StatusConflict, "action_invalid_position", err.Error()
 	case errors.Is(err, action.ErrActionCooldownActive):
-		writeErrorBody(ctx, consts.StatusConflict, "action_cooldown_active", err.Error())
+		return consts.StatusConflict, "action_cooldown_active", err.Error()
 	case errors.Is(err, action.ErrActionInProgress):
-		writeErrorBody(ctx, consts.StatusConflict, "action_in_progress", err.Error())
+		return consts.StatusConflict, "action_in_progress", err.Error()
 	case errors.Is(err, action.ErrActionPreconditionFailed):
-		writeErrorBody(ctx, consts.StatusConflict, "action_precondition_failed", err.Error())
+		return consts.StatusConflict, "action_precondition_failed", err.Error()
 	case errors.Is(err, action.ErrInventoryFull):
-		writeErrorBody(ctx, consts.StatusConflict, "INVENTORY_FULL", err.Error())
+		return consts.StatusConflict, "INVENTORY_FULL", err.Error()
 	case errors.Is(err, action.ErrContainerFull):
-		writeErrorBody(ctx, consts.StatusConflict, "CONTAINER_FULL", err.Error())
+		return consts.StatusConflict, "CONTAINER_FULL", err.Error()
+	case errors.Is(err, action.ErrContainerNotEmpty):
+		return consts.StatusConflict, "CONTAINER_NOT_EMPTY", err.Error()
+	case errors.Is(err, action.ErrTradeOfferExpired):
+		return consts.StatusConflict, "TRADE_OFFER_EXPIRED", err.Error()
 	case errors.Is(err, action.ErrInvalidActionParams):
-		writeErrorBody(ctx, consts.StatusBadRequest, "invalid_action_params", err.Error())
+		return consts.StatusBadRequest, "invalid_action_params", err.Error()
+	case errors.Is(err, message.ErrRateLimited):
+		return consts.StatusTooManyRequests, "message_rate_limited", err.Error()
+	case errors.Is(err, message.ErrAgentDead):
+		return consts.StatusConflict, "agent_dead", err.Error()
+	case errors.Is(err, message.ErrRecipientOutOfRange):
+		return consts.StatusConflict, "recipient_out_of_range", err.Error()
 	case errors.Is(err, action.ErrInvalidRequest),
+		errors.Is(err, agentmap.ErrInvalidRequest),
 		errors.Is(err, auth.ErrInvalidRequest),
+		errors.Is(err, message.ErrInvalidRequest),
 		errors.Is(err, observe.ErrInvalidRequest),
 		errors.Is(err, replay.ErrInvalidRequest),
+		errors.Is(err, session.ErrInvalidRequest),
 		errors.Is(err, status.ErrInvalidRequest),
 		errors.Is(err, survival.ErrInvalidDelta):
-		writeErrorBody(ctx, consts.StatusBadRequest, "bad_request", err.Error())
+		return consts.StatusBadRequest, "bad_request", err.Error()
 	case errors.Is(err, ports.ErrNotFound):
-		writeErrorBody(ctx, consts.StatusNotFound, "not_found", err.Error())
+		return consts.StatusNotFound, "not_found", err.Error()
 	case errors.Is(err, ports.ErrConflict):
-		writeErrorBody(ctx, consts.StatusConflict, "conflict", err.Error())
+		return consts.StatusConflict, "conflict", err.Error()
 	default:
-		writeErrorBody(ctx, consts.StatusInternalServerError, "internal_error", "internal error")
+		return consts.StatusInternalServerError, "internal_error", "internal error"
 	}
 }
 
@@ -374,7 +666,23 @@ func writeErrorBody(ctx *app.RequestContext, status int, code, message string) {
 	})
 }
 
+type actionRejection struct {
+	status    int
+	code      string
+	blockedBy []string
+	details   map[string]any
+}
+
 func writeActionRejectedFromErr(ctx *app.RequestContext, err error) bool {
+	rejection, ok := actionRejectionFromErr(err)
+	if !ok {
+		return false
+	}
+	writeActionRejected(ctx, rejection.status, rejection.code, err.Error(), false, rejection.blockedBy, rejection.details)
+	return true
+}
+
+func actionRejectionFromErr(err error) (actionRejection, bool) {
 	switch {
 	case errors.Is(err, action.ErrActionInvalidPosition):
 		details := map[string]any{}
@@ -396,8 +704,7 @@ func writeActionRejectedFromErr(ctx *app.RequestContext, err error) bool {
 		if len(details) == 0 {
 			details = nil
 		}
-		writeActionRejected(ctx, consts.StatusConflict, "action_invalid_position", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, details)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "action_invalid_position", blockedBy: []string{"REQUIREMENT_NOT_MET"}, details: details}, true
 	case errors.Is(err, action.ErrActionCooldownActive):
 		details := map[string]any{}
 		var cooldownErr *action.ActionCooldownActiveError
@@ -405,22 +712,18 @@ func writeActionRejectedFromErr(ctx *app.RequestContext, err error) bool {
 			details["intent"] = string(cooldownErr.IntentType)
 			details["remaining_seconds"] = cooldownErr.RemainingSeconds
 		}
-		writeActionRejected(ctx, consts.StatusConflict, "action_cooldown_active", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, details)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "action_cooldown_active", blockedBy: []string{"REQUIREMENT_NOT_MET"}, details: details}, true
 	case errors.Is(err, action.ErrActionInProgress):
-		writeActionRejected(ctx, consts.StatusConflict, "action_in_progress", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, nil)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "action_in_progress", blockedBy: []string{"REQUIREMENT_NOT_MET"}}, true
 	case errors.Is(err, action.ErrTargetOutOfView):
-		writeActionRejected(ctx, consts.StatusConflict, "TARGET_OUT_OF_VIEW", err.Error(), false, []string{"NOT_VISIBLE"}, map[string]any{
+		return actionRejection{status: consts.StatusConflict, code: "TARGET_OUT_OF_VIEW", blockedBy: []string{"NOT_VISIBLE"}, details: map[string]any{
 			"in_window": false,
-		})
-		return true
+		}}, true
 	case errors.Is(err, action.ErrTargetNotVisible):
-		writeActionRejected(ctx, consts.StatusConflict, "TARGET_NOT_VISIBLE", err.Error(), false, []string{"NOT_VISIBLE"}, map[string]any{
+		return actionRejection{status: consts.StatusConflict, code: "TARGET_NOT_VISIBLE", blockedBy: []string{"NOT_VISIBLE"}, details: map[string]any{
 			"in_window":  true,
 			"is_visible": false,
-		})
-		return true
+		}}, true
 	case errors.Is(err, action.ErrResourceDepleted):
 		details := map[string]any{}
 		var depletedErr *action.ResourceDepletedError
@@ -428,25 +731,23 @@ func writeActionRejectedFromErr(ctx *app.RequestContext, err error) bool {
 			details["target_id"] = depletedErr.TargetID
 			details["remaining_seconds"] = depletedErr.RemainingSeconds
 		}
-		writeActionRejected(ctx, consts.StatusConflict, "RESOURCE_DEPLETED", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, details)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "RESOURCE_DEPLETED", blockedBy: []string{"REQUIREMENT_NOT_MET"}, details: details}, true
 	case errors.Is(err, action.ErrActionPreconditionFailed):
-		writeActionRejected(ctx, consts.StatusConflict, "action_precondition_failed", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, nil)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "action_precondition_failed", blockedBy: []string{"REQUIREMENT_NOT_MET"}}, true
 	case errors.Is(err, action.ErrInventoryFull):
-		writeActionRejected(ctx, consts.StatusConflict, "INVENTORY_FULL", err.Error(), false, []string{"INVENTORY_FULL"}, nil)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "INVENTORY_FULL", blockedBy: []string{"INVENTORY_FULL"}}, true
 	case errors.Is(err, action.ErrContainerFull):
-		writeActionRejected(ctx, consts.StatusConflict, "CONTAINER_FULL", err.Error(), false, []string{"CONTAINER_FULL"}, nil)
-		return true
+		return actionRejection{status: consts.StatusConflict, code: "CONTAINER_FULL", blockedBy: []string{"CONTAINER_FULL"}}, true
+	case errors.Is(err, action.ErrContainerNotEmpty):
+		return actionRejection{status: consts.StatusConflict, code: "CONTAINER_NOT_EMPTY", blockedBy: []string{"CONTAINER_NOT_EMPTY"}}, true
+	case errors.Is(err, action.ErrTradeOfferExpired):
+		return actionRejection{status: consts.StatusConflict, code: "TRADE_OFFER_EXPIRED", blockedBy: []string{"REQUIREMENT_NOT_MET"}}, true
 	case errors.Is(err, action.ErrInvalidActionParams):
-		writeActionRejected(ctx, consts.StatusBadRequest, "invalid_action_params", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, nil)
-		return true
+		return actionRejection{status: consts.StatusBadRequest, code: "invalid_action_params", blockedBy: []string{"REQUIREMENT_NOT_MET"}}, true
 	case errors.Is(err, action.ErrInvalidRequest):
-		writeActionRejected(ctx, consts.StatusBadRequest, "bad_request", err.Error(), false, []string{"REQUIREMENT_NOT_MET"}, nil)
-		return true
+		return actionRejection{status: consts.StatusBadRequest, code: "bad_request", blockedBy: []string{"REQUIREMENT_NOT_MET"}}, true
 	default:
-		return false
+		return actionRejection{}, false
 	}
 }
 
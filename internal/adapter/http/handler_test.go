@@ -11,6 +11,7 @@ import (
 	staticskills "clawvival/internal/adapter/skills/static"
 	"clawvival/internal/app/action"
 	"clawvival/internal/app/auth"
+	"clawvival/internal/app/message"
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/skills"
 	"clawvival/internal/domain/survival"
@@ -149,6 +150,23 @@ func TestWriteError_InvalidActionParams(t *testing.T) {
 	}
 }
 
+func TestWriteError_MessageRateLimited(t *testing.T) {
+	ctx := &app.RequestContext{}
+	writeError(ctx, message.ErrRateLimited)
+
+	if got, want := ctx.Response.StatusCode(), consts.StatusTooManyRequests; got != want {
+		t.Fatalf("status mismatch: got=%d want=%d", got, want)
+	}
+
+	var body map[string]map[string]any
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if got, want := body["error"]["code"], "message_rate_limited"; got != want {
+		t.Fatalf("error code mismatch: got=%q want=%q", got, want)
+	}
+}
+
 func TestWriteError_ActionPreconditionFailed(t *testing.T) {
 	ctx := &app.RequestContext{}
 	writeError(ctx, action.ErrActionPreconditionFailed)
@@ -353,6 +371,21 @@ func TestWriteActionRejectedFromErr_ContainerFull(t *testing.T) {
 	}
 }
 
+func TestWriteActionRejectedFromErr_ContainerNotEmpty(t *testing.T) {
+	ctx := &app.RequestContext{}
+	if ok := writeActionRejectedFromErr(ctx, action.ErrContainerNotEmpty); !ok {
+		t.Fatalf("expected handled error")
+	}
+	var body map[string]any
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	errObj, _ := body["error"].(map[string]any)
+	if got, want := errObj["code"], "CONTAINER_NOT_EMPTY"; got != want {
+		t.Fatalf("error code mismatch: got=%v want=%v", got, want)
+	}
+}
+
 func TestWriteError_InvalidCredentials(t *testing.T) {
 	ctx := &app.RequestContext{}
 	writeError(ctx, auth.ErrInvalidCredentials)
@@ -412,6 +445,63 @@ func TestAction_RejectsClientDTField(t *testing.T) {
 	}
 }
 
+func TestPlan_RejectsEmptyPlan(t *testing.T) {
+	salt := []byte("salt")
+	key := "k1"
+	h := Handler{
+		AuthUC: auth.VerifyUseCase{Credentials: fakeCredentialStore{
+			cred: ports.AgentCredentialRecord{
+				AgentID: "agent-1",
+				KeySalt: salt,
+				KeyHash: hashForTest(salt, key),
+				Status:  auth.CredentialStatusActive,
+			},
+		}},
+	}
+	ctx := &app.RequestContext{}
+	ctx.Request.SetBody([]byte(`{"idempotency_key":"p1","steps":[]}`))
+	ctx.Request.Header.Set(agentIDHeader, "agent-1")
+	ctx.Request.Header.Set(agentKeyHeader, key)
+
+	h.plan(context.Background(), ctx)
+
+	if got, want := ctx.Response.StatusCode(), consts.StatusBadRequest; got != want {
+		t.Fatalf("status mismatch: got=%d want=%d", got, want)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	errObj, _ := body["error"].(map[string]any)
+	if got, want := errObj["code"], "bad_request"; got != want {
+		t.Fatalf("error code mismatch: got=%q want=%q", got, want)
+	}
+}
+
+func TestPlanResult_ReportsCommittedStepsWithMidPlanError(t *testing.T) {
+	moved := action.Response{ResultCode: survival.ResultOK, UpdatedState: survival.AgentStateAggregate{AgentID: "agent-1", Version: 2}}
+	status, out := planResult(action.PlanResponse{
+		StopReason: action.PlanStopRejected,
+		Steps: []action.PlanStepResult{
+			{Index: 0, Intent: survival.ActionMove, Response: &moved},
+			{Index: 1, Intent: survival.ActionMove, Err: ports.ErrConflict},
+		},
+	})
+
+	if status != consts.StatusConflict {
+		t.Fatalf("status mismatch: got=%d want=%d", status, consts.StatusConflict)
+	}
+	if out.StepsSucceeded != 1 || len(out.Steps) != 2 || out.UpdatedState == nil || out.UpdatedState.Version != 2 {
+		t.Fatalf("expected committed first step reported, got=%+v", out)
+	}
+	if got := out.Steps[1]; got.ResultCode != "ERROR" || got.Error["code"] != "conflict" {
+		t.Fatalf("expected failed second step, got=%+v", got)
+	}
+	if out.StopReason != planStopError || out.Error["code"] != "conflict" {
+		t.Fatalf("expected plan stopped on error, got reason=%s error=%v", out.StopReason, out.Error)
+	}
+}
+
 func TestSkillsIndex_OK(t *testing.T) {
 	h := Handler{
 		SkillsUC: skills.UseCase{Provider: fakeSkillsProvider{
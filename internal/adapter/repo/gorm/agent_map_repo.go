@@ -0,0 +1,72 @@
+package gormrepo
+
+import (
+	"context"
+
+	"clawvival/internal/adapter/repo/gorm/model"
+	"clawvival/internal/app/ports"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AgentMapRepo struct {
+	db *gorm.DB
+}
+
+func NewAgentMapRepo(db *gorm.DB) AgentMapRepo {
+	return AgentMapRepo{db: db}
+}
+
+func (r AgentMapRepo) Upsert(ctx context.Context, tiles []ports.AgentMapTileRecord) error {
+	if len(tiles) == 0 {
+		return nil
+	}
+	rows := make([]model.AgentMapTile, 0, len(tiles))
+	for _, tile := range tiles {
+		rows = append(rows, model.AgentMapTile{
+			AgentID:      tile.AgentID,
+			SessionID:    tile.SessionID,
+			X:            int32(tile.X),
+			Y:            int32(tile.Y),
+			TerrainType:  tile.TerrainType,
+			IsWalkable:   tile.IsWalkable,
+			ResourceType: tile.ResourceType,
+			ObjectID:     tile.ObjectID,
+			ObjectType:   tile.ObjectType,
+			LastSeenAt:   tile.LastSeenAt,
+		})
+	}
+	return getDBFromCtx(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "agent_id"}, {Name: "session_id"}, {Name: "x"}, {Name: "y"}},
+			DoUpdates: clause.AssignmentColumns([]string{"terrain_type", "is_walkable", "resource_type", "object_id", "object_type", "last_seen_at"}),
+		}).
+		Create(&rows).Error
+}
+
+func (r AgentMapRepo) ListInBounds(ctx context.Context, agentID, sessionID string, minX, minY, maxX, maxY int) ([]ports.AgentMapTileRecord, error) {
+	var rows []model.AgentMapTile
+	if err := getDBFromCtx(ctx, r.db).
+		Where("agent_id = ? AND session_id = ? AND x BETWEEN ? AND ? AND y BETWEEN ? AND ?", agentID, sessionID, minX, maxX, minY, maxY).
+		Order("y ASC, x ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]ports.AgentMapTileRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ports.AgentMapTileRecord{
+			AgentID:      row.AgentID,
+			SessionID:    row.SessionID,
+			X:            int(row.X),
+			Y:            int(row.Y),
+			TerrainType:  row.TerrainType,
+			IsWalkable:   row.IsWalkable,
+			ResourceType: row.ResourceType,
+			ObjectID:     row.ObjectID,
+			ObjectType:   row.ObjectType,
+			LastSeenAt:   row.LastSeenAt,
+		})
+	}
+	return out, nil
+}
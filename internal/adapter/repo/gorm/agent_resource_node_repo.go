@@ -12,17 +12,63 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// sharedWorldScope replaces the agent id on resource node rows in shared
+// world mode so depletion is global. Agent ids always start with "agt_".
+const sharedWorldScope = "world"
+
 type AgentResourceNodeRepo struct {
-	db *gorm.DB
+	db     *gorm.DB
+	shared bool
 }
 
 func NewAgentResourceNodeRepo(db *gorm.DB) AgentResourceNodeRepo {
 	return AgentResourceNodeRepo{db: db}
 }
 
+func NewSharedAgentResourceNodeRepo(db *gorm.DB) AgentResourceNodeRepo {
+	return AgentResourceNodeRepo{db: db, shared: true}
+}
+
+func (r AgentResourceNodeRepo) scope(agentID string) string {
+	if r.shared {
+		return sharedWorldScope
+	}
+	return agentID
+}
+
 func (r AgentResourceNodeRepo) Upsert(ctx context.Context, record ports.AgentResourceNodeRecord) error {
-	row := model.AgentResourceNode{
-		AgentID:       record.AgentID,
+	row := r.toModel(record)
+	return getDBFromCtx(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "agent_id"}, {Name: "target_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"resource_type", "x", "y", "depleted_until", "updated_at"}),
+		}).
+		Create(&row).Error
+}
+
+func (r AgentResourceNodeRepo) Deplete(ctx context.Context, record ports.AgentResourceNodeRecord, now time.Time) error {
+	row := r.toModel(record)
+	res := getDBFromCtx(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "agent_id"}, {Name: "target_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"resource_type", "x", "y", "depleted_until", "updated_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Lte{Column: clause.Column{Table: model.TableNameAgentResourceNode, Name: "depleted_until"}, Value: now},
+			}},
+		}).
+		Create(&row)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ports.ErrConflict
+	}
+	return nil
+}
+
+func (r AgentResourceNodeRepo) toModel(record ports.AgentResourceNodeRecord) model.AgentResourceNode {
+	return model.AgentResourceNode{
+		AgentID:       r.scope(record.AgentID),
 		TargetID:      record.TargetID,
 		ResourceType:  record.ResourceType,
 		X:             int32(record.X),
@@ -30,18 +76,12 @@ func (r AgentResourceNodeRepo) Upsert(ctx context.Context, record ports.AgentRes
 		DepletedUntil: record.DepletedUntil,
 		UpdatedAt:     time.Now().UTC(),
 	}
-	return getDBFromCtx(ctx, r.db).
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "agent_id"}, {Name: "target_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"resource_type", "x", "y", "depleted_until", "updated_at"}),
-		}).
-		Create(&row).Error
 }
 
 func (r AgentResourceNodeRepo) GetByTargetID(ctx context.Context, agentID, targetID string) (ports.AgentResourceNodeRecord, error) {
 	var row model.AgentResourceNode
 	err := getDBFromCtx(ctx, r.db).
-		Where(&model.AgentResourceNode{AgentID: agentID, TargetID: targetID}).
+		Where(&model.AgentResourceNode{AgentID: r.scope(agentID), TargetID: targetID}).
 		First(&row).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -62,7 +102,7 @@ func (r AgentResourceNodeRepo) GetByTargetID(ctx context.Context, agentID, targe
 func (r AgentResourceNodeRepo) ListByAgentID(ctx context.Context, agentID string) ([]ports.AgentResourceNodeRecord, error) {
 	var rows []model.AgentResourceNode
 	if err := getDBFromCtx(ctx, r.db).
-		Where(&model.AgentResourceNode{AgentID: agentID}).
+		Where(&model.AgentResourceNode{AgentID: r.scope(agentID)}).
 		Find(&rows).Error; err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"clawvival/internal/adapter/repo/gorm/model"
+	"clawvival/internal/app/ports"
 	"clawvival/internal/domain/survival"
 
 	"gorm.io/gorm"
@@ -41,3 +42,28 @@ func (r AgentSessionRepo) Close(ctx context.Context, sessionID string, cause sur
 		Updates(updates)
 	return res.Error
 }
+
+func (r AgentSessionRepo) ListByAgentID(ctx context.Context, agentID string) ([]ports.AgentSessionRecord, error) {
+	var rows []model.AgentSession
+	err := getDBFromCtx(ctx, r.db).
+		Where(&model.AgentSession{AgentID: agentID}).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ports.AgentSessionRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ports.AgentSessionRecord{
+			SessionID:  row.SessionID,
+			AgentID:    row.AgentID,
+			StartTick:  row.StartTick,
+			Status:     row.Status,
+			DeathCause: row.DeathCause,
+			StartedAt:  row.CreatedAt,
+			EndedAt:    row.EndedAt,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package gormrepo
+
+import (
+	"context"
+	"time"
+
+	"clawvival/internal/adapter/repo/gorm/model"
+	"clawvival/internal/app/ports"
+
+	"gorm.io/gorm"
+)
+
+type MessageRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageRepo(db *gorm.DB) MessageRepo {
+	return MessageRepo{db: db}
+}
+
+func (r MessageRepo) Save(ctx context.Context, messages []ports.MessageRecord) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	rows := make([]model.AgentMessage, 0, len(messages))
+	for _, msg := range messages {
+		rows = append(rows, model.AgentMessage{
+			MessageID:   msg.MessageID,
+			FromAgentID: msg.FromAgentID,
+			ToAgentID:   msg.ToAgentID,
+			Mode:        msg.Mode,
+			Channel:     msg.Channel,
+			Text:        msg.Text,
+			X:           int32(msg.X),
+			Y:           int32(msg.Y),
+			CreatedAt:   msg.CreatedAt,
+		})
+	}
+	return getDBFromCtx(ctx, r.db).Create(&rows).Error
+}
+
+func (r MessageRepo) LockSender(ctx context.Context, agentID string) error {
+	return getDBFromCtx(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "agent_messages:"+agentID).Error
+}
+
+func (r MessageRepo) CountSentSince(ctx context.Context, agentID string, since time.Time) (int, error) {
+	var count int64
+	err := getDBFromCtx(ctx, r.db).
+		Model(&model.AgentMessage{}).
+		Where("from_agent_id = ? AND created_at >= ?", agentID, since).
+		Distinct("message_id").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r MessageRepo) ListInbox(ctx context.Context, agentID string, since time.Time, limit int) ([]ports.MessageRecord, error) {
+	var rows []model.AgentMessage
+	q := getDBFromCtx(ctx, r.db).
+		Where("(to_agent_id = ? OR (to_agent_id = '' AND mode = ?)) AND from_agent_id <> ? AND created_at >= ?", agentID, "broadcast", agentID, since).
+		Order("created_at DESC").
+		Order("id DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]ports.MessageRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ports.MessageRecord{
+			MessageID:   row.MessageID,
+			FromAgentID: row.FromAgentID,
+			ToAgentID:   row.ToAgentID,
+			Mode:        row.Mode,
+			Channel:     row.Channel,
+			Text:        row.Text,
+			X:           int(row.X),
+			Y:           int(row.Y),
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return out, nil
+}
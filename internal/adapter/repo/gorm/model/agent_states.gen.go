@@ -16,6 +16,7 @@ type AgentState struct {
 	Hp                   int32     `gorm:"column:hp;not null" json:"hp"`
 	Hunger               int32     `gorm:"column:hunger;not null" json:"hunger"`
 	Energy               int32     `gorm:"column:energy;not null" json:"energy"`
+	Thirst               int32     `gorm:"column:thirst;not null;default:80" json:"thirst"`
 	X                    int32     `gorm:"column:x;not null" json:"x"`
 	Y                    int32     `gorm:"column:y;not null" json:"y"`
 	Version              int64     `gorm:"column:version;not null" json:"version"`
@@ -26,8 +27,15 @@ type AgentState struct {
 	OngoingActionType    string    `gorm:"column:ongoing_action_type" json:"ongoing_action_type"`
 	OngoingActionEndAt   time.Time `gorm:"column:ongoing_action_end_at" json:"ongoing_action_end_at"`
 	OngoingActionMinutes int32     `gorm:"column:ongoing_action_minutes;not null" json:"ongoing_action_minutes"`
+	OngoingActionPath    string    `gorm:"column:ongoing_action_path;not null;default:[]" json:"ongoing_action_path"`
 	InventoryCapacity    int32     `gorm:"column:inventory_capacity;not null;default:30" json:"inventory_capacity"`
 	InventoryUsed        int32     `gorm:"column:inventory_used;not null" json:"inventory_used"`
+	ToolDurability       string    `gorm:"column:tool_durability;not null;default:{}" json:"tool_durability"`
+	ToolStacks           string    `gorm:"column:tool_stacks;not null;default:{}" json:"tool_stacks"`
+	Escrow               string    `gorm:"column:escrow;not null;default:{}" json:"escrow"`
+	Freshness            string    `gorm:"column:freshness;not null;default:{}" json:"freshness"`
+	Equipment            string    `gorm:"column:equipment;not null;default:{}" json:"equipment"`
+	SessionID            string    `gorm:"column:session_id;not null" json:"session_id"`
 }
 
 // TableName AgentState's table name
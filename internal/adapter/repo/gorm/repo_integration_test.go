@@ -40,7 +40,8 @@ func TestAgentStateRepo_RoundTripInventoryAndDeath(t *testing.T) {
 		AgentID:           agentID,
 		Vitals:            survival.Vitals{HP: 88, Hunger: 55, Energy: 44},
 		Position:          survival.Position{X: 2, Y: 3},
-		Inventory:         map[string]int{"wood": 3, "stone": 1},
+		Inventory:         map[string]int{"wood": 3, "stone": 1, "tool_axe": 1},
+		ToolStacks:        map[string][]survival.ToolStack{"tool_axe": {{Count: 1, Durability: 7}}},
 		InventoryCapacity: 40,
 		InventoryUsed:     4,
 		Dead:              true,
@@ -57,6 +58,9 @@ func TestAgentStateRepo_RoundTripInventoryAndDeath(t *testing.T) {
 	if got.Inventory["wood"] != 3 {
 		t.Fatalf("expected wood=3, got %d", got.Inventory["wood"])
 	}
+	if stacks := got.ToolStacks["tool_axe"]; len(stacks) != 1 || stacks[0].Durability != 7 {
+		t.Fatalf("expected axe wear to round-trip, got %+v", got.ToolStacks)
+	}
 	if got.InventoryCapacity != 40 || got.InventoryUsed != 4 {
 		t.Fatalf("expected inventory cap/used 40/4, got %d/%d", got.InventoryCapacity, got.InventoryUsed)
 	}
@@ -111,13 +115,20 @@ func TestWorldObjectAndSessionRepos_PersistLifecycle(t *testing.T) {
 	if updated.UsedSlots != 5 || updated.ObjectState == "" {
 		t.Fatalf("unexpected updated object: %+v", updated)
 	}
-	list, err := objRepo.ListByAgentID(ctx, agentID)
+	list, err := objRepo.ListInBounds(ctx, agentID, 0, 0, 10, 10)
 	if err != nil {
 		t.Fatalf("list objects: %v", err)
 	}
 	if len(list) != 1 || list[0].ObjectID != "obj-2" {
 		t.Fatalf("unexpected object list: %+v", list)
 	}
+	outside, err := objRepo.ListInBounds(ctx, agentID, 8, 0, 20, 20)
+	if err != nil {
+		t.Fatalf("list objects outside: %v", err)
+	}
+	if len(outside) != 0 {
+		t.Fatalf("expected object outside bounds skipped, got %+v", outside)
+	}
 
 	if err := sessionRepo.EnsureActive(ctx, sessionID, agentID, 1); err != nil {
 		t.Fatalf("ensure active: %v", err)
@@ -440,3 +451,250 @@ func TestAgentResourceNodeRepo_UpsertAndListByAgentID(t *testing.T) {
 		t.Fatalf("unexpected list: %+v", list)
 	}
 }
+
+func TestSharedWorldRepos_ObjectsAndDepletionAreGlobal(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	objectID := "it-shared-obj"
+	targetID := "res_9_9_wood"
+	_ = db.Exec("DELETE FROM world_objects WHERE object_id = ?", objectID).Error
+	_ = db.Exec("DELETE FROM agent_resource_nodes WHERE agent_id = ? AND target_id = ?", sharedWorldScope, targetID).Error
+
+	objRepo := NewSharedWorldObjectRepo(db)
+	if err := objRepo.Save(ctx, "it-shared-a", ports.WorldObjectRecord{ObjectID: objectID, Kind: 1, X: 9, Y: 9, HP: 100, ObjectType: "wall"}); err != nil {
+		t.Fatalf("save shared object: %v", err)
+	}
+	got, err := objRepo.GetByObjectID(ctx, "it-shared-b", objectID)
+	if err != nil {
+		t.Fatalf("expected other agent to see object, got %v", err)
+	}
+	if got.OwnerAgentID != "it-shared-a" {
+		t.Fatalf("expected owner recorded, got %q", got.OwnerAgentID)
+	}
+	got.HP = 1
+	if err := objRepo.Update(ctx, "it-shared-b", got); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected other agent update to miss, got %v", err)
+	}
+	boxID := objectID + "-box"
+	_ = db.Exec("DELETE FROM world_objects WHERE object_id = ?", boxID).Error
+	if err := objRepo.Save(ctx, "it-shared-a", ports.WorldObjectRecord{ObjectID: boxID, Kind: 2, X: 9, Y: 10, HP: 100, ObjectType: "box"}); err != nil {
+		t.Fatalf("save shared box: %v", err)
+	}
+	box, err := objRepo.GetByObjectID(ctx, "it-shared-b", boxID)
+	if err != nil {
+		t.Fatalf("get shared box: %v", err)
+	}
+	box.ObjectState = `{"inventory":{"wood":1}}`
+	if err := objRepo.Update(ctx, "it-shared-b", box); err != nil {
+		t.Fatalf("expected shared box writable by anyone, got %v", err)
+	}
+	box.ObjectState = `{"inventory":{"stone":1}}`
+	if err := objRepo.Update(ctx, "it-shared-a", box); !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("expected stale box write to conflict, got %v", err)
+	}
+	if err := objRepo.Delete(ctx, "it-shared-b", boxID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected only the owner to remove a shared box, got %v", err)
+	}
+
+	nodeRepo := NewSharedAgentResourceNodeRepo(db)
+	now := time.Unix(3000, 0).UTC()
+	record := ports.AgentResourceNodeRecord{AgentID: "it-shared-a", TargetID: targetID, ResourceType: "wood", X: 9, Y: 9, DepletedUntil: now.Add(time.Hour)}
+	if err := nodeRepo.Deplete(ctx, record, now); err != nil {
+		t.Fatalf("first deplete: %v", err)
+	}
+	record.AgentID = "it-shared-b"
+	if err := nodeRepo.Deplete(ctx, record, now); !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("expected second agent to conflict, got %v", err)
+	}
+	if _, err := nodeRepo.GetByTargetID(ctx, "it-shared-b", targetID); err != nil {
+		t.Fatalf("expected depletion visible to other agent, got %v", err)
+	}
+	if err := nodeRepo.Deplete(ctx, record, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("expected deplete after respawn, got %v", err)
+	}
+}
+
+func TestWorldObjectRepo_DeleteRemovesOwnedObjectOnly(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	objectID := "it-delete-obj"
+	_ = db.Exec("DELETE FROM world_objects WHERE object_id = ?", objectID).Error
+
+	objRepo := NewWorldObjectRepo(db)
+	if err := objRepo.Save(ctx, "it-delete-a", ports.WorldObjectRecord{ObjectID: objectID, Kind: 2, HP: 100, ObjectType: "box"}); err != nil {
+		t.Fatalf("save object: %v", err)
+	}
+	if err := objRepo.Delete(ctx, "it-delete-b", objectID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected other agent delete to miss, got %v", err)
+	}
+	if err := objRepo.Delete(ctx, "it-delete-a", objectID); err != nil {
+		t.Fatalf("delete object: %v", err)
+	}
+	if _, err := objRepo.GetByObjectID(ctx, "it-delete-a", objectID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected deleted object gone, got %v", err)
+	}
+}
+
+func TestWorldObjectRepo_UpdateWearKeepsStateAndSkipsStaleCheck(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	objectID := "it-wear-obj"
+	_ = db.Exec("DELETE FROM world_objects WHERE object_id = ?", objectID).Error
+
+	objRepo := NewWorldObjectRepo(db)
+	checked := time.Unix(4000, 0).UTC()
+	if err := objRepo.Save(ctx, "it-wear-a", ports.WorldObjectRecord{ObjectID: objectID, Kind: 2, HP: 100, ObjectType: "box", ObjectState: `{"inventory":{"wood":1}}`, DecayCheckedAt: checked}); err != nil {
+		t.Fatalf("save object: %v", err)
+	}
+	worn := ports.WorldObjectRecord{ObjectID: objectID, HP: 90, DecayCheckedAt: checked.Add(10 * time.Hour), WearCarry: 7}
+	if err := objRepo.UpdateWear(ctx, "it-wear-a", worn, checked.Add(time.Hour)); err != nil {
+		t.Fatalf("stale wear update: %v", err)
+	}
+	if got, _ := objRepo.GetByObjectID(ctx, "it-wear-a", objectID); got.HP != 100 {
+		t.Fatalf("expected stale wear skipped, got hp=%d", got.HP)
+	}
+	if err := objRepo.UpdateWear(ctx, "it-wear-a", worn, checked); err != nil {
+		t.Fatalf("wear update: %v", err)
+	}
+	got, err := objRepo.GetByObjectID(ctx, "it-wear-a", objectID)
+	if err != nil {
+		t.Fatalf("get object: %v", err)
+	}
+	if got.HP != 90 || got.WearCarry != 7 || got.ObjectState != `{"inventory":{"wood":1}}` {
+		t.Fatalf("expected only wear columns written, got %+v", got)
+	}
+}
+
+func TestTradeOfferRepo_KeepsStacksAndListsExpiredOpenOffers(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	_ = db.Exec("DELETE FROM trade_offers WHERE from_agent_id = ?", "it-trade-a").Error
+
+	repo := NewTradeOfferRepo(db)
+	created := time.Unix(5000, 0).UTC()
+	acquired := created.Add(-time.Hour)
+	for _, offer := range []ports.TradeOfferRecord{
+		{OfferID: "it-trade-expired", ExpiresAt: created.Add(time.Minute)},
+		{OfferID: "it-trade-live", ExpiresAt: created.Add(time.Hour)},
+	} {
+		offer.FromAgentID = "it-trade-a"
+		offer.ToAgentID = "it-trade-b"
+		offer.OfferItems = map[string]int{"berry": 2, "tool_axe": 1}
+		offer.OfferStacks = map[string][]survival.FoodStack{"berry": {{Count: 2, AcquiredAt: acquired}}}
+		offer.OfferTools = map[string][]survival.ToolStack{"tool_axe": {{Count: 1, Durability: 5}}}
+		offer.Status = survival.TradeStatusOpen
+		offer.CreatedAt = created
+		if err := repo.Create(ctx, offer); err != nil {
+			t.Fatalf("create offer: %v", err)
+		}
+	}
+	expired, err := repo.ListExpired(ctx, "it-trade-a", created.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("list expired: %v", err)
+	}
+	if len(expired) != 1 || expired[0].OfferID != "it-trade-expired" {
+		t.Fatalf("expected only the expired offer, got %+v", expired)
+	}
+	if got := expired[0].OfferStacks["berry"]; len(got) != 1 || !got[0].AcquiredAt.Equal(acquired) {
+		t.Fatalf("expected berry stack kept, got %+v", got)
+	}
+	if got := expired[0].OfferTools["tool_axe"]; len(got) != 1 || got[0].Durability != 5 {
+		t.Fatalf("expected axe wear kept, got %+v", got)
+	}
+	if err := repo.UpdateStatus(ctx, "it-trade-expired", survival.TradeStatusOpen, survival.TradeStatusExpired, created.Add(time.Minute)); err != nil {
+		t.Fatalf("expire offer: %v", err)
+	}
+	if expired, _ := repo.ListExpired(ctx, "it-trade-a", created.Add(time.Minute)); len(expired) != 0 {
+		t.Fatalf("expected closed offers skipped, got %+v", expired)
+	}
+}
+
+func TestAgentMapRepo_UpsertReplacesSightingAndListsInBounds(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	agentID := "it-agent-map"
+	_ = db.Exec("DELETE FROM agent_map_tiles WHERE agent_id = ?", agentID).Error
+
+	repo := NewAgentMapRepo(db)
+	first := time.Unix(1000, 0).UTC()
+	if err := repo.Upsert(ctx, []ports.AgentMapTileRecord{
+		{AgentID: agentID, SessionID: "s1", X: 0, Y: 0, TerrainType: "tree", ResourceType: "wood", LastSeenAt: first},
+		{AgentID: agentID, SessionID: "s1", X: 9, Y: 9, TerrainType: "grass", IsWalkable: true, LastSeenAt: first},
+		{AgentID: agentID, SessionID: "s2", X: 0, Y: 0, TerrainType: "rock", LastSeenAt: first},
+	}); err != nil {
+		t.Fatalf("upsert first: %v", err)
+	}
+	second := first.Add(time.Hour)
+	if err := repo.Upsert(ctx, []ports.AgentMapTileRecord{
+		{AgentID: agentID, SessionID: "s1", X: 0, Y: 0, TerrainType: "tree", LastSeenAt: second},
+	}); err != nil {
+		t.Fatalf("upsert second: %v", err)
+	}
+	got, err := repo.ListInBounds(ctx, agentID, "s1", -1, -1, 1, 1)
+	if err != nil {
+		t.Fatalf("list in bounds: %v", err)
+	}
+	if len(got) != 1 || got[0].ResourceType != "" || !got[0].LastSeenAt.Equal(second) {
+		t.Fatalf("expected replaced sighting only, got %+v", got)
+	}
+}
+
+func TestWorldCreatureRepo_DamageStacksAndSaveCannotResurrect(t *testing.T) {
+	dsn := requireDSN(t)
+	db, err := OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	ctx := context.Background()
+	coord := world.ChunkCoord{X: 77, Y: 77}
+	epoch := "it-night-1"
+	_ = db.Exec("DELETE FROM world_creatures WHERE chunk_x = ? AND chunk_y = ? AND epoch = ?", coord.X, coord.Y, epoch).Error
+
+	repo := NewWorldCreatureRepo(db)
+	wolf := world.Creature{ID: "it-crt-1", Kind: world.CreatureWolf, HP: 20, Damage: 4, Chunk: coord, Epoch: epoch}
+	if err := repo.SaveCreatures(ctx, coord, epoch, []world.Creature{wolf}); err != nil {
+		t.Fatalf("save creatures: %v", err)
+	}
+	if _, err := repo.Damage(ctx, wolf, 12); err != nil {
+		t.Fatalf("first damage: %v", err)
+	}
+	hp, err := repo.Damage(ctx, wolf, 12)
+	if err != nil {
+		t.Fatalf("second damage: %v", err)
+	}
+	if hp != 0 {
+		t.Fatalf("expected both hits to land, got hp=%d", hp)
+	}
+
+	wolf.Pos = world.Point{X: 1, Y: 1}
+	if err := repo.SaveCreatures(ctx, coord, epoch, []world.Creature{wolf}); err != nil {
+		t.Fatalf("save moved creature: %v", err)
+	}
+	got, _, err := repo.GetCreatures(ctx, coord, epoch)
+	if err != nil {
+		t.Fatalf("get creatures: %v", err)
+	}
+	if len(got) != 1 || got[0].HP != 0 || got[0].Pos != wolf.Pos {
+		t.Fatalf("expected moved creature to stay dead, got %+v", got)
+	}
+}
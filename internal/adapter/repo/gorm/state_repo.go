@@ -30,22 +30,30 @@ func (r AgentStateRepo) GetByAgentID(ctx context.Context, agentID string) (survi
 		return survival.AgentStateAggregate{}, err
 	}
 	return survival.AgentStateAggregate{
-		AgentID: agentID,
+		AgentID:   agentID,
+		SessionID: m.SessionID,
 		Vitals: survival.Vitals{
 			HP:     int(m.Hp),
 			Hunger: int(m.Hunger),
 			Energy: int(m.Energy),
+			Thirst: int(m.Thirst),
 		},
 		Position:          survival.Position{X: int(m.X), Y: int(m.Y)},
 		Inventory:         decodeInventory(m.Inventory),
 		InventoryCapacity: int(m.InventoryCapacity),
 		InventoryUsed:     int(m.InventoryUsed),
+		ToolDurability:    decodeInventory(m.ToolDurability),
+		ToolStacks:        decodeToolStacks(m.ToolStacks),
+		Escrow:            decodeInventory(m.Escrow),
+		Freshness:         decodeFreshness(m.Freshness),
+		Equipment:         decodeEquipment(m.Equipment),
 		Dead:              m.Dead,
 		DeathCause:        survival.DeathCause(m.DeathCause),
 		OngoingAction: decodeOngoingAction(
 			m.OngoingActionType,
 			m.OngoingActionMinutes,
 			m.OngoingActionEndAt,
+			m.OngoingActionPath,
 		),
 		Version: m.Version,
 	}, nil
@@ -59,12 +67,19 @@ func (r AgentStateRepo) SaveWithVersion(ctx context.Context, state survival.Agen
 			Hp:                int32(state.Vitals.HP),
 			Hunger:            int32(state.Vitals.Hunger),
 			Energy:            int32(state.Vitals.Energy),
+			Thirst:            int32(state.Vitals.Thirst),
 			X:                 int32(state.Position.X),
 			Y:                 int32(state.Position.Y),
 			Version:           state.Version,
 			Inventory:         encodeInventory(state.Inventory),
 			InventoryCapacity: int32(resolveInventoryCapacity(state)),
 			InventoryUsed:     int32(resolveInventoryUsed(state)),
+			ToolDurability:    encodeInventory(state.ToolDurability),
+			ToolStacks:        encodeToolStacks(state.ToolStacks),
+			Escrow:            encodeInventory(state.Escrow),
+			Freshness:         encodeFreshness(state.Freshness),
+			Equipment:         encodeEquipment(state.Equipment),
+			SessionID:         state.SessionID,
 			Dead:              state.Dead,
 			DeathCause:        string(state.DeathCause),
 		}
@@ -79,23 +94,35 @@ func (r AgentStateRepo) SaveWithVersion(ctx context.Context, state survival.Agen
 		"hp":                 int32(state.Vitals.HP),
 		"hunger":             int32(state.Vitals.Hunger),
 		"energy":             int32(state.Vitals.Energy),
+		"thirst":             int32(state.Vitals.Thirst),
 		"x":                  int32(state.Position.X),
 		"y":                  int32(state.Position.Y),
 		"version":            state.Version,
 		"inventory":          encodeInventory(state.Inventory),
 		"inventory_capacity": int32(resolveInventoryCapacity(state)),
 		"inventory_used":     int32(resolveInventoryUsed(state)),
+		"tool_durability":    encodeInventory(state.ToolDurability),
+		"tool_stacks":        encodeToolStacks(state.ToolStacks),
+		"escrow":             encodeInventory(state.Escrow),
+		"freshness":          encodeFreshness(state.Freshness),
+		"equipment":          encodeEquipment(state.Equipment),
 		"dead":               state.Dead,
 		"death_cause":        string(state.DeathCause),
 	}
+	// Callers that loaded state without a session keep the stored one.
+	if state.SessionID != "" {
+		updates["session_id"] = state.SessionID
+	}
 	if state.OngoingAction == nil {
 		updates["ongoing_action_type"] = ""
 		updates["ongoing_action_end_at"] = time.Time{}
 		updates["ongoing_action_minutes"] = 0
+		updates["ongoing_action_path"] = encodePath(nil)
 	} else {
 		updates["ongoing_action_type"] = string(state.OngoingAction.Type)
 		updates["ongoing_action_end_at"] = state.OngoingAction.EndAt
 		updates["ongoing_action_minutes"] = state.OngoingAction.Minutes
+		updates["ongoing_action_path"] = encodePath(state.OngoingAction.Path)
 	}
 
 	res := db.Model(&model.AgentState{}).
@@ -110,6 +137,36 @@ func (r AgentStateRepo) SaveWithVersion(ctx context.Context, state survival.Agen
 	return nil
 }
 
+func (r AgentStateRepo) ListLivingNear(ctx context.Context, center survival.Position, radius int) ([]string, error) {
+	var rows []model.AgentState
+	err := getDBFromCtx(ctx, r.db).
+		Select("agent_id", "x", "y").
+		Where("dead = ? AND x BETWEEN ? AND ? AND y BETWEEN ? AND ?", false, center.X-radius, center.X+radius, center.Y-radius, center.Y+radius).
+		Order("agent_id ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if absInt(int(row.X)-center.X)+absInt(int(row.Y)-center.Y) > radius {
+			continue
+		}
+		out = append(out, row.AgentID)
+	}
+	return out, nil
+}
+
+func (r AgentStateRepo) ListLiving(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := getDBFromCtx(ctx, r.db).
+		Model(&model.AgentState{}).
+		Where("dead = ?", false).
+		Order("agent_id ASC").
+		Pluck("agent_id", &ids).Error
+	return ids, err
+}
+
 func encodeInventory(inv map[string]int) string {
 	if len(inv) == 0 {
 		return "{}"
@@ -127,7 +184,75 @@ func decodeInventory(raw string) map[string]int {
 	return out
 }
 
-func decodeOngoingAction(actionType string, minutes int32, endAt time.Time) *survival.OngoingActionInfo {
+func encodeToolStacks(stacks map[string][]survival.ToolStack) string {
+	if len(stacks) == 0 {
+		return "{}"
+	}
+	b, _ := json.Marshal(stacks)
+	return string(b)
+}
+
+func decodeToolStacks(raw string) map[string][]survival.ToolStack {
+	if raw == "" || raw == "{}" {
+		return nil
+	}
+	out := map[string][]survival.ToolStack{}
+	_ = json.Unmarshal([]byte(raw), &out)
+	return out
+}
+
+func encodeFreshness(stacks map[string][]survival.FoodStack) string {
+	if len(stacks) == 0 {
+		return "{}"
+	}
+	b, _ := json.Marshal(stacks)
+	return string(b)
+}
+
+func decodeFreshness(raw string) map[string][]survival.FoodStack {
+	if raw == "" || raw == "{}" {
+		return nil
+	}
+	out := map[string][]survival.FoodStack{}
+	_ = json.Unmarshal([]byte(raw), &out)
+	return out
+}
+
+func encodeEquipment(equipment map[string]string) string {
+	if len(equipment) == 0 {
+		return "{}"
+	}
+	b, _ := json.Marshal(equipment)
+	return string(b)
+}
+
+func decodeEquipment(raw string) map[string]string {
+	if raw == "" || raw == "{}" {
+		return nil
+	}
+	out := map[string]string{}
+	_ = json.Unmarshal([]byte(raw), &out)
+	return out
+}
+
+func encodePath(path []survival.Position) string {
+	if len(path) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(path)
+	return string(b)
+}
+
+func decodePath(raw string) []survival.Position {
+	if raw == "" || raw == "[]" {
+		return nil
+	}
+	out := []survival.Position{}
+	_ = json.Unmarshal([]byte(raw), &out)
+	return out
+}
+
+func decodeOngoingAction(actionType string, minutes int32, endAt time.Time, path string) *survival.OngoingActionInfo {
 	if actionType == "" || endAt.IsZero() {
 		return nil
 	}
@@ -138,6 +263,7 @@ func decodeOngoingAction(actionType string, minutes int32, endAt time.Time) *sur
 		Type:    survival.ActionType(actionType),
 		Minutes: int(minutes),
 		EndAt:   endAt,
+		Path:    decodePath(path),
 	}
 }
 
@@ -146,12 +272,14 @@ func applyOngoingActionModel(m *model.AgentState, ongoing *survival.OngoingActio
 		m.OngoingActionType = ""
 		m.OngoingActionEndAt = time.Time{}
 		m.OngoingActionMinutes = 0
+		m.OngoingActionPath = encodePath(nil)
 		return
 	}
 	endAt := ongoing.EndAt
 	m.OngoingActionType = string(ongoing.Type)
 	m.OngoingActionEndAt = endAt
 	m.OngoingActionMinutes = int32(ongoing.Minutes)
+	m.OngoingActionPath = encodePath(ongoing.Path)
 }
 
 func resolveInventoryCapacity(state survival.AgentStateAggregate) int {
@@ -173,3 +301,10 @@ func resolveInventoryUsed(state survival.AgentStateAggregate) int {
 	}
 	return total
 }
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"clawvival/internal/adapter/repo/gorm/model"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+
+	"gorm.io/gorm"
+)
+
+type TradeOfferRepo struct {
+	db *gorm.DB
+}
+
+func NewTradeOfferRepo(db *gorm.DB) TradeOfferRepo {
+	return TradeOfferRepo{db: db}
+}
+
+func (r TradeOfferRepo) Create(ctx context.Context, offer ports.TradeOfferRecord) error {
+	row := model.TradeOffer{
+		OfferID:      offer.OfferID,
+		FromAgentID:  offer.FromAgentID,
+		ToAgentID:    offer.ToAgentID,
+		OfferItems:   encodeInventory(offer.OfferItems),
+		RequestItems: encodeInventory(offer.RequestItems),
+		OfferStacks:  encodeFreshness(offer.OfferStacks),
+		OfferTools:   encodeToolStacks(offer.OfferTools),
+		Status:       offer.Status,
+		CreatedAt:    offer.CreatedAt,
+		ExpiresAt:    offer.ExpiresAt,
+		UpdatedAt:    offer.CreatedAt,
+	}
+	return getDBFromCtx(ctx, r.db).Create(&row).Error
+}
+
+func (r TradeOfferRepo) GetByOfferID(ctx context.Context, offerID string) (ports.TradeOfferRecord, error) {
+	var row model.TradeOffer
+	err := getDBFromCtx(ctx, r.db).
+		Where(&model.TradeOffer{OfferID: offerID}).
+		First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.TradeOfferRecord{}, ports.ErrNotFound
+		}
+		return ports.TradeOfferRecord{}, err
+	}
+	return toTradeOfferRecord(row), nil
+}
+
+func (r TradeOfferRepo) ListExpired(ctx context.Context, fromAgentID string, now time.Time) ([]ports.TradeOfferRecord, error) {
+	var rows []model.TradeOffer
+	err := getDBFromCtx(ctx, r.db).
+		Where(&model.TradeOffer{FromAgentID: fromAgentID, Status: survival.TradeStatusOpen}).
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC, offer_id ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ports.TradeOfferRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toTradeOfferRecord(row))
+	}
+	return out, nil
+}
+
+func (r TradeOfferRepo) UpdateStatus(ctx context.Context, offerID, fromStatus, toStatus string, at time.Time) error {
+	res := getDBFromCtx(ctx, r.db).
+		Model(&model.TradeOffer{}).
+		Where(&model.TradeOffer{OfferID: offerID, Status: fromStatus}).
+		Updates(map[string]any{"status": toStatus, "updated_at": at})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ports.ErrConflict
+	}
+	return nil
+}
+
+func toTradeOfferRecord(row model.TradeOffer) ports.TradeOfferRecord {
+	return ports.TradeOfferRecord{
+		OfferID:      row.OfferID,
+		FromAgentID:  row.FromAgentID,
+		ToAgentID:    row.ToAgentID,
+		OfferItems:   decodeInventory(row.OfferItems),
+		RequestItems: decodeInventory(row.RequestItems),
+		OfferStacks:  decodeFreshness(row.OfferStacks),
+		OfferTools:   decodeToolStacks(row.OfferTools),
+		Status:       row.Status,
+		CreatedAt:    row.CreatedAt,
+		ExpiresAt:    row.ExpiresAt,
+	}
+}
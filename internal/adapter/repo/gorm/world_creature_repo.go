@@ -0,0 +1,149 @@
+package gormrepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"clawvival/internal/adapter/repo/gorm/model"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/world"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorldCreatureRepo struct {
+	db *gorm.DB
+}
+
+func NewWorldCreatureRepo(db *gorm.DB) WorldCreatureRepo {
+	return WorldCreatureRepo{db: db}
+}
+
+func (r WorldCreatureRepo) GetCreatures(ctx context.Context, coord world.ChunkCoord, epoch string) ([]world.Creature, bool, error) {
+	var row model.WorldCreature
+	err := getDBFromCtx(ctx, r.db).WithContext(ctx).
+		Where(map[string]any{
+			"chunk_x": int32(coord.X),
+			"chunk_y": int32(coord.Y),
+			"epoch":   epoch,
+		}).
+		First(&row).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	creatures, err := decodeCreatures(row.Creatures)
+	if err != nil {
+		return nil, false, err
+	}
+	return creatures, true, nil
+}
+
+// SaveCreatures persists a chunk's creatures. Creatures never heal within an
+// epoch, so the lower of the stored and incoming HP wins; a snapshot taken
+// before a kill cannot resurrect the creature when its movement is saved.
+func (r WorldCreatureRepo) SaveCreatures(ctx context.Context, coord world.ChunkCoord, epoch string, creatures []world.Creature) error {
+	return getDBFromCtx(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, ok, err := lockCreatures(tx, coord, epoch)
+		if err != nil {
+			return err
+		}
+		merged := make([]world.Creature, len(creatures))
+		copy(merged, creatures)
+		if ok {
+			hp := make(map[string]int, len(stored))
+			for _, c := range stored {
+				hp[c.ID] = c.HP
+			}
+			for i := range merged {
+				if prev, seen := hp[merged[i].ID]; seen && prev < merged[i].HP {
+					merged[i].HP = prev
+				}
+			}
+		}
+		return saveCreatures(tx, coord, epoch, merged)
+	})
+}
+
+// Damage lowers one creature's HP while holding its chunk row, so concurrent
+// attacks on the same chunk all land.
+func (r WorldCreatureRepo) Damage(ctx context.Context, creature world.Creature, damage int) (int, error) {
+	hpAfter := 0
+	err := getDBFromCtx(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		creatures, ok, err := lockCreatures(tx, creature.Chunk, creature.Epoch)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ports.ErrNotFound
+		}
+		for i := range creatures {
+			if creatures[i].ID != creature.ID {
+				continue
+			}
+			creatures[i].HP -= damage
+			if creatures[i].HP < 0 {
+				creatures[i].HP = 0
+			}
+			hpAfter = creatures[i].HP
+			return saveCreatures(tx, creature.Chunk, creature.Epoch, creatures)
+		}
+		return ports.ErrNotFound
+	})
+	return hpAfter, err
+}
+
+func lockCreatures(tx *gorm.DB, coord world.ChunkCoord, epoch string) ([]world.Creature, bool, error) {
+	var row model.WorldCreature
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(map[string]any{
+			"chunk_x": int32(coord.X),
+			"chunk_y": int32(coord.Y),
+			"epoch":   epoch,
+		}).
+		First(&row).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	creatures, err := decodeCreatures(row.Creatures)
+	if err != nil {
+		return nil, false, err
+	}
+	return creatures, true, nil
+}
+
+func saveCreatures(tx *gorm.DB, coord world.ChunkCoord, epoch string, creatures []world.Creature) error {
+	b, err := json.Marshal(creatures)
+	if err != nil {
+		return err
+	}
+	row := model.WorldCreature{
+		ChunkX:    int32(coord.X),
+		ChunkY:    int32(coord.Y),
+		Epoch:     epoch,
+		Creatures: string(b),
+		UpdatedAt: time.Now(),
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chunk_x"}, {Name: "chunk_y"}, {Name: "epoch"}},
+		DoUpdates: clause.AssignmentColumns([]string{"creatures", "updated_at"}),
+	}).Create(&row).Error
+}
+
+func decodeCreatures(raw string) ([]world.Creature, error) {
+	out := []world.Creature{}
+	if raw == "" {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"clawvival/internal/adapter/repo/gorm/model"
 	"clawvival/internal/app/ports"
@@ -12,13 +13,28 @@ import (
 )
 
 type WorldObjectRepo struct {
-	db *gorm.DB
+	db     *gorm.DB
+	shared bool
 }
 
 func NewWorldObjectRepo(db *gorm.DB) WorldObjectRepo {
 	return WorldObjectRepo{db: db}
 }
 
+// NewSharedWorldObjectRepo records owners on save and lets every agent see
+// every object; updates stay with the owner except on shared boxes.
+func NewSharedWorldObjectRepo(db *gorm.DB) WorldObjectRepo {
+	return WorldObjectRepo{db: db, shared: true}
+}
+
+// gorm skips zero-valued struct conditions, so an empty owner matches every row.
+func (r WorldObjectRepo) ownerFilter(agentID string) string {
+	if r.shared {
+		return ""
+	}
+	return agentID
+}
+
 func (r WorldObjectRepo) Save(ctx context.Context, agentID string, obj ports.WorldObjectRecord) error {
 	m := model.WorldObject{
 		ObjectID:     obj.ObjectID,
@@ -30,6 +46,10 @@ func (r WorldObjectRepo) Save(ctx context.Context, agentID string, obj ports.Wor
 		ObjectType:   obj.ObjectType,
 		Quality:      obj.Quality,
 		ObjectState:  obj.ObjectState,
+		WearCarry:    int32(obj.WearCarry),
+	}
+	if !obj.DecayCheckedAt.IsZero() {
+		m.DecayCheckedAt = obj.DecayCheckedAt
 	}
 	if obj.CapacitySlots > 0 {
 		m.CapacitySlots = int32(obj.CapacitySlots)
@@ -43,7 +63,8 @@ func (r WorldObjectRepo) Save(ctx context.Context, agentID string, obj ports.Wor
 func (r WorldObjectRepo) GetByObjectID(ctx context.Context, agentID, objectID string) (ports.WorldObjectRecord, error) {
 	var m model.WorldObject
 	err := getDBFromCtx(ctx, r.db).
-		Where(&model.WorldObject{OwnerAgentID: agentID, ObjectID: objectID}).
+		Where(&model.WorldObject{OwnerAgentID: r.ownerFilter(agentID), ObjectID: objectID}).
+		Where("hp > 0").
 		First(&m).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -51,41 +72,22 @@ func (r WorldObjectRepo) GetByObjectID(ctx context.Context, agentID, objectID st
 		}
 		return ports.WorldObjectRecord{}, err
 	}
-	kind, _ := strconv.Atoi(m.Kind)
-	return ports.WorldObjectRecord{
-		ObjectID:      m.ObjectID,
-		Kind:          kind,
-		X:             int(m.X),
-		Y:             int(m.Y),
-		HP:            int(m.Hp),
-		ObjectType:    m.ObjectType,
-		Quality:       m.Quality,
-		CapacitySlots: int(m.CapacitySlots),
-		UsedSlots:     int(m.UsedSlots),
-		ObjectState:   m.ObjectState,
-	}, nil
+	return toWorldObjectRecord(m), nil
 }
 
-func (r WorldObjectRepo) ListByAgentID(ctx context.Context, agentID string) ([]ports.WorldObjectRecord, error) {
+func (r WorldObjectRepo) ListInBounds(ctx context.Context, agentID string, minX, minY, maxX, maxY int) ([]ports.WorldObjectRecord, error) {
 	var rows []model.WorldObject
-	if err := getDBFromCtx(ctx, r.db).Where(&model.WorldObject{OwnerAgentID: agentID}).Find(&rows).Error; err != nil {
+	// Destroyed objects keep their row at zero HP but are no longer listed.
+	if err := getDBFromCtx(ctx, r.db).
+		Where(&model.WorldObject{OwnerAgentID: r.ownerFilter(agentID)}).
+		Where("x BETWEEN ? AND ? AND y BETWEEN ? AND ?", minX, maxX, minY, maxY).
+		Where("hp > 0").
+		Find(&rows).Error; err != nil {
 		return nil, err
 	}
 	out := make([]ports.WorldObjectRecord, 0, len(rows))
 	for _, m := range rows {
-		kind, _ := strconv.Atoi(m.Kind)
-		out = append(out, ports.WorldObjectRecord{
-			ObjectID:      m.ObjectID,
-			Kind:          kind,
-			X:             int(m.X),
-			Y:             int(m.Y),
-			HP:            int(m.Hp),
-			ObjectType:    m.ObjectType,
-			Quality:       m.Quality,
-			CapacitySlots: int(m.CapacitySlots),
-			UsedSlots:     int(m.UsedSlots),
-			ObjectState:   m.ObjectState,
-		})
+		out = append(out, toWorldObjectRecord(m))
 	}
 	return out, nil
 }
@@ -98,9 +100,84 @@ func (r WorldObjectRepo) Update(ctx context.Context, agentID string, obj ports.W
 		"capacity_slots": obj.CapacitySlots,
 		"used_slots":     obj.UsedSlots,
 		"object_state":   obj.ObjectState,
+		"wear_carry":     obj.WearCarry,
+		"version":        gorm.Expr("version + 1"),
+	}
+	if !obj.DecayCheckedAt.IsZero() {
+		updates["decay_checked_at"] = obj.DecayCheckedAt
+	}
+	writable := func() *gorm.DB {
+		query := getDBFromCtx(ctx, r.db).
+			Model(&model.WorldObject{}).
+			Where(&model.WorldObject{OwnerAgentID: r.ownerFilter(agentID), ObjectID: obj.ObjectID})
+		if r.shared {
+			query = query.Where("(COALESCE(owner_agent_id, '') IN (?, '') OR object_type = ?)", agentID, "box")
+		}
+		return query
+	}
+	res := writable().Where("version = ?", obj.Version).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
 	}
+	var count int64
+	if err := writable().Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ports.ErrConflict
+	}
+	return ports.ErrNotFound
+}
+
+// UpdateWear is checked against decay_checked_at instead and leaves the
+// version alone, so wear applied while preparing an action does not make
+// that action's own Update conflict.
+func (r WorldObjectRepo) UpdateWear(ctx context.Context, agentID string, obj ports.WorldObjectRecord, checkedAt time.Time) error {
 	return getDBFromCtx(ctx, r.db).
 		Model(&model.WorldObject{}).
-		Where(&model.WorldObject{OwnerAgentID: agentID, ObjectID: obj.ObjectID}).
-		Updates(updates).Error
+		Where(&model.WorldObject{OwnerAgentID: r.ownerFilter(agentID), ObjectID: obj.ObjectID}).
+		Where("decay_checked_at = ?", checkedAt).
+		Updates(map[string]any{
+			"hp":               obj.HP,
+			"decay_checked_at": obj.DecayCheckedAt,
+			"wear_carry":       obj.WearCarry,
+		}).Error
+}
+
+// Delete only removes the agent's own objects, in shared mode too.
+func (r WorldObjectRepo) Delete(ctx context.Context, agentID, objectID string) error {
+	res := getDBFromCtx(ctx, r.db).
+		Where(&model.WorldObject{ObjectID: objectID}).
+		Where("COALESCE(owner_agent_id, '') IN (?, '')", agentID).
+		Delete(&model.WorldObject{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+func toWorldObjectRecord(m model.WorldObject) ports.WorldObjectRecord {
+	kind, _ := strconv.Atoi(m.Kind)
+	return ports.WorldObjectRecord{
+		ObjectID:       m.ObjectID,
+		Kind:           kind,
+		X:              int(m.X),
+		Y:              int(m.Y),
+		HP:             int(m.Hp),
+		ObjectType:     m.ObjectType,
+		Quality:        m.Quality,
+		CapacitySlots:  int(m.CapacitySlots),
+		UsedSlots:      int(m.UsedSlots),
+		ObjectState:    m.ObjectState,
+		OwnerAgentID:   m.OwnerAgentID,
+		DecayCheckedAt: m.DecayCheckedAt,
+		WearCarry:      int(m.WearCarry),
+		Version:        m.Version,
+	}
 }
@@ -0,0 +1,132 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"clawvival/internal/domain/world"
+)
+
+type CreatureStore interface {
+	GetCreatures(ctx context.Context, coord world.ChunkCoord, epoch string) ([]world.Creature, bool, error)
+	SaveCreatures(ctx context.Context, coord world.ChunkCoord, epoch string, creatures []world.Creature) error
+}
+
+func creatureEpoch(phase world.Phase, cycle int64) string {
+	return fmt.Sprintf("%s-%d", phase, cycle)
+}
+
+func (p Provider) creaturesForWindow(ctx context.Context, center world.Point, phase world.Phase, nowAt time.Time) ([]world.Creature, error) {
+	cycle := p.cfg.Clock.CycleAt(nowAt)
+	epoch := p.storeKey(creatureEpoch(phase, cycle))
+	reach := p.cfg.ViewRadius + world.CreatureLeashRadius
+	minX := floorDiv(center.X-reach, p.chunkSize)
+	maxX := floorDiv(center.X+reach, p.chunkSize)
+	minY := floorDiv(center.Y-reach, p.chunkSize)
+	maxY := floorDiv(center.Y+reach, p.chunkSize)
+
+	out := []world.Creature{}
+	for cy := minY; cy <= maxY; cy++ {
+		for cx := minX; cx <= maxX; cx++ {
+			coord := world.ChunkCoord{X: cx, Y: cy}
+			creatures, ok := []world.Creature(nil), false
+			if p.cfg.CreatureStore != nil {
+				var err error
+				creatures, ok, err = p.cfg.CreatureStore.GetCreatures(ctx, coord, epoch)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !ok {
+				creatures = p.spawnCreatures(coord, phase, cycle, epochStart(nowAt, p.cfg.Clock, phase))
+			}
+			moved := false
+			for i := range creatures {
+				next, changed := p.advanceCreature(creatures[i], nowAt)
+				creatures[i] = next
+				moved = moved || changed
+			}
+			if p.cfg.CreatureStore != nil && (!ok || moved) {
+				if err := p.cfg.CreatureStore.SaveCreatures(ctx, coord, epoch, creatures); err != nil {
+					return nil, err
+				}
+			}
+			for _, c := range creatures {
+				if !c.Alive() {
+					continue
+				}
+				if c.Pos.X < center.X-p.cfg.ViewRadius || c.Pos.X > center.X+p.cfg.ViewRadius || c.Pos.Y < center.Y-p.cfg.ViewRadius || c.Pos.Y > center.Y+p.cfg.ViewRadius {
+					continue
+				}
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (p Provider) spawnCreatures(coord world.ChunkCoord, phase world.Phase, cycle int64, spawnedAt time.Time) []world.Creature {
+	baseX := coord.X * p.chunkSize
+	baseY := coord.Y * p.chunkSize
+	zone := p.cfg.Terrain.Tile(baseX+p.chunkSize/2, baseY+p.chunkSize/2).Zone
+	rule, ok := world.CreatureSpawnRuleFor(zone, phase)
+	if !ok {
+		return []world.Creature{}
+	}
+	seed := tileSeed(coord.X*31+int(cycle), coord.Y*17+len(phase))
+	if seed%100 >= rule.ChancePercent {
+		return []world.Creature{}
+	}
+	for attempt := 0; attempt < p.chunkSize; attempt++ {
+		x := baseX + (seed/7+attempt*3)%p.chunkSize
+		y := baseY + (seed/11+attempt*5)%p.chunkSize
+		if !p.cfg.Terrain.Tile(x, y).Passable {
+			continue
+		}
+		pos := world.Point{X: x, Y: y}
+		epoch := p.storeKey(creatureEpoch(phase, cycle))
+		return []world.Creature{{
+			ID:      fmt.Sprintf("crt_%s_%d_%d_0", epoch, coord.X, coord.Y),
+			Kind:    rule.Kind,
+			Pos:     pos,
+			Home:    pos,
+			HP:      rule.HP,
+			Damage:  rule.Damage,
+			MovedAt: spawnedAt,
+			Chunk:   coord,
+			Epoch:   epoch,
+		}}
+	}
+	return []world.Creature{}
+}
+
+// advanceCreature replays every step tick since the creature last moved. Each
+// step depends only on world time and the creature's own state, so positions
+// are the same no matter which agent observes or how often.
+func (p Provider) advanceCreature(c world.Creature, nowAt time.Time) (world.Creature, bool) {
+	if !c.Alive() {
+		return c, false
+	}
+	steps := int(nowAt.Sub(c.MovedAt) / world.CreatureStepInterval)
+	if steps <= 0 {
+		return c, false
+	}
+	passable := func(pt world.Point) bool { return p.cfg.Terrain.Tile(pt.X, pt.Y).Passable }
+	for i := 0; i < steps; i++ {
+		tick := c.MovedAt.Add(time.Duration(i+1) * world.CreatureStepInterval)
+		c = world.StepCreature(c, nil, passable, tileSeed(len(c.ID)+int(tick.Unix()/60), c.Pos.X*7+c.Pos.Y))
+	}
+	c.MovedAt = c.MovedAt.Add(time.Duration(steps) * world.CreatureStepInterval)
+	return c, true
+}
+
+func epochStart(nowAt time.Time, clock world.Clock, phase world.Phase) time.Time {
+	_, remaining := clock.PhaseAt(nowAt)
+	length := clock.PhaseDuration(phase)
+	elapsed := length - remaining
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return nowAt.Add(-elapsed)
+}
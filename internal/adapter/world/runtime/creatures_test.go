@@ -0,0 +1,143 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"clawvival/internal/domain/world"
+)
+
+type fakeCreatureStore struct {
+	byChunk map[string][]world.Creature
+	saves   int
+}
+
+func (s *fakeCreatureStore) GetCreatures(_ context.Context, coord world.ChunkCoord, epoch string) ([]world.Creature, bool, error) {
+	c, ok := s.byChunk[epoch+":"+key(coord)]
+	if !ok {
+		return nil, false, nil
+	}
+	out := make([]world.Creature, len(c))
+	copy(out, c)
+	return out, true, nil
+}
+
+func (s *fakeCreatureStore) SaveCreatures(_ context.Context, coord world.ChunkCoord, epoch string, creatures []world.Creature) error {
+	if s.byChunk == nil {
+		s.byChunk = map[string][]world.Creature{}
+	}
+	s.saves++
+	out := make([]world.Creature, len(creatures))
+	copy(out, creatures)
+	s.byChunk[epoch+":"+key(coord)] = out
+	return nil
+}
+
+func TestProvider_SpawnsAndPersistsCreaturesAtNightInWildZone(t *testing.T) {
+	start := time.Unix(0, 0)
+	now := start.Add(11 * time.Minute)
+	store := &fakeCreatureStore{}
+	p := NewProvider(Config{
+		Clock: world.NewClock(world.ClockConfig{
+			StartAt:       start,
+			DayDuration:   10 * time.Minute,
+			NightDuration: 5 * time.Minute,
+		}),
+		ViewRadius:    5,
+		CreatureStore: store,
+		Now:           func() time.Time { return now },
+	})
+
+	s, err := p.SnapshotForAgent(context.Background(), "agent-1", world.Point{X: 100, Y: 0})
+	if err != nil {
+		t.Fatalf("SnapshotForAgent error: %v", err)
+	}
+	if store.saves == 0 {
+		t.Fatalf("expected spawned creatures to be persisted")
+	}
+	for _, c := range s.Creatures {
+		if abs := c.Pos.X - 100; abs > 5 || abs < -5 || c.Pos.Y > 5 || c.Pos.Y < -5 {
+			t.Fatalf("creature outside view window leaked: %+v", c)
+		}
+	}
+
+	before := map[string]world.Creature{}
+	for _, list := range store.byChunk {
+		for _, c := range list {
+			before[c.ID] = c
+		}
+	}
+	if len(before) == 0 {
+		t.Fatalf("expected wild night chunks to spawn creatures")
+	}
+	now = now.Add(3 * time.Minute)
+	if _, err := p.SnapshotForAgent(context.Background(), "agent-1", world.Point{X: 100, Y: 0}); err != nil {
+		t.Fatalf("SnapshotForAgent error: %v", err)
+	}
+	advanced := false
+	for _, list := range store.byChunk {
+		for _, c := range list {
+			if prev, ok := before[c.ID]; ok && c.MovedAt.After(prev.MovedAt) {
+				advanced = true
+			}
+		}
+	}
+	if !advanced {
+		t.Fatalf("expected persisted creatures to advance over world time")
+	}
+}
+
+func TestProvider_NoCreaturesInSafeZone(t *testing.T) {
+	start := time.Unix(0, 0)
+	p := NewProvider(Config{
+		Clock: world.NewClock(world.ClockConfig{
+			StartAt:       start,
+			DayDuration:   10 * time.Minute,
+			NightDuration: 5 * time.Minute,
+		}),
+		ViewRadius: 1,
+		Now:        func() time.Time { return start.Add(11 * time.Minute) },
+	})
+	s, err := p.SnapshotForAgent(context.Background(), "agent-1", world.Point{X: 0, Y: 0})
+	if err != nil {
+		t.Fatalf("SnapshotForAgent error: %v", err)
+	}
+	if len(s.Creatures) != 0 {
+		t.Fatalf("expected no creatures around spawn, got %+v", s.Creatures)
+	}
+}
+
+func TestProvider_CreatureMovementIgnoresObserverPosition(t *testing.T) {
+	start := time.Unix(0, 0)
+	p := NewProvider(Config{
+		Clock: world.NewClock(world.ClockConfig{
+			StartAt:       start,
+			DayDuration:   10 * time.Minute,
+			NightDuration: 5 * time.Minute,
+		}),
+		ViewRadius: 5,
+		Now:        func() time.Time { return start.Add(14 * time.Minute) },
+	})
+
+	seen := map[string]world.Point{}
+	shared := 0
+	for _, center := range []world.Point{{X: 100, Y: 0}, {X: 103, Y: 3}} {
+		s, err := p.SnapshotForAgent(context.Background(), "agent-1", center)
+		if err != nil {
+			t.Fatalf("SnapshotForAgent error: %v", err)
+		}
+		for _, c := range s.Creatures {
+			if prev, ok := seen[c.ID]; ok {
+				shared++
+				if prev != c.Pos {
+					t.Fatalf("creature %s moved differently per observer: %+v vs %+v", c.ID, prev, c.Pos)
+				}
+			}
+			seen[c.ID] = c.Pos
+		}
+	}
+	if shared == 0 {
+		t.Fatalf("expected both observers to see a common creature")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
 
@@ -19,7 +20,9 @@ type Config struct {
 	Now             func() time.Time
 	ChunkStore      ChunkStore
 	ClockStateStore ClockStateStore
+	CreatureStore   CreatureStore
 	RefreshInterval time.Duration
+	Terrain         TerrainGenerator
 }
 
 type Provider struct {
@@ -46,6 +49,7 @@ func DefaultConfig() Config {
 		ResourcesNight: map[string]int{"wood": 6, "stone": 3},
 		ViewRadius:     5,
 		Now:            time.Now,
+		Terrain:        LegacyTerrain{},
 	}
 }
 
@@ -69,6 +73,9 @@ func NewProvider(cfg Config) Provider {
 	if cfg.RefreshInterval <= 0 {
 		cfg.RefreshInterval = 5 * time.Minute
 	}
+	if cfg.Terrain == nil {
+		cfg.Terrain = def.Terrain
+	}
 	return Provider{cfg: cfg, chunkSize: 8}
 }
 
@@ -92,7 +99,7 @@ func (p Provider) SnapshotForAgent(ctx context.Context, _ string, center world.P
 	tiles := make([]world.Tile, 0, (p.cfg.ViewRadius*2+1)*(p.cfg.ViewRadius*2+1))
 	counts := map[string]int{}
 	refreshBucket := resourceRefreshBucket(nowAt, p.cfg.RefreshInterval)
-	chunks, err := p.loadChunksForWindow(ctx, center, timeOfDay)
+	chunks, err := p.loadChunksForWindow(ctx, center, p.storeKey(timeOfDay))
 	if err != nil {
 		return world.Snapshot{}, err
 	}
@@ -113,12 +120,20 @@ func (p Provider) SnapshotForAgent(ctx context.Context, _ string, center world.P
 	if len(counts) > 0 {
 		nearby = counts
 	}
+	creatures, err := p.creaturesForWindow(ctx, center, phase, nowAt)
+	if err != nil {
+		return world.Snapshot{}, err
+	}
+	weather := p.cfg.Clock.WeatherAt(nowAt)
 
 	return world.Snapshot{
 		WorldTimeSeconds:   p.cfg.Clock.WorldTimeSecondsAt(nowAt),
 		TimeOfDay:          timeOfDay,
+		Day:                p.cfg.Clock.DayAt(nowAt),
+		Season:             string(p.cfg.Clock.SeasonAt(nowAt)),
+		Weather:            string(weather),
 		ThreatLevel:        threat,
-		VisibilityPenalty:  visibilityPenalty(isDay),
+		VisibilityPenalty:  visibilityPenalty(isDay) + survival.WeatherEffectFor(string(weather)).VisionPenalty,
 		NearbyResource:     nearby,
 		Center:             center,
 		ViewRadius:         p.cfg.ViewRadius,
@@ -127,6 +142,7 @@ func (p Provider) SnapshotForAgent(ctx context.Context, _ string, center world.P
 		PhaseChanged:       phaseChange.changed,
 		PhaseFrom:          phaseChange.from,
 		PhaseTo:            phaseChange.to,
+		Creatures:          creatures,
 	}, nil
 }
 
@@ -192,12 +208,21 @@ func (p Provider) generateChunk(coord world.ChunkCoord) world.Chunk {
 	baseY := coord.Y * p.chunkSize
 	for y := 0; y < p.chunkSize; y++ {
 		for x := 0; x < p.chunkSize; x++ {
-			tiles = append(tiles, genTile(baseX+x, baseY+y))
+			tiles = append(tiles, p.cfg.Terrain.Tile(baseX+x, baseY+y))
 		}
 	}
 	return world.Chunk{Coord: coord, Tiles: tiles}
 }
 
+// storeKey scopes a chunk phase or creature epoch to the terrain generator.
+// Legacy keys stay unprefixed so existing rows remain valid.
+func (p Provider) storeKey(key string) string {
+	if ns := p.cfg.Terrain.Namespace(); ns != "" {
+		return ns + ":" + key
+	}
+	return key
+}
+
 func floorDiv(a, b int) int {
 	if a >= 0 {
 		return a / b
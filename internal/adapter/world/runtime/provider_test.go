@@ -36,6 +36,13 @@ func TestProvider_UsesWorldClockAndReportsNextPhase(t *testing.T) {
 	if s.NextPhaseInSeconds != 240 {
 		t.Fatalf("expected 240 seconds until next phase, got %d", s.NextPhaseInSeconds)
 	}
+	if s.Day != 1 || s.Season != string(world.SeasonSpring) {
+		t.Fatalf("expected day 1 of spring, got day=%d season=%q", s.Day, s.Season)
+	}
+	clock := world.NewClock(world.ClockConfig{StartAt: start, DayDuration: 10 * time.Minute, NightDuration: 5 * time.Minute})
+	if want := string(clock.WeatherAt(start.Add(11 * time.Minute))); s.Weather != want {
+		t.Fatalf("expected weather %q from clock, got %q", want, s.Weather)
+	}
 }
 
 func TestProvider_WindowCenterAndTiles(t *testing.T) {
@@ -0,0 +1,147 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"clawvival/internal/domain/world"
+)
+
+var ErrUnknownTerrainGenerator = errors.New("unknown terrain generator")
+
+const (
+	TerrainLegacy = "legacy"
+	TerrainNoise  = "noise"
+)
+
+// TerrainGenerator produces the static tile for any world coordinate. Generators
+// must be pure: the same coordinate always yields the same tile.
+type TerrainGenerator interface {
+	// Namespace keys cached chunks and creatures so worlds from different
+	// generators or seeds never share rows. The legacy generator returns "".
+	Namespace() string
+	Tile(x, y int) world.Tile
+}
+
+func NewTerrainGenerator(name string, seed int64) (TerrainGenerator, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", TerrainLegacy:
+		return LegacyTerrain{}, nil
+	case TerrainNoise:
+		return NoiseTerrain{Seed: seed}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTerrainGenerator, name)
+	}
+}
+
+// LegacyTerrain is the original diamond-shaped world built from Manhattan distance rings.
+type LegacyTerrain struct{}
+
+func (LegacyTerrain) Namespace() string { return "" }
+
+func (LegacyTerrain) Tile(x, y int) world.Tile { return genTile(x, y) }
+
+// NoiseTerrain derives biomes from seeded elevation and moisture fields. The
+// spawn area around the origin stays a safe plain so new agents start alike.
+type NoiseTerrain struct {
+	Seed int64
+}
+
+const (
+	noiseSafeRadius     = 6
+	noiseElevationScale = 24.0
+	noiseMoistureScale  = 18.0
+	noiseRiverScale     = 40.0
+	noiseClusterScale   = 5.0
+	noiseRiverWidth     = 0.025
+)
+
+func (n NoiseTerrain) Namespace() string { return fmt.Sprintf("noise-%d", n.Seed) }
+
+func (n NoiseTerrain) Tile(x, y int) world.Tile {
+	dist := absInt(x) + absInt(y)
+	if dist <= noiseSafeRadius {
+		return world.Tile{X: x, Y: y, Kind: world.TileGrass, Zone: world.ZoneSafe, Biome: world.BiomePlain, Passable: true, BaseThreat: zoneBaseThreat(world.ZoneSafe)}
+	}
+	elevation := n.fractal(x, y, noiseElevationScale, 1)
+	moisture := n.fractal(x, y, noiseMoistureScale, 2)
+	river := n.fractal(x, y, noiseRiverScale, 3)
+	cluster := n.fractal(x, y, noiseClusterScale, 4)
+	scatter := int(n.hash(x, y, 5) % 100)
+
+	tile := world.Tile{X: x, Y: y, Kind: world.TileDirt, Passable: true}
+	switch {
+	case elevation > 0.64:
+		tile.Zone, tile.Biome = world.ZoneQuarry, world.BiomeMountain
+		if scatter < 25 {
+			tile.Kind, tile.Resource, tile.Passable = world.TileRock, "stone", false
+		}
+	case moisture > 0.56:
+		tile.Zone, tile.Biome, tile.Kind = world.ZoneForest, world.BiomeForest, world.TileGrass
+		if cluster > 0.55 {
+			tile.Kind, tile.Resource, tile.Passable = world.TileTree, "wood", false
+		}
+	default:
+		tile.Zone, tile.Biome = world.ZoneWild, world.BiomeWasteland
+		if moisture > 0.4 {
+			tile.Biome = world.BiomePlain
+		}
+		if scatter < 6 {
+			tile.Kind, tile.Resource = world.TileGrass, "berry"
+		} else if cluster > 0.7 {
+			tile.Kind, tile.Resource, tile.Passable = world.TileTree, "wood", false
+		}
+	}
+	// Rivers follow the mid contour of a low-frequency field and cut through every biome below the peaks.
+	if math.Abs(river-0.5) < noiseRiverWidth && elevation <= 0.64 {
+		tile.Kind, tile.Resource, tile.Passable = world.TileWater, "", false
+	}
+	tile.BaseThreat = zoneBaseThreat(tile.Zone)
+	return tile
+}
+
+func (n NoiseTerrain) fractal(x, y int, scale float64, layer uint64) float64 {
+	total, amplitude, norm := 0.0, 1.0, 0.0
+	fx, fy := float64(x)/scale, float64(y)/scale
+	for octave := uint64(0); octave < 3; octave++ {
+		total += amplitude * n.valueNoise(fx, fy, layer*8+octave)
+		norm += amplitude
+		amplitude /= 2
+		fx, fy = fx*2, fy*2
+	}
+	return total / norm
+}
+
+func (n NoiseTerrain) valueNoise(fx, fy float64, layer uint64) float64 {
+	x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+	tx, ty := smoothstep(fx-float64(x0)), smoothstep(fy-float64(y0))
+	corner := func(x, y int) float64 { return float64(n.hash(x, y, layer)%10000) / 9999 }
+	top := lerp(corner(x0, y0), corner(x0+1, y0), tx)
+	bottom := lerp(corner(x0, y0+1), corner(x0+1, y0+1), tx)
+	return lerp(top, bottom, ty)
+}
+
+func (n NoiseTerrain) hash(x, y int, layer uint64) uint64 {
+	v := uint64(n.Seed) ^ layer*0x9e3779b97f4a7c15
+	v ^= uint64(int64(x)) * 0xbf58476d1ce4e5b9
+	v ^= uint64(int64(y)) * 0x94d049bb133111eb
+	v ^= v >> 30
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	v *= 0x94d049bb133111eb
+	v ^= v >> 31
+	return v
+}
+
+func smoothstep(t float64) float64 { return t * t * (3 - 2*t) }
+
+func lerp(a, b, t float64) float64 { return a + (b-a)*t }
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"clawvival/internal/domain/world"
+)
+
+func TestNewTerrainGenerator_DefaultsToLegacyAndRejectsUnknown(t *testing.T) {
+	gen, err := NewTerrainGenerator("", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gen.Tile(44, 0) != genTile(44, 0) || gen.Namespace() != "" {
+		t.Fatalf("expected legacy generator to match genTile without namespace")
+	}
+	if _, err := NewTerrainGenerator("perlin", 7); !errors.Is(err, ErrUnknownTerrainGenerator) {
+		t.Fatalf("expected ErrUnknownTerrainGenerator, got %v", err)
+	}
+}
+
+func TestNoiseTerrain_SeedDeterminesMap(t *testing.T) {
+	a, b, other := NoiseTerrain{Seed: 42}, NoiseTerrain{Seed: 42}, NoiseTerrain{Seed: 43}
+	if a.Tile(0, 0).Zone != world.ZoneSafe || !a.Tile(0, 0).Passable {
+		t.Fatalf("expected safe passable spawn, got %+v", a.Tile(0, 0))
+	}
+
+	differs := 0
+	kinds := map[world.TileKind]bool{}
+	for y := -60; y <= 60; y += 2 {
+		for x := -60; x <= 60; x += 2 {
+			ta := a.Tile(x, y)
+			if ta != b.Tile(x, y) {
+				t.Fatalf("expected identical tiles for identical seeds at (%d,%d)", x, y)
+			}
+			if ta != other.Tile(x, y) {
+				differs++
+			}
+			kinds[ta.Kind] = true
+		}
+	}
+	if differs == 0 {
+		t.Fatalf("expected different seeds to produce different maps")
+	}
+	for _, kind := range []world.TileKind{world.TileTree, world.TileRock, world.TileWater} {
+		if !kinds[kind] {
+			t.Fatalf("expected noise terrain to contain %s tiles, got %v", kind, kinds)
+		}
+	}
+}
+
+func TestProvider_NamespacesChunkCacheForNoiseTerrain(t *testing.T) {
+	store := &fakeChunkStore{}
+	p := NewProvider(Config{ChunkStore: store, ViewRadius: 1, Terrain: NoiseTerrain{Seed: 9}})
+	if _, err := p.SnapshotForAgent(t.Context(), "agent-1", world.Point{}); err != nil {
+		t.Fatalf("SnapshotForAgent error: %v", err)
+	}
+	for k := range store.chunks {
+		if !strings.HasPrefix(k, "noise-9:") {
+			t.Fatalf("expected chunk key namespaced by terrain, got %q", k)
+		}
+	}
+	if len(store.chunks) == 0 {
+		t.Fatalf("expected chunks to be cached")
+	}
+}
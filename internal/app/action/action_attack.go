@@ -0,0 +1,73 @@
+package action
+
+import (
+	"context"
+	"strings"
+
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+type attackActionHandler struct{ BaseHandler }
+
+func validateAttackActionParams(intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.TargetID) != ""
+}
+
+func (h attackActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	pos := ac.View.StateWorking.Position
+	target, ok := findVisibleCreature(ac, strings.TrimSpace(ac.Tmp.ResolvedIntent.TargetID))
+	if !ok {
+		return ErrTargetNotVisible
+	}
+	if abs(target.Pos.X-pos.X)+abs(target.Pos.Y-pos.Y) > survival.ThreatAttackRange {
+		return &ActionInvalidPositionError{TargetPos: &survival.Position{X: target.Pos.X, Y: target.Pos.Y}}
+	}
+	if uc.CreatureRepo == nil {
+		// Without a creature store kills would not stick and the target
+		// would respawn on the next snapshot.
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h attackActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyCombat: true})
+}
+
+func findVisibleCreature(ac *ActionContext, id string) (world.Creature, bool) {
+	pos := ac.View.StateWorking.Position
+	for _, c := range ac.View.Snapshot.Creatures {
+		if c.ID != id || !c.Alive() {
+			continue
+		}
+		dist := abs(c.Pos.X-pos.X) + abs(c.Pos.Y-pos.Y)
+		if strings.EqualFold(ac.View.Snapshot.TimeOfDay, "night") && dist > survival.VisionRadius(survival.ActionNightVisionRadius, ac.View.Snapshot.Weather) && !ac.View.Lighting.IsLit(c.Pos.X, c.Pos.Y) {
+			return world.Creature{}, false
+		}
+		return c, true
+	}
+	return world.Creature{}, false
+}
+
+func persistCombatOutcome(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	for _, evt := range ac.Plan.EventsToAppend {
+		if evt.Type != "combat_resolved" || evt.Payload == nil {
+			continue
+		}
+		result, _ := evt.Payload["result"].(map[string]any)
+		targetID, _ := result["target_id"].(string)
+		for _, c := range ac.View.Snapshot.Creatures {
+			if c.ID != targetID {
+				continue
+			}
+			if _, err := uc.CreatureRepo.Damage(ctx, c, int(toNum(result["damage_dealt"]))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func TestUseCase_AttackWithSpearDamagesCreatureAndEmitsCombatResolved(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Inventory: map[string]int{"spear": 1}, Version: 1},
+	}}
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	eventRepo := &stubEventRepo{}
+	creatureRepo := &stubCreatureRepo{}
+	uc := UseCase{
+		TxManager:    stubTxManager{},
+		StateRepo:    stateRepo,
+		ActionRepo:   actionRepo,
+		EventRepo:    eventRepo,
+		CreatureRepo: creatureRepo,
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay:    "night",
+			VisibleTiles: []world.Tile{{X: 0, Y: 0, Passable: true}, {X: 1, Y: 0, Passable: true}},
+			Creatures:    []world.Creature{{ID: "crt-1", Kind: world.CreatureWolf, Pos: world.Point{X: 1, Y: 0}, HP: 20, Damage: 4}},
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-attack",
+		Intent:         survival.ActionIntent{Type: survival.ActionAttack, TargetID: "crt-1"},
+	})
+	if err != nil {
+		t.Fatalf("execute error: %v", err)
+	}
+	var combat *survival.DomainEvent
+	for i := range out.Events {
+		if out.Events[i].Type == "combat_resolved" {
+			combat = &out.Events[i]
+		}
+	}
+	if combat == nil {
+		t.Fatalf("expected combat_resolved event, got %+v", out.Events)
+	}
+	result := combat.Payload["result"].(map[string]any)
+	if result["weapon"] != "spear" || result["damage_dealt"] != survival.SpearDamage || result["target_hp_after"] != 8 {
+		t.Fatalf("unexpected combat result: %+v", result)
+	}
+	if got := creatureRepo.updated["crt-1"].HP; got != 8 {
+		t.Fatalf("expected creature hp persisted as 8, got %d", got)
+	}
+	if out.UpdatedState.Vitals.HP != 96 {
+		t.Fatalf("expected counter damage of 4, got hp=%d", out.UpdatedState.Vitals.HP)
+	}
+}
+
+func TestUseCase_AttackRejectsTargetOutOfReach(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay: "day",
+			Creatures: []world.Creature{{ID: "crt-1", Kind: world.CreatureBoar, Pos: world.Point{X: 3, Y: 0}, HP: 25, Damage: 3}},
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-attack-far",
+		Intent:         survival.ActionIntent{Type: survival.ActionAttack, TargetID: "crt-1"},
+	})
+	if !errors.Is(err, ErrActionInvalidPosition) {
+		t.Fatalf("expected ErrActionInvalidPosition, got %v", err)
+	}
+
+	_, err = uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-attack-missing",
+		Intent:         survival.ActionIntent{Type: survival.ActionAttack, TargetID: "crt-unknown"},
+	})
+	if !errors.Is(err, ErrTargetNotVisible) {
+		t.Fatalf("expected ErrTargetNotVisible, got %v", err)
+	}
+}
+
+func TestUseCase_AttackRejectedWithoutCreatureStore(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay: "day",
+			Creatures: []world.Creature{{ID: "crt-1", Kind: world.CreatureBoar, Pos: world.Point{X: 1, Y: 0}, HP: 25, Damage: 3}},
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-attack-no-store",
+		Intent:         survival.ActionIntent{Type: survival.ActionAttack, TargetID: "crt-1"},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed, got %v", err)
+	}
+}
@@ -2,13 +2,15 @@ package action
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/shared/cooldown"
 	"clawvival/internal/app/shared/stateview"
+	"clawvival/internal/app/shared/structures"
+	"clawvival/internal/app/shared/threats"
 	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
 )
 
 func runStandardActionPrecheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
@@ -18,14 +20,14 @@ func runStandardActionPrecheck(ctx context.Context, uc UseCase, ac *ActionContex
 		}
 	}
 	intent := ac.Tmp.ResolvedIntent
-	if err := ensureCooldownReady(ac.View.EventsBefore, intent.Type, ac.In.NowAt); err != nil {
+	if err := ensureCooldownReady(uc.Settle.RuleSet(), ac.View.EventsBefore, intent.Type, ac.In.NowAt); err != nil {
 		return err
 	}
 	return nil
 }
 
-func ensureCooldownReady(events []survival.DomainEvent, intentType survival.ActionType, now time.Time) error {
-	remaining, ok := cooldown.RemainingForAction(events, intentType, now)
+func ensureCooldownReady(rules *survival.RuleSet, events []survival.DomainEvent, intentType survival.ActionType, now time.Time) error {
+	remaining, ok := cooldown.RemainingForAction(rules, events, intentType, now)
 	if !ok {
 		return nil
 	}
@@ -48,6 +50,8 @@ type settleOptions struct {
 	applyGatherDeplete bool
 	applyObjectAction  bool
 	createBuiltObjects bool
+	applyCombat        bool
+	applyTrade         bool
 }
 
 // Regular actions settle against the fixed standard tick. Only ongoing flows
@@ -63,6 +67,8 @@ func settleViaDomainOrInstant(ctx context.Context, uc UseCase, ac *ActionContext
 	if opts.filterGatherNearby {
 		settleNearby = filterGatherNearbyResource(intent.TargetID, ac.View.Snapshot.NearbyResource)
 	}
+	pos := ac.View.StateWorking.Position
+	sheltered := ac.View.Structures.IsSheltered(pos.X, pos.Y)
 	result, err := uc.Settle.Settle(
 		ac.View.StateWorking,
 		intent,
@@ -70,19 +76,29 @@ func settleViaDomainOrInstant(ctx context.Context, uc UseCase, ac *ActionContext
 		ac.In.NowAt,
 		survival.WorldSnapshot{
 			TimeOfDay:         ac.View.Snapshot.TimeOfDay,
-			ThreatLevel:       ac.View.Snapshot.ThreatLevel,
+			ThreatLevel:       structures.ReduceThreat(ac.View.Snapshot.ThreatLevel, sheltered),
 			VisibilityPenalty: ac.View.Snapshot.VisibilityPenalty,
 			NearbyResource:    settleNearby,
+			Threats:           threats.Contacts(ac.View.Snapshot.Creatures),
+			Sheltered:         sheltered,
+			Season:            ac.View.Snapshot.Season,
+			Weather:           ac.View.Snapshot.Weather,
+			Biome:             stateview.CurrentBiomeAtPosition(pos, ac.View.Snapshot.VisibleTiles),
+			NearHeat:          ac.View.Lighting.IsWarm(pos.X, pos.Y),
 			WorldTimeSeconds:  ac.View.Snapshot.WorldTimeSeconds,
+			Blocked:           stateview.BlockedTiles(ac.View.Snapshot.VisibleTiles),
 		},
 	)
 	if err != nil {
 		return ExecuteModeContinue, err
 	}
 
-	result.UpdatedState = stateview.Enrich(result.UpdatedState, ac.View.Snapshot.TimeOfDay, isCurrentTileLit(ac.View.Snapshot.TimeOfDay))
+	result.UpdatedState = stateview.Enrich(result.UpdatedState, ac.View.Snapshot.TimeOfDay, ac.View.Lighting.IsLit(result.UpdatedState.Position.X, result.UpdatedState.Position.Y))
+	afterPos := result.UpdatedState.Position
+	result.UpdatedState = stateview.MarkSheltered(result.UpdatedState, ac.View.Structures.IsSheltered(afterPos.X, afterPos.Y))
+	result.UpdatedState = stateview.MarkTemperature(uc.Settle.RuleSet(), result.UpdatedState, ac.View.Snapshot, ac.View.Lighting.IsWarm(afterPos.X, afterPos.Y), ac.View.Structures.IsSheltered(afterPos.X, afterPos.Y))
 	result.UpdatedState.CurrentZone = stateview.CurrentZoneAtPosition(result.UpdatedState.Position, ac.View.Snapshot.VisibleTiles)
-	result.UpdatedState.ActionCooldowns = cooldown.RemainingByActionWithCurrent(ac.View.EventsBefore, ac.In.NowAt, intent.Type)
+	result.UpdatedState.ActionCooldowns = cooldown.RemainingByActionWithCurrent(uc.Settle.RuleSet(), ac.View.EventsBefore, ac.In.NowAt, intent.Type)
 	if ac.View.Snapshot.PhaseChanged && deltaMinutes > 0 {
 		result.Events = append(result.Events, survival.DomainEvent{
 			Type:       "world_phase_changed",
@@ -119,6 +135,8 @@ func settleViaDomainOrInstant(ctx context.Context, uc UseCase, ac *ActionContext
 	ac.Plan.ApplyGatherDepletion = opts.applyGatherDeplete
 	ac.Plan.ApplyObjectAction = opts.applyObjectAction
 	ac.Plan.CreateBuiltObjects = opts.createBuiltObjects
+	ac.Plan.ApplyCombat = opts.applyCombat
+	ac.Plan.ApplyTrade = opts.applyTrade
 	ac.Plan.CloseSession = result.ResultCode == survival.ResultGameOver
 	ac.Plan.CloseSessionCause = result.UpdatedState.DeathCause
 
@@ -137,6 +155,11 @@ func settleViaDomainOrInstant(ctx context.Context, uc UseCase, ac *ActionContext
 type portsActionExecutionRecord = ports.ActionExecutionRecord
 type actionResult = ports.ActionResult
 
-func isCurrentTileLit(timeOfDay string) bool {
-	return strings.EqualFold(strings.TrimSpace(timeOfDay), "day")
+// loadWorldObjects lists the objects that can shape the snapshot's view.
+func loadWorldObjects(ctx context.Context, repo ports.WorldObjectRepository, agentID string, snapshot world.Snapshot) ([]ports.WorldObjectRecord, error) {
+	if repo == nil {
+		return nil, nil
+	}
+	minX, minY, maxX, maxY := structures.Bounds(snapshot.Center, snapshot.ViewRadius)
+	return repo.ListInBounds(ctx, agentID, minX, minY, maxX, maxY)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"clawvival/internal/app/ports"
 	"clawvival/internal/domain/survival"
 )
 
@@ -15,8 +14,8 @@ func validateCraftActionParams(intent survival.ActionIntent) bool {
 	return intent.RecipeID > 0
 }
 
-func validateEatActionParams(intent survival.ActionIntent) bool {
-	_, ok := foodIDFromItemType(intent.ItemType)
+func validateEatActionParams(rules *survival.RuleSet, intent survival.ActionIntent) bool {
+	_, ok := rules.FoodID(intent.ItemType)
 	return ok && intent.Count > 0
 }
 
@@ -24,11 +23,11 @@ func (h craftActionHandler) Precheck(ctx context.Context, uc UseCase, ac *Action
 	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
 		return err
 	}
-	if needsFurnace(survival.RecipeID(ac.Tmp.ResolvedIntent.RecipeID)) &&
-		!hasUsableFurnace(ctx, uc.ObjectRepo, ac.In.AgentID) {
+	// Furnace recipes run as timed jobs through furnace_load instead.
+	if uc.Settle.RuleSet().IsFurnaceRecipe(survival.RecipeID(ac.Tmp.ResolvedIntent.RecipeID)) {
 		return ErrActionPreconditionFailed
 	}
-	if !survival.CanCraft(ac.View.StateWorking, survival.RecipeID(ac.Tmp.ResolvedIntent.RecipeID)) {
+	if !uc.Settle.RuleSet().CanCraft(ac.View.StateWorking, survival.RecipeID(ac.Tmp.ResolvedIntent.RecipeID)) {
 		return ErrActionPreconditionFailed
 	}
 	return nil
@@ -42,7 +41,7 @@ func (h eatActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionCo
 	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
 		return err
 	}
-	if !eatPreconditionsSatisfied(ac.View.StateWorking, ac.Tmp.ResolvedIntent) {
+	if !eatPreconditionsSatisfied(uc.Settle.RuleSet(), ac.View.StateWorking, ac.Tmp.ResolvedIntent) {
 		return ErrActionPreconditionFailed
 	}
 	return nil
@@ -52,12 +51,12 @@ func (h eatActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase,
 	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
 }
 
-func eatPreconditionsSatisfied(state survival.AgentStateAggregate, intent survival.ActionIntent) bool {
+func eatPreconditionsSatisfied(rules *survival.RuleSet, state survival.AgentStateAggregate, intent survival.ActionIntent) bool {
 	if intent.Type != survival.ActionEat {
 		return true
 	}
-	foodID, ok := foodIDFromItemType(intent.ItemType)
-	if !ok || !survival.CanEat(state, foodID) {
+	foodID, ok := rules.FoodID(intent.ItemType)
+	if !ok || !rules.CanEat(state, foodID) {
 		return false
 	}
 	count := intent.Count
@@ -66,44 +65,3 @@ func eatPreconditionsSatisfied(state survival.AgentStateAggregate, intent surviv
 	}
 	return state.Inventory[strings.ToLower(strings.TrimSpace(intent.ItemType))] >= count
 }
-
-func foodIDFromItemType(itemType string) (survival.FoodID, bool) {
-	switch strings.ToLower(strings.TrimSpace(itemType)) {
-	case "berry":
-		return survival.FoodBerry, true
-	case "bread":
-		return survival.FoodBread, true
-	case "wheat":
-		return survival.FoodWheat, true
-	case "jam":
-		return survival.FoodJam, true
-	default:
-		return 0, false
-	}
-}
-
-func needsFurnace(recipeID survival.RecipeID) bool {
-	for _, requirement := range survival.CraftRequirements(recipeID) {
-		if strings.EqualFold(strings.TrimSpace(requirement), "FURNACE") {
-			return true
-		}
-	}
-	return false
-}
-
-func hasUsableFurnace(ctx context.Context, repo ports.WorldObjectRepository, agentID string) bool {
-	if repo == nil || strings.TrimSpace(agentID) == "" {
-		return false
-	}
-	objects, err := repo.ListByAgentID(ctx, agentID)
-	if err != nil {
-		return false
-	}
-	for _, object := range objects {
-		if strings.EqualFold(strings.TrimSpace(object.ObjectType), "furnace") ||
-			object.Kind == int(survival.BuildFurnace) {
-			return true
-		}
-	}
-	return false
-}
@@ -8,6 +8,7 @@ import (
 
 	worldmock "clawvival/internal/adapter/world/mock"
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/furnacestate"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -84,8 +85,8 @@ func TestUseCase_EatAllowsJamAndRecoversMoreThanDefault(t *testing.T) {
 	if got := out.UpdatedState.Inventory["jam"]; got != 0 {
 		t.Fatalf("expected jam consumed, got=%d", got)
 	}
-	if got := out.UpdatedState.Vitals.Hunger; got <= 40+survival.ActionEatDeltaHunger {
-		t.Fatalf("expected jam hunger recovery > default(%d), got hunger=%d", survival.ActionEatDeltaHunger, got)
+	if eatDelta := survival.DefaultRuleSet().Delta(survival.ActionEat).Hunger; out.UpdatedState.Vitals.Hunger <= 40+eatDelta {
+		t.Fatalf("expected jam hunger recovery > default(%d), got hunger=%d", eatDelta, out.UpdatedState.Vitals.Hunger)
 	}
 	if got, want := out.UpdatedState.Vitals.Hunger, 100; got != want {
 		t.Fatalf("expected jam hunger=%d, got=%d", want, got)
@@ -125,7 +126,7 @@ func TestUseCase_CraftFurnaceRecipeRequiresFurnaceObject(t *testing.T) {
 	}
 }
 
-func TestUseCase_CraftFurnaceRecipeSucceedsWithFurnaceObject(t *testing.T) {
+func TestUseCase_CraftFurnaceRecipeRejectedEvenWithFurnaceObject(t *testing.T) {
 	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
 		"agent-1": {
 			AgentID:   "agent-1",
@@ -150,39 +151,35 @@ func TestUseCase_CraftFurnaceRecipeSucceedsWithFurnaceObject(t *testing.T) {
 		Now:        func() time.Time { return time.Unix(1700000000, 0) },
 	}
 
-	out, err := uc.Execute(context.Background(), Request{
+	_, err := uc.Execute(context.Background(), Request{
 		AgentID:        "agent-1",
 		IdempotencyKey: "k-craft-brick-with-furnace",
 		Intent:         survival.ActionIntent{Type: survival.ActionCraft, RecipeID: int(survival.RecipeBrick)},
 	})
-	if err != nil {
-		t.Fatalf("expected craft brick success with furnace, got err=%v", err)
-	}
-	if got := out.UpdatedState.Inventory["brick"]; got != 1 {
-		t.Fatalf("expected crafted brick=1, got=%d", got)
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected furnace recipe to be rejected by craft, got err=%v", err)
 	}
-	if got := out.UpdatedState.Inventory["stone"]; got != 0 {
-		t.Fatalf("expected stone consumed, got=%d", got)
+	if got := stateRepo.byAgent["agent-1"].Inventory["stone"]; got != 2 {
+		t.Fatalf("expected stone untouched, got=%d", got)
 	}
 }
 
-func TestUseCase_CraftFurnaceRecipeUsesBuiltFurnaceFromBuildAction(t *testing.T) {
+func TestUseCase_FurnaceSmeltsBrickInBuiltFurnaceOverTime(t *testing.T) {
 	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
 		"agent-1": {
 			AgentID:   "agent-1",
 			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 80},
-			Inventory: map[string]int{"stone": 8},
+			Inventory: map[string]int{"stone": 8, "wood": 1},
 			Version:   1,
 		},
 	}}
-	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
-	eventRepo := &stubEventRepo{}
 	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{}}
+	now := time.Unix(1700000000, 0)
 	uc := UseCase{
 		TxManager:  stubTxManager{},
 		StateRepo:  stateRepo,
-		ActionRepo: actionRepo,
-		EventRepo:  eventRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
 		ObjectRepo: objectRepo,
 		World: worldmock.Provider{Snapshot: world.Snapshot{
 			TimeOfDay:   "day",
@@ -193,30 +190,55 @@ func TestUseCase_CraftFurnaceRecipeUsesBuiltFurnaceFromBuildAction(t *testing.T)
 			},
 		}},
 		Settle: survival.SettlementService{},
-		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+		Now:    func() time.Time { return now },
+	}
+	execute := func(key string, intent survival.ActionIntent) error {
+		_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: key, Intent: intent})
+		return err
 	}
 
-	if _, err := uc.Execute(context.Background(), Request{
-		AgentID:        "agent-1",
-		IdempotencyKey: "k-build-furnace-for-craft",
-		Intent: survival.ActionIntent{
-			Type:       survival.ActionBuild,
-			ObjectType: "furnace",
-			Pos:        &survival.Position{X: 1, Y: 0},
-		},
-	}); err != nil {
-		t.Fatalf("expected build furnace success, got err=%v", err)
+	if err := execute("k-build-furnace", survival.ActionIntent{Type: survival.ActionBuild, ObjectType: "furnace", Pos: &survival.Position{X: 1, Y: 0}}); err != nil {
+		t.Fatalf("build furnace: %v", err)
+	}
+	furnaceID := ""
+	for id, obj := range objectRepo.byID {
+		if obj.ObjectType == "furnace" {
+			furnaceID = id
+		}
+	}
+	if furnaceID == "" {
+		t.Fatalf("expected built furnace object")
 	}
 
-	out, err := uc.Execute(context.Background(), Request{
-		AgentID:        "agent-1",
-		IdempotencyKey: "k-craft-brick-after-build-furnace",
-		Intent:         survival.ActionIntent{Type: survival.ActionCraft, RecipeID: int(survival.RecipeBrick)},
-	})
+	if err := execute("k-load", survival.ActionIntent{Type: survival.ActionFurnaceLoad, ObjectID: furnaceID, RecipeID: int(survival.RecipeBrick)}); err != nil {
+		t.Fatalf("load furnace: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["stone"]; got != 0 {
+		t.Fatalf("expected brick inputs taken on load, got stone=%d", got)
+	}
+	now = now.Add(40 * time.Minute)
+	if err := execute("k-collect-unfueled", survival.ActionIntent{Type: survival.ActionFurnaceCollect, ObjectID: furnaceID}); !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected nothing to collect without fuel, got %v", err)
+	}
+	if err := execute("k-fuel", survival.ActionIntent{Type: survival.ActionFurnaceFuel, ObjectID: furnaceID, ItemType: "wood", Count: 1}); err != nil {
+		t.Fatalf("fuel furnace: %v", err)
+	}
+	now = now.Add(20 * time.Minute)
+	if err := execute("k-collect-early", survival.ActionIntent{Type: survival.ActionFurnaceCollect, ObjectID: furnaceID}); !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected early collect rejected, got %v", err)
+	}
+	now = now.Add(10 * time.Minute)
+	if err := execute("k-collect", survival.ActionIntent{Type: survival.ActionFurnaceCollect, ObjectID: furnaceID}); err != nil {
+		t.Fatalf("collect furnace: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["brick"]; got != 1 {
+		t.Fatalf("expected collected brick=1, got=%d", got)
+	}
+	state, err := furnacestate.Parse(objectRepo.byID[furnaceID].ObjectState)
 	if err != nil {
-		t.Fatalf("expected craft brick success after building furnace, got err=%v", err)
+		t.Fatalf("parse furnace state: %v", err)
 	}
-	if got := out.UpdatedState.Inventory["brick"]; got != 1 {
-		t.Fatalf("expected crafted brick=1, got=%d", got)
+	if state.FuelMinutes != 0 || len(state.Queue) != 0 || len(state.Output) != 0 {
+		t.Fatalf("expected drained furnace after collect, got %+v", state)
 	}
 }
@@ -0,0 +1,46 @@
+package action
+
+import (
+	"context"
+	"strings"
+
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+type drinkActionHandler struct{ BaseHandler }
+
+func validateDrinkActionParams(intent survival.ActionIntent) bool {
+	item := strings.TrimSpace(intent.ItemType)
+	return item == "" || item == survival.ItemWaterFlask
+}
+
+func (h drinkActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType) == survival.ItemWaterFlask {
+		if ac.View.StateWorking.Inventory[survival.ItemWaterFlask] <= 0 {
+			return ErrActionPreconditionFailed
+		}
+		return nil
+	}
+	if !adjacentToWater(ac.View.StateWorking.Position, ac.View.Snapshot.VisibleTiles) {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h drinkActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	ac.Tmp.ResolvedIntent.ItemType = strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType)
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{})
+}
+
+func adjacentToWater(pos survival.Position, tiles []world.Tile) bool {
+	for _, tile := range tiles {
+		if tile.Kind == world.TileWater && abs(tile.X-pos.X)+abs(tile.Y-pos.Y) <= 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func newDrinkUseCase(state survival.AgentStateAggregate, tiles []world.Tile) UseCase {
+	return UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{state.AgentID: state}},
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", VisibleTiles: tiles}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+}
+
+func TestUseCase_DrinkAdjacentToWaterRestoresThirstAndFillsFlasks(t *testing.T) {
+	uc := newDrinkUseCase(survival.AgentStateAggregate{
+		AgentID:   "agent-1",
+		Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 20},
+		Inventory: map[string]int{survival.ItemFlask: 2},
+		Version:   1,
+	}, []world.Tile{{X: 0, Y: 1, Kind: world.TileWater}})
+
+	out, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: "k-drink", Intent: survival.ActionIntent{Type: survival.ActionDrink}})
+	if err != nil {
+		t.Fatalf("expected drink success, got %v", err)
+	}
+	if got, want := out.UpdatedState.Vitals.Thirst, 20-survival.DefaultRuleSet().Drains.BaseThirstPer30+survival.DefaultRuleSet().Delta(survival.ActionDrink).Thirst; got != want {
+		t.Fatalf("expected thirst=%d, got %d", want, got)
+	}
+	if out.UpdatedState.Inventory[survival.ItemWaterFlask] != 2 || out.UpdatedState.Inventory[survival.ItemFlask] != 0 {
+		t.Fatalf("expected both flasks filled, got %v", out.UpdatedState.Inventory)
+	}
+}
+
+func TestUseCase_DrinkRequiresWaterOrFlask(t *testing.T) {
+	state := survival.AgentStateAggregate{
+		AgentID: "agent-1",
+		Vitals:  survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 20},
+		Version: 1,
+	}
+	dry := newDrinkUseCase(state, []world.Tile{{X: 0, Y: 2, Kind: world.TileWater}})
+	_, err := dry.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: "k-drink-dry", Intent: survival.ActionIntent{Type: survival.ActionDrink}})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed away from water, got %v", err)
+	}
+
+	state.Inventory = map[string]int{survival.ItemWaterFlask: 1}
+	carried := newDrinkUseCase(state, nil)
+	out, err := carried.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: "k-drink-flask", Intent: survival.ActionIntent{Type: survival.ActionDrink, ItemType: survival.ItemWaterFlask}})
+	if err != nil {
+		t.Fatalf("expected flask drink success, got %v", err)
+	}
+	if out.UpdatedState.Inventory[survival.ItemWaterFlask] != 0 || out.UpdatedState.Inventory[survival.ItemFlask] != 1 {
+		t.Fatalf("expected water flask emptied back to flask, got %v", out.UpdatedState.Inventory)
+	}
+}
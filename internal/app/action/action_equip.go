@@ -0,0 +1,61 @@
+package action
+
+import (
+	"context"
+	"strings"
+
+	"clawvival/internal/domain/survival"
+)
+
+type equipActionHandler struct{ BaseHandler }
+type unequipActionHandler struct{ BaseHandler }
+
+func validateEquipActionParams(intent survival.ActionIntent) bool {
+	_, ok := survival.GearRuleFor(intent.ItemType)
+	return ok
+}
+
+func validateUnequipActionParams(intent survival.ActionIntent) bool {
+	_, ok := survival.ParseEquipSlot(intent.Slot)
+	return ok
+}
+
+func (h equipActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	item := strings.ToLower(strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType))
+	if ac.View.StateWorking.Inventory[item] <= 0 {
+		return ErrActionPreconditionFailed
+	}
+	// Swapping out a backpack can leave more items than the new capacity.
+	if !survival.CanEquip(ac.View.StateWorking, item) {
+		return ErrInventoryFull
+	}
+	return nil
+}
+
+func (h equipActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	ac.Tmp.ResolvedIntent.ItemType = strings.ToLower(strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType))
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{})
+}
+
+func (h unequipActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	slot, _ := survival.ParseEquipSlot(ac.Tmp.ResolvedIntent.Slot)
+	if ac.View.StateWorking.Equipment[string(slot)] == "" {
+		return ErrActionPreconditionFailed
+	}
+	if !survival.CanUnequip(ac.View.StateWorking, slot) {
+		return ErrInventoryFull
+	}
+	return nil
+}
+
+func (h unequipActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	slot, _ := survival.ParseEquipSlot(ac.Tmp.ResolvedIntent.Slot)
+	ac.Tmp.ResolvedIntent.Slot = string(slot)
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{})
+}
@@ -0,0 +1,89 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func TestUseCase_EquipBackpackRaisesCapacityAndBlocksUnequipWhenFull(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:           "agent-1",
+			Vitals:            survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
+			Position:          survival.Position{X: 0, Y: 0},
+			Inventory:         map[string]int{"backpack": 1},
+			InventoryCapacity: survival.DefaultInventoryCapacity,
+			Version:           1,
+		},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	if _, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-equip-backpack",
+		Intent:         survival.ActionIntent{Type: survival.ActionEquip, ItemType: "backpack"},
+	}); err != nil {
+		t.Fatalf("equip backpack: %v", err)
+	}
+	state := stateRepo.byAgent["agent-1"]
+	if state.Equipment["backpack"] != "backpack" || state.Inventory["backpack"] != 0 {
+		t.Fatalf("expected backpack worn, inv=%v eq=%v", state.Inventory, state.Equipment)
+	}
+	if got, want := state.InventoryCapacity, survival.DefaultInventoryCapacity+survival.BackpackCapacityBonus; got != want {
+		t.Fatalf("capacity mismatch: got=%d want=%d", got, want)
+	}
+
+	state.Inventory["stone"] = survival.DefaultInventoryCapacity
+	stateRepo.byAgent["agent-1"] = state
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-unequip-backpack",
+		Intent:         survival.ActionIntent{Type: survival.ActionUnequip, Slot: "backpack"},
+	})
+	if !errors.Is(err, ErrInventoryFull) {
+		t.Fatalf("expected ErrInventoryFull, got %v", err)
+	}
+}
+
+func TestUseCase_UnequipEmptySlotRejected(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
+			Inventory: map[string]int{},
+			Version:   1,
+		},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-unequip-empty",
+		Intent:         survival.ActionIntent{Type: survival.ActionUnequip, Slot: "body"},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package action
+
+import (
+	"context"
+	"strings"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+type furnaceFuelActionHandler struct{ BaseHandler }
+type furnaceLoadActionHandler struct{ BaseHandler }
+type furnaceCollectActionHandler struct{ BaseHandler }
+
+func validateFurnaceFuelActionParams(rules *survival.RuleSet, intent survival.ActionIntent) bool {
+	_, ok := rules.FurnaceFuelMinutes(intent.ItemType)
+	return strings.TrimSpace(intent.ObjectID) != "" && ok && intent.Count >= 0
+}
+
+func validateFurnaceLoadActionParams(rules *survival.RuleSet, intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.ObjectID) != "" && rules.IsFurnaceRecipe(survival.RecipeID(intent.RecipeID)) && intent.Count >= 0
+}
+
+func validateFurnaceCollectActionParams(intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.ObjectID) != ""
+}
+
+func (h furnaceFuelActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if ac.View.PreparedObj == nil {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h furnaceFuelActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
+func (h furnaceLoadActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if ac.View.PreparedObj == nil {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h furnaceLoadActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
+func (h furnaceCollectActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if ac.View.PreparedObj == nil || len(ac.Tmp.ResolvedIntent.Items) == 0 {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h furnaceCollectActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
+func isFurnaceObject(obj ports.WorldObjectRecord) bool {
+	typ := strings.ToLower(strings.TrimSpace(obj.ObjectType))
+	return typ == "furnace" || obj.Kind == int(survival.BuildFurnace)
+}
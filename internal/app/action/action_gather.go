@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/lighting"
 	"clawvival/internal/app/shared/resourcestate"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
@@ -23,7 +24,7 @@ func (h gatherActionHandler) Precheck(ctx context.Context, uc UseCase, ac *Actio
 	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
 		return err
 	}
-	if err := validateTargetVisibility(ac.View.StateWorking.Position, ac.Tmp.ResolvedIntent, ac.View.Snapshot); err != nil {
+	if err := validateTargetVisibility(ac.View.StateWorking.Position, ac.Tmp.ResolvedIntent, ac.View.Snapshot, ac.View.Lighting); err != nil {
 		return err
 	}
 	return validateGatherTargetState(ctx, uc.ResourceRepo, ac.In.AgentID, ac.Tmp.ResolvedIntent, ac.In.NowAt)
@@ -132,7 +133,7 @@ func filterGatherNearbyResource(targetID string, nearby map[string]int) map[stri
 	return map[string]int{resource: 1}
 }
 
-func validateTargetVisibility(center survival.Position, intent survival.ActionIntent, snapshot world.Snapshot) error {
+func validateTargetVisibility(center survival.Position, intent survival.ActionIntent, snapshot world.Snapshot, lit lighting.Map) error {
 	if intent.Type != survival.ActionGather || strings.TrimSpace(intent.TargetID) == "" {
 		return nil
 	}
@@ -149,7 +150,7 @@ func validateTargetVisibility(center survival.Position, intent survival.ActionIn
 	}
 	if strings.EqualFold(snapshot.TimeOfDay, "night") {
 		dist := abs(tx-center.X) + abs(ty-center.Y)
-		if dist > survival.ActionNightVisionRadius {
+		if dist > survival.VisionRadius(survival.ActionNightVisionRadius, snapshot.Weather) && !lit.IsLit(tx, ty) {
 			return ErrTargetNotVisible
 		}
 	}
@@ -193,12 +194,12 @@ func persistGatherDepletion(ctx context.Context, repo ports.AgentResourceNodeRep
 	if !ok {
 		return nil
 	}
-	return repo.Upsert(ctx, ports.AgentResourceNodeRecord{
+	return repo.Deplete(ctx, ports.AgentResourceNodeRecord{
 		AgentID:       agentID,
 		TargetID:      strings.TrimSpace(intent.TargetID),
 		ResourceType:  resource,
 		X:             x,
 		Y:             y,
 		DepletedUntil: now.Add(resourcestate.RespawnDuration(resource)),
-	})
+	}, now)
 }
@@ -137,6 +137,48 @@ func TestUseCase_GatherRejectsNightTargetOutsideVisionRadius(t *testing.T) {
 	}
 }
 
+func TestUseCase_GatherAllowsNightTargetLitByTorch(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
+	}}
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	eventRepo := &stubEventRepo{}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"obj-torch": {ObjectID: "obj-torch", ObjectType: "torch", X: 2, Y: 0},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: actionRepo,
+		EventRepo:  eventRepo,
+		ObjectRepo: objectRepo,
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay:      "night",
+			ThreatLevel:    2,
+			NearbyResource: map[string]int{"wood": 1},
+			VisibleTiles: []world.Tile{
+				{X: 4, Y: 0, Passable: true, Resource: "wood"},
+			},
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-gather-night-torch",
+		Intent:         survival.ActionIntent{Type: survival.ActionGather, TargetID: "res_4_0_wood"},
+	})
+	if err != nil {
+		t.Fatalf("expected torch-lit target to be gatherable at night, got %v", err)
+	}
+	for _, effect := range out.UpdatedState.StatusEffects {
+		if effect == "IN_DARK" {
+			t.Fatalf("expected no IN_DARK inside torch light, got effects=%v", out.UpdatedState.StatusEffects)
+		}
+	}
+}
+
 func TestUseCase_GatherRejectsWhenTargetResourceTypeMismatchesTile(t *testing.T) {
 	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
 		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
@@ -351,6 +393,49 @@ func TestUseCase_GatherPersistsAgentResourceDepletion(t *testing.T) {
 	}
 }
 
+// racingResourceNodeRepo lets the precheck pass while another agent's
+// gather commits the depletion first.
+type racingResourceNodeRepo struct {
+	stubResourceNodeRepo
+}
+
+func (r *racingResourceNodeRepo) Deplete(_ context.Context, _ ports.AgentResourceNodeRecord, _ time.Time) error {
+	return ports.ErrConflict
+}
+
+func TestUseCase_GatherConflictsWhenNodeClaimedConcurrently(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Inventory: map[string]int{}, Version: 1},
+	}}
+	metrics := &stubActionMetrics{}
+	uc := UseCase{
+		TxManager:    stubTxManager{},
+		StateRepo:    stateRepo,
+		ActionRepo:   &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:    &stubEventRepo{},
+		ResourceRepo: &racingResourceNodeRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay:      "day",
+			NearbyResource: map[string]int{"wood": 7},
+			VisibleTiles:   []world.Tile{{X: 0, Y: 0, Passable: true, Resource: "wood"}},
+		}},
+		Metrics: metrics,
+		Settle:  survival.SettlementService{},
+		Now:     func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-gather-race",
+		Intent:         survival.ActionIntent{Type: survival.ActionGather, TargetID: "res_0_0_wood"},
+	})
+	if !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if metrics.conflictCalls != 1 {
+		t.Fatalf("expected conflict metric, got %+v", metrics)
+	}
+}
+
 func TestUseCase_GatherTriggersSeedPityAfterConsecutiveFails(t *testing.T) {
 	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
 		"agent-1": {
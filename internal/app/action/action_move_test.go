@@ -144,3 +144,55 @@ func TestUseCase_MoveToPosition_RejectsWhenTargetNotPassable(t *testing.T) {
 		t.Fatalf("unexpected blocking_tile_pos details: %+v", posErr.BlockingTilePos)
 	}
 }
+
+func TestUseCase_MoveBlockedByWallButOwnerPassesDoor(t *testing.T) {
+	newUC := func(objects map[string]ports.WorldObjectRecord) UseCase {
+		return UseCase{
+			TxManager: stubTxManager{},
+			StateRepo: &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+				"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
+			}},
+			ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+			EventRepo:  &stubEventRepo{},
+			ObjectRepo: &stubObjectRepo{byID: objects},
+			World: worldmock.Provider{Snapshot: world.Snapshot{
+				TimeOfDay:    "day",
+				VisibleTiles: []world.Tile{{X: 0, Y: 0, Passable: true}, {X: 1, Y: 0, Passable: true}},
+			}},
+			Settle: survival.SettlementService{},
+			Now:    func() time.Time { return time.Unix(1700000000, 0) },
+		}
+	}
+	move := Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-move-structure",
+		Intent:         survival.ActionIntent{Type: survival.ActionMove, Direction: "E"},
+	}
+
+	wall := newUC(map[string]ports.WorldObjectRecord{
+		"obj-wall": {ObjectID: "obj-wall", ObjectType: "wall", X: 1, Y: 0, OwnerAgentID: "agent-1"},
+	})
+	_, err := wall.Execute(context.Background(), move)
+	var posErr *ActionInvalidPositionError
+	if !errors.As(err, &posErr) || posErr.BlockingTilePos == nil || posErr.BlockingTilePos.X != 1 {
+		t.Fatalf("expected wall to block move with blocking tile details, got %v", err)
+	}
+
+	door := newUC(map[string]ports.WorldObjectRecord{
+		"obj-door": {ObjectID: "obj-door", ObjectType: "door", X: 1, Y: 0, OwnerAgentID: "agent-1"},
+	})
+	out, err := door.Execute(context.Background(), move)
+	if err != nil {
+		t.Fatalf("expected owner to pass through own door, got %v", err)
+	}
+	if out.UpdatedState.Position.X != 1 {
+		t.Fatalf("expected agent on door tile, got %+v", out.UpdatedState.Position)
+	}
+
+	foreignDoor := newUC(map[string]ports.WorldObjectRecord{
+		"obj-door": {ObjectID: "obj-door", ObjectType: "door", X: 1, Y: 0, OwnerAgentID: "agent-2"},
+	})
+	if _, err := foreignDoor.Execute(context.Background(), move); !errors.Is(err, ErrActionInvalidPosition) {
+		t.Fatalf("expected foreign door to block move, got %v", err)
+	}
+}
@@ -8,28 +8,48 @@ import (
 	"time"
 
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/farmstate"
+	"clawvival/internal/app/shared/furnacestate"
+	"clawvival/internal/app/shared/objectwear"
 	"clawvival/internal/domain/survival"
 )
 
 type farmPlantActionHandler struct{ BaseHandler }
 type farmHarvestActionHandler struct{ BaseHandler }
+type farmWaterActionHandler struct{ BaseHandler }
+type repairActionHandler struct{ BaseHandler }
+type deconstructActionHandler struct{ BaseHandler }
 type containerDepositActionHandler struct{ BaseHandler }
 type containerWithdrawActionHandler struct{ BaseHandler }
 type buildActionHandler struct{ BaseHandler }
 
-func validateBuildActionParams(intent survival.ActionIntent) bool {
-	_, ok := buildKindFromObjectType(intent.ObjectType)
+func validateBuildActionParams(rules *survival.RuleSet, intent survival.ActionIntent) bool {
+	_, ok := rules.Building(intent.ObjectType)
 	return ok && intent.Pos != nil
 }
 
 func validateFarmPlantActionParams(intent survival.ActionIntent) bool {
-	return strings.TrimSpace(intent.FarmID) != ""
+	_, ok := survival.ParseCrop(intent.ItemType)
+	return strings.TrimSpace(intent.FarmID) != "" && ok
 }
 
 func validateFarmHarvestActionParams(intent survival.ActionIntent) bool {
 	return strings.TrimSpace(intent.FarmID) != ""
 }
 
+func validateFarmWaterActionParams(intent survival.ActionIntent) bool {
+	item := strings.TrimSpace(intent.ItemType)
+	return strings.TrimSpace(intent.FarmID) != "" && (item == "" || item == survival.ItemWaterFlask)
+}
+
+func validateRepairActionParams(intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.ObjectID) != ""
+}
+
+func validateDeconstructActionParams(intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.ObjectID) != ""
+}
+
 func validateContainerActionParams(intent survival.ActionIntent) bool {
 	return strings.TrimSpace(intent.ContainerID) != "" && hasValidItems(intent.Items)
 }
@@ -38,7 +58,7 @@ func (h buildActionHandler) Precheck(ctx context.Context, uc UseCase, ac *Action
 	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
 		return err
 	}
-	if !buildPreconditionsSatisfied(ac.View.StateWorking, ac.Tmp.ResolvedIntent) {
+	if !buildPreconditionsSatisfied(uc.Settle.RuleSet(), ac.View.StateWorking, ac.Tmp.ResolvedIntent) {
 		return ErrActionPreconditionFailed
 	}
 	return nil
@@ -70,6 +90,59 @@ func (h farmHarvestActionHandler) ExecuteActionAndPlan(ctx context.Context, uc U
 	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
 }
 
+func (h farmWaterActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType) == survival.ItemWaterFlask {
+		if ac.View.StateWorking.Inventory[survival.ItemWaterFlask] <= 0 {
+			return ErrActionPreconditionFailed
+		}
+		return nil
+	}
+	if !adjacentToWater(ac.View.StateWorking.Position, ac.View.Snapshot.VisibleTiles) {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h farmWaterActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	ac.Tmp.ResolvedIntent.ItemType = strings.TrimSpace(ac.Tmp.ResolvedIntent.ItemType)
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
+func (h repairActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	prepared := ac.View.PreparedObj
+	if prepared == nil || prepared.record.HP >= survival.ObjectMaxHP {
+		return ErrActionPreconditionFailed
+	}
+	if !uc.Settle.RuleSet().CanRepair(ac.View.StateWorking, ac.Tmp.ResolvedIntent.ObjectType) {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h repairActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
+func (h deconstructActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	if ac.View.PreparedObj == nil {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h deconstructActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyObjectAction: true, createBuiltObjects: true})
+}
+
 func (h containerDepositActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
 	return runStandardActionPrecheck(ctx, uc, ac)
 }
@@ -87,25 +160,32 @@ func (h containerWithdrawActionHandler) ExecuteActionAndPlan(ctx context.Context
 }
 
 type preparedObjectAction struct {
-	record ports.WorldObjectRecord
-	box    boxObjectState
-	farm   farmObjectState
+	record      ports.WorldObjectRecord
+	box         boxObjectState
+	farm        farmstate.State
+	furnace     furnacestate.State
+	growMinutes int
+	// returnSeed is set when deconstructing a farm plot with a crop still
+	// growing on it.
+	returnSeed bool
+	// stacks are the perishables moving between the agent and a container,
+	// stamped for where they are headed.
+	stacks map[string][]survival.FoodStack
+	// tools are the tool copies moving with their wear.
+	tools map[string][]survival.ToolStack
 }
 
 type boxObjectState struct {
-	Inventory map[string]int `json:"inventory"`
-}
-
-type farmObjectState struct {
-	State         string `json:"state"`
-	PlantedAtUnix int64  `json:"planted_at_unix,omitempty"`
-	ReadyAtUnix   int64  `json:"ready_at_unix,omitempty"`
+	Inventory map[string]int                  `json:"inventory"`
+	Freshness map[string][]survival.FoodStack `json:"freshness,omitempty"`
+	Tools     map[string][]survival.ToolStack `json:"tools,omitempty"`
 }
 
-func prepareObjectAction(ctx context.Context, nowAt time.Time, state survival.AgentStateAggregate, intent survival.ActionIntent, repo ports.WorldObjectRepository, agentID string) (*preparedObjectAction, error) {
+func prepareObjectAction(ctx context.Context, nowAt time.Time, rules *survival.RuleSet, state survival.AgentStateAggregate, intent survival.ActionIntent, repo ports.WorldObjectRepository, agentID string) (*preparedObjectAction, error) {
 	if repo == nil {
 		switch intent.Type {
-		case survival.ActionSleep, survival.ActionFarmPlant, survival.ActionFarmHarvest, survival.ActionContainerDeposit, survival.ActionContainerWithdraw:
+		case survival.ActionSleep, survival.ActionFarmPlant, survival.ActionFarmHarvest, survival.ActionFarmWater, survival.ActionRepair, survival.ActionDeconstruct, survival.ActionContainerDeposit, survival.ActionContainerWithdraw,
+			survival.ActionFurnaceFuel, survival.ActionFurnaceLoad, survival.ActionFurnaceCollect:
 			return nil, ErrActionPreconditionFailed
 		}
 		return nil, nil
@@ -141,6 +221,9 @@ func prepareObjectAction(ctx context.Context, nowAt time.Time, state survival.Ag
 		if err != nil {
 			return nil, ErrActionPreconditionFailed
 		}
+		box.Freshness = survival.SyncFoodStacks(box.Inventory, box.Freshness, nowAt)
+		survival.SpoilStoredFood(box.Inventory, box.Freshness, nowAt)
+		box.Tools = survival.SyncToolStacks(box.Inventory, box.Tools)
 		total := 0
 		requested := aggregateItemCounts(intent.Items)
 		for _, item := range intent.Items {
@@ -162,16 +245,23 @@ func prepareObjectAction(ctx context.Context, nowAt time.Time, state survival.Ag
 			return nil, ErrContainerFull
 		}
 		if intent.Type == survival.ActionContainerWithdraw {
-			capacity := state.InventoryCapacity
-			if capacity <= 0 {
-				capacity = survival.DefaultInventoryCapacity
-			}
+			capacity := survival.InventoryCapacity(state)
 			if inventoryUsed(state.Inventory)+total > capacity {
 				return nil, ErrInventoryFull
 			}
 		}
-		return &preparedObjectAction{record: obj, box: box}, nil
-	case survival.ActionFarmPlant, survival.ActionFarmHarvest:
+		prepared := &preparedObjectAction{record: obj, box: box}
+		switch intent.Type {
+		case survival.ActionContainerDeposit:
+			held := survival.SyncFoodStacks(state.Inventory, state.Freshness, nowAt)
+			prepared.stacks = survival.RestampFoodStacks(survival.OldestFoodStacks(held, intent.Items), nowAt, 100, survival.StoredFoodSpoilPercent)
+			prepared.tools = survival.ToolStacksLeaving(state, intent.Items)
+		case survival.ActionContainerWithdraw:
+			prepared.stacks = survival.RestampFoodStacks(survival.OldestFoodStacks(box.Freshness, intent.Items), nowAt, survival.StoredFoodSpoilPercent, 100)
+			prepared.tools = survival.ContainerToolStacks(box.Tools, intent.Items)
+		}
+		return prepared, nil
+	case survival.ActionFarmPlant, survival.ActionFarmHarvest, survival.ActionFarmWater:
 		obj, err := repo.GetByObjectID(ctx, agentID, intent.FarmID)
 		if err != nil {
 			if errors.Is(err, ports.ErrNotFound) {
@@ -179,34 +269,135 @@ func prepareObjectAction(ctx context.Context, nowAt time.Time, state survival.Ag
 			}
 			return nil, err
 		}
-		if !isFarmObject(obj) {
+		if !isFarmObject(obj) || !canModifyObject(obj, agentID) {
 			return nil, ErrActionPreconditionFailed
 		}
-		farm, err := parseFarmObjectState(obj.ObjectState)
+		farm, err := farmstate.Parse(obj.ObjectState)
 		if err != nil {
 			return nil, ErrActionPreconditionFailed
 		}
+		farm = farmstate.Advance(farm, nowAt, rules)
 		switch intent.Type {
 		case survival.ActionFarmPlant:
-			if strings.ToUpper(strings.TrimSpace(farm.State)) != "IDLE" {
+			// A withered crop is cleared by planting over it.
+			if farm.State != farmstate.StateIdle && farm.State != farmstate.StateWithered {
 				return nil, ErrActionPreconditionFailed
 			}
 		case survival.ActionFarmHarvest:
-			ready := strings.ToUpper(strings.TrimSpace(farm.State)) == "READY"
-			if strings.ToUpper(strings.TrimSpace(farm.State)) == "GROWING" && farm.ReadyAtUnix > 0 && nowAt.Unix() >= farm.ReadyAtUnix {
-				ready = true
+			if farm.State != farmstate.StateReady {
+				return nil, ErrActionPreconditionFailed
 			}
-			if !ready {
+		case survival.ActionFarmWater:
+			if farm.State != farmstate.StateGrowing && farm.State != farmstate.StateReady {
 				return nil, ErrActionPreconditionFailed
 			}
 		}
 		return &preparedObjectAction{record: obj, farm: farm}, nil
+	case survival.ActionRepair:
+		obj, err := repo.GetByObjectID(ctx, agentID, intent.ObjectID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ErrActionPreconditionFailed
+			}
+			return nil, err
+		}
+		if _, ok := rules.RepairCost(objectwear.TypeKey(obj)); !ok || !canModifyObject(obj, agentID) {
+			return nil, ErrActionPreconditionFailed
+		}
+		return &preparedObjectAction{record: obj}, nil
+	case survival.ActionDeconstruct:
+		obj, err := repo.GetByObjectID(ctx, agentID, intent.ObjectID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ErrActionPreconditionFailed
+			}
+			return nil, err
+		}
+		refund, ok := rules.DeconstructRefund(objectwear.TypeKey(obj))
+		if !ok || !ownsObject(obj, agentID) {
+			return nil, ErrActionPreconditionFailed
+		}
+		refundCount := inventoryUsed(refund)
+		prepared := &preparedObjectAction{record: obj}
+		switch {
+		case isBoxObject(obj):
+			box, err := parseBoxObjectState(obj.ObjectState)
+			if err != nil {
+				return nil, ErrActionPreconditionFailed
+			}
+			if obj.UsedSlots > 0 || inventoryUsed(box.Inventory) > 0 {
+				return nil, ErrContainerNotEmpty
+			}
+		case isFurnaceObject(obj):
+			furnace, err := furnacestate.Parse(obj.ObjectState)
+			if err != nil {
+				return nil, ErrActionPreconditionFailed
+			}
+			if furnace.FuelMinutes > 0 || len(furnace.Queue) > 0 || len(furnace.Output) > 0 {
+				return nil, ErrContainerNotEmpty
+			}
+		case isFarmObject(obj):
+			farm, err := farmstate.Parse(obj.ObjectState)
+			if err != nil {
+				return nil, ErrActionPreconditionFailed
+			}
+			// A ripe crop has to be harvested first; a growing one gives its seed back.
+			farm = farmstate.Advance(farm, nowAt, rules)
+			if farm.State == farmstate.StateReady {
+				return nil, ErrActionPreconditionFailed
+			}
+			if farm.State == farmstate.StateGrowing {
+				prepared.returnSeed = true
+				refundCount++
+			}
+			prepared.farm = farm
+		}
+		if inventoryUsed(state.Inventory)+refundCount > survival.InventoryCapacity(state) {
+			return nil, ErrInventoryFull
+		}
+		return prepared, nil
+	case survival.ActionFurnaceFuel, survival.ActionFurnaceLoad, survival.ActionFurnaceCollect:
+		obj, err := repo.GetByObjectID(ctx, agentID, intent.ObjectID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ErrActionPreconditionFailed
+			}
+			return nil, err
+		}
+		if !isFurnaceObject(obj) || !canModifyObject(obj, agentID) {
+			return nil, ErrActionPreconditionFailed
+		}
+		furnace, err := furnacestate.Parse(obj.ObjectState)
+		if err != nil {
+			return nil, ErrActionPreconditionFailed
+		}
+		furnace = furnacestate.Advance(furnace, nowAt, rules)
+		switch intent.Type {
+		case survival.ActionFurnaceFuel:
+			if !rules.CanFuelFurnace(state, intent.ItemType, intent.Count) {
+				return nil, ErrActionPreconditionFailed
+			}
+		case survival.ActionFurnaceLoad:
+			if !rules.CanLoadFurnace(state, furnace.Furnace(), survival.RecipeID(intent.RecipeID), intent.Count) {
+				return nil, ErrActionPreconditionFailed
+			}
+		case survival.ActionFurnaceCollect:
+			items := furnace.OutputItems()
+			if len(items) == 0 {
+				return nil, ErrActionPreconditionFailed
+			}
+			capacity := survival.InventoryCapacity(state)
+			if inventoryUsed(state.Inventory)+inventoryUsed(aggregateItemCounts(items)) > capacity {
+				return nil, ErrInventoryFull
+			}
+		}
+		return &preparedObjectAction{record: obj, furnace: furnace}, nil
 	default:
 		return nil, nil
 	}
 }
 
-func persistObjectAction(ctx context.Context, nowAt time.Time, intent survival.ActionIntent, prepared *preparedObjectAction, repo ports.WorldObjectRepository, agentID string) error {
+func persistObjectAction(ctx context.Context, nowAt time.Time, rules *survival.RuleSet, intent survival.ActionIntent, prepared *preparedObjectAction, repo ports.WorldObjectRepository, agentID string) error {
 	if repo == nil || prepared == nil {
 		return nil
 	}
@@ -220,6 +411,8 @@ func persistObjectAction(ctx context.Context, nowAt time.Time, intent survival.A
 			prepared.box.Inventory[item.ItemType] += item.Count
 			obj.UsedSlots += item.Count
 		}
+		prepared.box.Freshness = survival.AddFoodStacks(prepared.box.Freshness, prepared.stacks)
+		prepared.box.Tools = survival.AddToolStacks(prepared.box.Tools, prepared.tools)
 		encoded, err := json.Marshal(prepared.box)
 		if err != nil {
 			return err
@@ -240,37 +433,80 @@ func persistObjectAction(ctx context.Context, nowAt time.Time, intent survival.A
 		if obj.UsedSlots < 0 {
 			obj.UsedSlots = 0
 		}
+		prepared.box.Freshness = survival.SyncFoodStacks(prepared.box.Inventory, prepared.box.Freshness, nowAt)
+		prepared.box.Tools = survival.SyncToolStacks(prepared.box.Inventory, prepared.box.Tools)
 		encoded, err := json.Marshal(prepared.box)
 		if err != nil {
 			return err
 		}
 		obj.ObjectState = string(encoded)
 		return repo.Update(ctx, agentID, obj)
-	case survival.ActionFarmPlant:
-		prepared.farm.State = "GROWING"
-		prepared.farm.PlantedAtUnix = nowAt.Unix()
-		prepared.farm.ReadyAtUnix = nowAt.Add(survival.DefaultFarmGrowMinutes * time.Minute).Unix()
-		encoded, err := json.Marshal(prepared.farm)
+	case survival.ActionDeconstruct:
+		return repo.Delete(ctx, agentID, obj.ObjectID)
+	case survival.ActionRepair:
+		obj.HP = survival.ObjectMaxHP
+		obj.DecayCheckedAt = nowAt
+		obj.WearCarry = 0
+		return repo.Update(ctx, agentID, obj)
+	case survival.ActionFarmPlant, survival.ActionFarmHarvest, survival.ActionFarmWater:
+		var next farmstate.State
+		switch intent.Type {
+		case survival.ActionFarmPlant:
+			crop, _ := survival.ParseCrop(intent.ItemType)
+			next = farmstate.Planted(crop, prepared.growMinutes, nowAt, rules)
+		case survival.ActionFarmHarvest:
+			next = farmstate.State{State: farmstate.StateIdle}
+		case survival.ActionFarmWater:
+			next, _ = farmstate.Water(prepared.farm, rules)
+		}
+		encoded, err := next.Encode()
 		if err != nil {
 			return err
 		}
-		obj.ObjectState = string(encoded)
+		obj.ObjectState = encoded
 		return repo.Update(ctx, agentID, obj)
-	case survival.ActionFarmHarvest:
-		prepared.farm.State = "IDLE"
-		prepared.farm.PlantedAtUnix = 0
-		prepared.farm.ReadyAtUnix = 0
-		encoded, err := json.Marshal(prepared.farm)
+	case survival.ActionFurnaceFuel, survival.ActionFurnaceLoad, survival.ActionFurnaceCollect:
+		next := prepared.furnace
+		switch intent.Type {
+		case survival.ActionFurnaceFuel:
+			next, _ = furnacestate.Fuel(next, intent.ItemType, intent.Count, rules)
+		case survival.ActionFurnaceLoad:
+			next, _ = furnacestate.Load(next, survival.RecipeID(intent.RecipeID), intent.Count, rules)
+		case survival.ActionFurnaceCollect:
+			next = furnacestate.Collected(next)
+		}
+		encoded, err := next.Encode()
 		if err != nil {
 			return err
 		}
-		obj.ObjectState = string(encoded)
+		obj.ObjectState = encoded
 		return repo.Update(ctx, agentID, obj)
 	default:
 		return nil
 	}
 }
 
+// persistObjectWear writes HP lost to wear since the last check and records
+// an object_destroyed event for each object that fell apart. Only the wear
+// columns are written so container and furnace state stay untouched.
+func persistObjectWear(ctx context.Context, repo ports.WorldObjectRepository, ac *ActionContext) error {
+	if repo == nil {
+		return nil
+	}
+	for _, obj := range ac.View.Wear.Worn {
+		if err := repo.UpdateWear(ctx, ac.In.AgentID, obj, ac.View.Wear.CheckedAt(obj.ObjectID)); err != nil {
+			return err
+		}
+	}
+	for _, obj := range ac.View.Wear.Destroyed {
+		if err := repo.UpdateWear(ctx, ac.In.AgentID, obj, ac.View.Wear.CheckedAt(obj.ObjectID)); err != nil {
+			return err
+		}
+	}
+	ac.Plan.EventsToAppend = append(ac.Plan.EventsToAppend, objectwear.DestroyedEvents(ac.View.Wear.Destroyed, ac.In.NowAt)...)
+	return nil
+}
+
 func parseBoxObjectState(raw string) (boxObjectState, error) {
 	out := boxObjectState{Inventory: map[string]int{}}
 	if strings.TrimSpace(raw) == "" {
@@ -285,18 +521,16 @@ func parseBoxObjectState(raw string) (boxObjectState, error) {
 	return out, nil
 }
 
-func parseFarmObjectState(raw string) (farmObjectState, error) {
-	out := farmObjectState{State: "IDLE"}
-	if strings.TrimSpace(raw) == "" {
-		return out, nil
-	}
-	if err := json.Unmarshal([]byte(raw), &out); err != nil {
-		return farmObjectState{}, err
-	}
-	if strings.TrimSpace(out.State) == "" {
-		out.State = "IDLE"
-	}
-	return out, nil
+// ownsObject treats objects saved before owners were recorded as the
+// caller's own.
+func ownsObject(obj ports.WorldObjectRecord, agentID string) bool {
+	return obj.OwnerAgentID == "" || obj.OwnerAgentID == agentID
+}
+
+// canModifyObject is the shared-world write rule: boxes are common storage,
+// anything else only changes at its owner's hand.
+func canModifyObject(obj ports.WorldObjectRecord, agentID string) bool {
+	return ownsObject(obj, agentID) || isBoxObject(obj)
 }
 
 func isBoxObject(obj ports.WorldObjectRecord) bool {
@@ -365,33 +599,12 @@ func hasValidItems(items []survival.ItemAmount) bool {
 	return true
 }
 
-func buildPreconditionsSatisfied(state survival.AgentStateAggregate, intent survival.ActionIntent) bool {
+func buildPreconditionsSatisfied(rules *survival.RuleSet, state survival.AgentStateAggregate, intent survival.ActionIntent) bool {
 	if intent.Type != survival.ActionBuild {
 		return true
 	}
-	_, ok := buildKindFromObjectType(intent.ObjectType)
-	return ok && survival.CanBuildObjectType(state, intent.ObjectType)
-}
-
-func buildKindFromObjectType(objectType string) (survival.BuildKind, bool) {
-	switch strings.ToLower(strings.TrimSpace(objectType)) {
-	case "bed", "bed_rough", "bed_good":
-		return survival.BuildBed, true
-	case "box":
-		return survival.BuildBox, true
-	case "farm_plot":
-		return survival.BuildFarm, true
-	case "torch":
-		return survival.BuildTorch, true
-	case "wall":
-		return survival.BuildWall, true
-	case "door":
-		return survival.BuildDoor, true
-	case "furnace":
-		return survival.BuildFurnace, true
-	default:
-		return 0, false
-	}
+	_, ok := rules.Building(intent.ObjectType)
+	return ok && rules.CanBuildObjectType(state, intent.ObjectType)
 }
 
 func attachBuiltObjectIDs(events []survival.DomainEvent, ids []string) {
@@ -51,8 +51,8 @@ func TestUseCase_ContainerWithdrawRejectsWhenInventoryCapacityExceeded(t *testin
 			AgentID:           "agent-1",
 			Vitals:            survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
 			Position:          survival.Position{X: 0, Y: 0},
-			Inventory:         map[string]int{"wood": 1},
-			InventoryCapacity: 1,
+			Inventory:         map[string]int{"wood": survival.DefaultInventoryCapacity},
+			InventoryCapacity: survival.DefaultInventoryCapacity,
 			Version:           1,
 		},
 	}}
@@ -365,3 +365,465 @@ func TestUseCase_BuildFurnaceCreatesWorldObject(t *testing.T) {
 		t.Fatalf("expected kind=%d, got=%d", int(survival.BuildFurnace), obj.Kind)
 	}
 }
+
+func TestUseCase_FarmWateringSpeedsCropToHarvest(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Position:  survival.Position{X: 0, Y: 0},
+			Inventory: map[string]int{"seed": 1, survival.ItemWaterFlask: 1},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"farm-1": {ObjectID: "farm-1", ObjectType: "farm_plot", ObjectState: `{"state":"IDLE"}`},
+	}}
+	now := time.Unix(1700000000, 0)
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+	execute := func(key string, intent survival.ActionIntent) error {
+		_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: key, Intent: intent})
+		return err
+	}
+
+	if err := execute("k-plant", survival.ActionIntent{Type: survival.ActionFarmPlant, FarmID: "farm-1", ItemType: "potato"}); err != nil {
+		t.Fatalf("plant potato: %v", err)
+	}
+	now = now.Add(10 * time.Minute)
+	if err := execute("k-water", survival.ActionIntent{Type: survival.ActionFarmWater, FarmID: "farm-1", ItemType: survival.ItemWaterFlask}); err != nil {
+		t.Fatalf("water farm: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory[survival.ItemFlask]; got != 1 {
+		t.Fatalf("expected watering to empty the flask, got flask=%d", got)
+	}
+
+	now = now.Add(70 * time.Minute)
+	if err := execute("k-harvest-early", survival.ActionIntent{Type: survival.ActionFarmHarvest, FarmID: "farm-1"}); !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected early harvest rejected, got %v", err)
+	}
+	now = now.Add(10 * time.Minute)
+	if err := execute("k-harvest", survival.ActionIntent{Type: survival.ActionFarmHarvest, FarmID: "farm-1"}); err != nil {
+		t.Fatalf("harvest potato: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["potato"]; got != 4 {
+		t.Fatalf("potato yield mismatch: got=%d want=4", got)
+	}
+	if got := objectRepo.byID["farm-1"].ObjectState; got != `{"state":"IDLE"}` {
+		t.Fatalf("expected farm reset after harvest, got %s", got)
+	}
+}
+
+func TestUseCase_FarmWaterRejectsIdlePlot(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{survival.ItemWaterFlask: 1},
+			Version:   1,
+		},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+			"farm-1": {ObjectID: "farm-1", ObjectType: "farm_plot", ObjectState: `{"state":"IDLE"}`},
+		}},
+		World:  worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-water-idle",
+		Intent:         survival.ActionIntent{Type: survival.ActionFarmWater, FarmID: "farm-1", ItemType: survival.ItemWaterFlask},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed, got %v", err)
+	}
+}
+
+func TestUseCase_RepairRestoresHPAndRecordsDestroyedObjects(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	checked := now.Add(-time.Hour)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{"stone": 3},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"wall-1":  {ObjectID: "wall-1", ObjectType: "wall", X: 1, Y: 0, HP: 40, DecayCheckedAt: checked},
+		"torch-1": {ObjectID: "torch-1", ObjectType: "torch", X: 2, Y: 0, HP: 2, DecayCheckedAt: checked},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-repair-wall",
+		Intent:         survival.ActionIntent{Type: survival.ActionRepair, ObjectID: "wall-1"},
+	})
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if got := objectRepo.byID["wall-1"]; got.HP != survival.ObjectMaxHP || !got.DecayCheckedAt.Equal(now) {
+		t.Fatalf("expected wall repaired to full hp, got %+v", got)
+	}
+	if got := out.UpdatedState.Inventory["stone"]; got != 1 {
+		t.Fatalf("expected repair to consume 2 stone, left=%d", got)
+	}
+	if got := objectRepo.byID["torch-1"].HP; got != 0 {
+		t.Fatalf("expected worn-out torch persisted at 0 hp, got %d", got)
+	}
+	seen := map[string]bool{}
+	for _, evt := range out.Events {
+		seen[evt.Type] = true
+	}
+	if !seen["object_repaired"] || !seen["object_destroyed"] {
+		t.Fatalf("expected object_repaired and object_destroyed events, got %+v", out.Events)
+	}
+}
+
+func TestUseCase_RepairRejectsUndamagedObject(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{"stone": 3},
+			Version:   1,
+		},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+			"wall-1": {ObjectID: "wall-1", ObjectType: "wall", HP: survival.ObjectMaxHP},
+		}},
+		World:  worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-repair-full",
+		Intent:         survival.ActionIntent{Type: survival.ActionRepair, ObjectID: "wall-1"},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed, got %v", err)
+	}
+}
+
+func TestUseCase_DeconstructDeletesObjectAndRefundsMaterials(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"box-full":  {ObjectID: "box-full", ObjectType: "box", HP: 100, UsedSlots: 1, ObjectState: `{"inventory":{"wood":1}}`},
+		"box-empty": {ObjectID: "box-empty", ObjectType: "box", HP: 100, ObjectState: `{"inventory":{}}`},
+		"furnace-1": {ObjectID: "furnace-1", ObjectType: "furnace", HP: 100, ObjectState: `{"fuel_minutes":0,"output":{"iron_ingot":1}}`},
+		"farm-1": {ObjectID: "farm-1", ObjectType: "farm_plot", HP: 100,
+			ObjectState: `{"state":"GROWING","crop":"potato","planted_at_unix":1699999000,"target_minutes":150}`},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+	execute := func(key, objectID string) error {
+		_, err := uc.Execute(context.Background(), Request{
+			AgentID:        "agent-1",
+			IdempotencyKey: key,
+			Intent:         survival.ActionIntent{Type: survival.ActionDeconstruct, ObjectID: objectID},
+		})
+		return err
+	}
+
+	if err := execute("k-deconstruct-full", "box-full"); !errors.Is(err, ErrContainerNotEmpty) {
+		t.Fatalf("expected ErrContainerNotEmpty, got %v", err)
+	}
+	if err := execute("k-deconstruct-furnace", "furnace-1"); !errors.Is(err, ErrContainerNotEmpty) {
+		t.Fatalf("expected furnace with uncollected output kept, got %v", err)
+	}
+	// The seed refund is resolved from farm state, not from client params.
+	if _, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-deconstruct-box",
+		Intent:         survival.ActionIntent{Type: survival.ActionDeconstruct, ObjectID: "box-empty", ItemType: "seed"},
+	}); err != nil {
+		t.Fatalf("deconstruct box: %v", err)
+	}
+	if _, ok := objectRepo.byID["box-empty"]; ok {
+		t.Fatalf("expected deconstructed box deleted")
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["wood"]; got != 2 {
+		t.Fatalf("expected half box cost refunded, wood=%d", got)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["seed"]; got != 0 {
+		t.Fatalf("expected no seed from a box, seed=%d", got)
+	}
+	if err := execute("k-deconstruct-farm", "farm-1"); err != nil {
+		t.Fatalf("deconstruct farm: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Inventory["seed"]; got != 1 {
+		t.Fatalf("expected growing crop seed returned, seed=%d", got)
+	}
+}
+
+func TestUseCase_DeconstructRequiresOwnerAndRoomForRefund(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{"stone": survival.DefaultInventoryCapacity - 1},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"box-other": {ObjectID: "box-other", ObjectType: "box", HP: 100, OwnerAgentID: "agent-2", ObjectState: `{"inventory":{}}`},
+		"box-own":   {ObjectID: "box-own", ObjectType: "box", HP: 100, OwnerAgentID: "agent-1", ObjectState: `{"inventory":{}}`},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	execute := func(key, objectID string) error {
+		_, err := uc.Execute(context.Background(), Request{
+			AgentID:        "agent-1",
+			IdempotencyKey: key,
+			Intent:         survival.ActionIntent{Type: survival.ActionDeconstruct, ObjectID: objectID},
+		})
+		return err
+	}
+
+	if err := execute("k-deconstruct-other", "box-other"); !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected another agent's box to be off limits, got %v", err)
+	}
+	if err := execute("k-deconstruct-own", "box-own"); !errors.Is(err, ErrInventoryFull) {
+		t.Fatalf("expected refund over capacity to be rejected, got %v", err)
+	}
+	if _, ok := objectRepo.byID["box-own"]; !ok {
+		t.Fatalf("expected box kept when the refund does not fit")
+	}
+}
+
+func TestUseCase_BoxSlowsFoodSpoilageAcrossDepositAndWithdraw(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{"berry": 2},
+			Freshness: map[string][]survival.FoodStack{
+				"berry": {{Count: 2, AcquiredAt: now.Add(-6 * time.Hour)}},
+			},
+			Version: 1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"box-1": {ObjectID: "box-1", ObjectType: "box", CapacitySlots: 60, ObjectState: `{"inventory":{}}`},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+	execute := func(key string, intent survival.ActionIntent) error {
+		_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: key, Intent: intent})
+		return err
+	}
+	berries := []survival.ItemAmount{{ItemType: "berry", Count: 2}}
+
+	if err := execute("k-deposit", survival.ActionIntent{Type: survival.ActionContainerDeposit, ContainerID: "box-1", Items: berries}); err != nil {
+		t.Fatalf("deposit: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].Freshness; len(got) != 0 {
+		t.Fatalf("expected agent to stop tracking deposited berries, got %+v", got)
+	}
+	// Eight more hours would spoil carried berries; stored ones age at half speed.
+	now = now.Add(8 * time.Hour)
+	if err := execute("k-withdraw", survival.ActionIntent{Type: survival.ActionContainerWithdraw, ContainerID: "box-1", Items: berries}); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	agent := stateRepo.byAgent["agent-1"]
+	if got := agent.Inventory["berry"]; got != 2 {
+		t.Fatalf("expected berries still good after storage, got berry=%d rotten=%d", got, agent.Inventory[survival.ItemRottenFood])
+	}
+	stacks := agent.Freshness["berry"]
+	if len(stacks) != 1 || survival.FoodAgeMinutes(stacks[0], now, 100) != 600 {
+		t.Fatalf("expected withdrawn berries aged 10h, got %+v", stacks)
+	}
+}
+
+func TestUseCase_BoxKeepsToolWearAcrossDepositAndWithdraw(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:    "agent-1",
+			Vitals:     survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory:  map[string]int{"tool_axe": 1},
+			ToolStacks: map[string][]survival.ToolStack{"tool_axe": {{Count: 1, Durability: 5}}},
+			Version:    1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"box-1": {ObjectID: "box-1", ObjectType: "box", CapacitySlots: 60, ObjectState: `{"inventory":{}}`},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+	execute := func(key string, intent survival.ActionIntent) error {
+		_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: key, Intent: intent})
+		return err
+	}
+	axe := []survival.ItemAmount{{ItemType: "tool_axe", Count: 1}}
+
+	if err := execute("k-deposit", survival.ActionIntent{Type: survival.ActionContainerDeposit, ContainerID: "box-1", Items: axe}); err != nil {
+		t.Fatalf("deposit: %v", err)
+	}
+	if got := stateRepo.byAgent["agent-1"].ToolStacks; len(got) != 0 {
+		t.Fatalf("expected agent to stop tracking the deposited axe, got %+v", got)
+	}
+	now = now.Add(time.Hour)
+	if err := execute("k-withdraw", survival.ActionIntent{Type: survival.ActionContainerWithdraw, ContainerID: "box-1", Items: axe}); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if got := survival.ToolDurability(stateRepo.byAgent["agent-1"], "tool_axe"); got != 5 {
+		t.Fatalf("expected withdrawn axe to keep its wear, got durability=%d", got)
+	}
+}
+
+func TestUseCase_OthersObjectsOnlyAcceptBoxDeposits(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:           "agent-1",
+			Vitals:            survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory:         map[string]int{"wood": 1, "stone": 3},
+			InventoryCapacity: 30,
+			Version:           1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"wall-2": {ObjectID: "wall-2", ObjectType: "wall", X: 1, Y: 0, HP: 40, OwnerAgentID: "agent-2"},
+		"box-2":  {ObjectID: "box-2", ObjectType: "box", OwnerAgentID: "agent-2", CapacitySlots: 60, ObjectState: `{"inventory":{}}`},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-repair-other",
+		Intent:         survival.ActionIntent{Type: survival.ActionRepair, ObjectID: "wall-2"},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected another agent's wall to be off limits, got %v", err)
+	}
+	_, err = uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-deposit-other",
+		Intent:         survival.ActionIntent{Type: survival.ActionContainerDeposit, ContainerID: "box-2", Items: []survival.ItemAmount{{ItemType: "wood", Count: 1}}},
+	})
+	if err != nil {
+		t.Fatalf("expected shared box deposit, got %v", err)
+	}
+	if got := objectRepo.byID["box-2"].UsedSlots; got != 1 {
+		t.Fatalf("expected deposit stored in the shared box, used=%d", got)
+	}
+}
+
+func TestUseCase_OnlyObjectsAroundAgentAreWorn(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	checked := now.Add(-time.Hour)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+			Inventory: map[string]int{"stone": 3},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{
+		"wall-1":    {ObjectID: "wall-1", ObjectType: "wall", X: 1, Y: 0, HP: 40, DecayCheckedAt: checked},
+		"torch-far": {ObjectID: "torch-far", ObjectType: "torch", X: 500, Y: 0, HP: 2, DecayCheckedAt: checked},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day", ThreatLevel: 1, ViewRadius: 5}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return now },
+	}
+
+	if _, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-repair-near",
+		Intent:         survival.ActionIntent{Type: survival.ActionRepair, ObjectID: "wall-1"},
+	}); err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if got := objectRepo.byID["torch-far"]; got.HP != 2 || !got.DecayCheckedAt.Equal(checked) {
+		t.Fatalf("expected distant torch left alone, got %+v", got)
+	}
+}
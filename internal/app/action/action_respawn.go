@@ -0,0 +1,82 @@
+package action
+
+import (
+	"context"
+
+	"clawvival/internal/domain/survival"
+)
+
+type respawnActionHandler struct{ BaseHandler }
+
+func validateRespawnActionParams(survival.ActionIntent) bool {
+	return true
+}
+
+func (h respawnActionHandler) Precheck(_ context.Context, _ UseCase, ac *ActionContext) error {
+	state := ac.View.StateWorking
+	if !state.Dead {
+		return ErrActionPreconditionFailed
+	}
+	if legacy := ac.Tmp.ResolvedIntent.ItemType; legacy != "" && survival.RespawnLegacyCount(state, legacy) == 0 {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h respawnActionHandler) ExecuteActionAndPlan(_ context.Context, _ UseCase, ac *ActionContext) (ExecuteMode, error) {
+	prev := ac.View.StateWorking
+	legacy := ac.Tmp.ResolvedIntent.ItemType
+	previousSessionID := ac.In.SessionID
+	ac.In.SessionID = survival.NewSessionID(ac.In.AgentID, ac.In.NowAt)
+
+	next := survival.Respawn(prev, ac.In.SessionID, legacy, ac.In.NowAt)
+	next.Version++
+	event := survival.DomainEvent{
+		Type:       "agent_respawned",
+		OccurredAt: ac.In.NowAt,
+		Payload: map[string]any{
+			"previous_session_id":       previousSessionID,
+			"previous_death_cause":      string(prev.DeathCause),
+			"legacy_item":               legacy,
+			"legacy_count":              survival.RespawnLegacyCount(prev, legacy),
+			"world_time_before_seconds": ac.View.Snapshot.WorldTimeSeconds,
+			"world_time_after_seconds":  ac.View.Snapshot.WorldTimeSeconds,
+			"state_after": map[string]any{
+				"hp":     next.Vitals.HP,
+				"hunger": next.Vitals.Hunger,
+				"energy": next.Vitals.Energy,
+				"thirst": next.Vitals.Thirst,
+				"x":      next.Position.X,
+				"y":      next.Position.Y,
+				"pos":    map[string]int{"x": next.Position.X, "y": next.Position.Y},
+			},
+		},
+	}
+	ac.Plan.StateToSave = &next
+	ac.Plan.StateVersion = prev.Version
+	ac.Plan.EventsToAppend = []survival.DomainEvent{event}
+	ac.Plan.ExecutionToSave = &portsActionExecutionRecord{
+		AgentID:        ac.In.AgentID,
+		IdempotencyKey: ac.In.IdempotencyKey,
+		IntentType:     string(ac.Tmp.ResolvedIntent.Type),
+		Result: actionResult{
+			UpdatedState: next,
+			Events:       []survival.DomainEvent{event},
+			ResultCode:   survival.ResultOK,
+		},
+		AppliedAt: ac.In.NowAt,
+	}
+	ac.Plan.ResultCode = survival.ResultOK
+	ac.Plan.ShouldPersist = true
+	ac.Plan.OpenSession = true
+	ac.Tmp.Completed = true
+	ac.Tmp.Response = Response{
+		WorldTimeBeforeSeconds: ac.View.Snapshot.WorldTimeSeconds,
+		WorldTimeAfterSeconds:  ac.View.Snapshot.WorldTimeSeconds,
+		UpdatedState:           next,
+		Events:                 []survival.DomainEvent{event},
+		Settlement:             settlementSummary([]survival.DomainEvent{event}),
+		ResultCode:             survival.ResultOK,
+	}
+	return ExecuteModeCompleted, nil
+}
@@ -0,0 +1,128 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func TestUseCase_RespawnStartsNewSessionWithLegacyItem(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:    "agent-1",
+			Vitals:     survival.Vitals{HP: 0, Hunger: 0, Energy: 10, Thirst: 0},
+			Position:   survival.Position{X: 7, Y: -3},
+			Inventory:  map[string]int{"wood": 5, "knife": 1},
+			Dead:       true,
+			DeathCause: survival.DeathCauseStarvation,
+			Version:    9,
+		},
+	}}
+	sessionRepo := &stubSessionRepo{}
+	eventRepo := &stubEventRepo{}
+	uc := UseCase{
+		TxManager:   stubTxManager{},
+		StateRepo:   stateRepo,
+		ActionRepo:  &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:   eventRepo,
+		SessionRepo: sessionRepo,
+		World:       worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day"}},
+		Settle:      survival.SettlementService{},
+		Now:         func() time.Time { return now },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-respawn",
+		Intent:         survival.ActionIntent{Type: survival.ActionRespawn, ItemType: "wood"},
+	})
+	if err != nil {
+		t.Fatalf("expected respawn success, got %v", err)
+	}
+	got := out.UpdatedState
+	if got.Dead || got.Vitals != survival.SpawnVitals() || got.Position != survival.SpawnPosition {
+		t.Fatalf("expected fresh spawn state, got %+v", got)
+	}
+	if got.Inventory["wood"] != survival.RespawnLegacyItemCount || got.Inventory["knife"] != 0 {
+		t.Fatalf("expected only the legacy item to carry over, got %v", got.Inventory)
+	}
+	wantSession := survival.NewSessionID("agent-1", now)
+	if got.SessionID != wantSession || stateRepo.byAgent["agent-1"].SessionID != wantSession {
+		t.Fatalf("expected stored session %q, got %q", wantSession, stateRepo.byAgent["agent-1"].SessionID)
+	}
+	if !sessionRepo.called || sessionRepo.sessionID != wantSession {
+		t.Fatalf("expected new session to be opened, got %+v", sessionRepo)
+	}
+	if len(eventRepo.events) != 1 || eventRepo.events[0].Type != "agent_respawned" {
+		t.Fatalf("expected agent_respawned event, got %+v", eventRepo.events)
+	}
+	if prev := eventRepo.events[0].Payload["previous_session_id"]; prev != survival.LegacySessionID("agent-1") {
+		t.Fatalf("expected previous session recorded, got %v", prev)
+	}
+}
+
+func TestUseCase_RespawnRequiresDeadAgent(t *testing.T) {
+	uc := UseCase{
+		TxManager: stubTxManager{},
+		StateRepo: &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+			"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 50}, Version: 1},
+		}},
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day"}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: "k-respawn", Intent: survival.ActionIntent{Type: survival.ActionRespawn}})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected ErrActionPreconditionFailed for living agent, got %v", err)
+	}
+}
+
+func TestUseCase_RespawnLegacyFoodKeepsItsAge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	pickedAt := now.Add(-60 * time.Minute)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Inventory: map[string]int{"berry": 3},
+			Freshness: map[string][]survival.FoodStack{"berry": {
+				{Count: 2, AcquiredAt: now.Add(-600 * time.Minute)},
+				{Count: 1, AcquiredAt: pickedAt},
+			}},
+			Dead:    true,
+			Version: 2,
+		},
+	}}
+	uc := UseCase{
+		TxManager:   stubTxManager{},
+		StateRepo:   stateRepo,
+		ActionRepo:  &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:   &stubEventRepo{},
+		SessionRepo: &stubSessionRepo{},
+		World:       worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day"}},
+		Settle:      survival.SettlementService{},
+		Now:         func() time.Time { return now },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-respawn",
+		Intent:         survival.ActionIntent{Type: survival.ActionRespawn, ItemType: "berry"},
+	})
+	if err != nil {
+		t.Fatalf("expected respawn success, got %v", err)
+	}
+	berries := out.UpdatedState.Freshness["berry"]
+	if len(berries) != 1 || berries[0].Count != survival.RespawnLegacyItemCount || !berries[0].AcquiredAt.Equal(pickedAt) {
+		t.Fatalf("expected the freshest berry to carry over with its age, got %+v", berries)
+	}
+}
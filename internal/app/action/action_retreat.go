@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"clawvival/internal/app/shared/threats"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -26,14 +27,28 @@ func attachLastKnownThreat(result *survival.SettlementResult, snapshot world.Sna
 	if result == nil {
 		return
 	}
+	pos := world.Point{X: result.UpdatedState.Position.X, Y: result.UpdatedState.Position.Y}
+	creature, hasCreature := threats.Nearest(snapshot.Creatures, pos)
 	threat, ok := strongestVisibleThreat(snapshot)
-	if !ok {
+	if !hasCreature && !ok {
 		return
 	}
 	for i := range result.Events {
 		if result.Events[i].Type != "game_over" || result.Events[i].Payload == nil {
 			continue
 		}
+		if result.Events[i].Payload["last_known_threat"] != nil {
+			continue
+		}
+		if hasCreature {
+			result.Events[i].Payload["last_known_threat"] = map[string]any{
+				"id":           creature.ID,
+				"type":         string(creature.Kind),
+				"pos":          map[string]int{"x": creature.Pos.X, "y": creature.Pos.Y},
+				"danger_score": creature.DangerScore(),
+			}
+			continue
+		}
 		result.Events[i].Payload["last_known_threat"] = map[string]any{
 			"id":           fmt.Sprintf("thr_%d_%d", threat.X, threat.Y),
 			"type":         "wild",
@@ -58,11 +73,15 @@ func strongestVisibleThreat(snapshot world.Snapshot) (world.Tile, bool) {
 	return best, found
 }
 
-func resolveRetreatIntent(intent survival.ActionIntent, pos survival.Position, tiles []world.Tile) survival.ActionIntent {
+func resolveRetreatIntent(intent survival.ActionIntent, pos survival.Position, snapshot world.Snapshot) survival.ActionIntent {
 	if intent.Type != survival.ActionRetreat {
 		return intent
 	}
+	tiles := snapshot.VisibleTiles
 	target, ok := highestThreatTile(pos, tiles)
+	if creature, found := threats.Nearest(snapshot.Creatures, world.Point{X: pos.X, Y: pos.Y}); found {
+		target, ok = world.Tile{X: creature.Pos.X, Y: creature.Pos.Y, BaseThreat: creature.Damage}, true
+	}
 	if !ok {
 		return intent
 	}
@@ -139,3 +139,24 @@ func TestUseCase_RetreatIgnoresCenterThreatAndStillMovesAway(t *testing.T) {
 		t.Fatalf("expected retreat to move away from threat, got no movement")
 	}
 }
+
+func TestUseCase_RetreatMovesAwayFromNearestCreature(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Home: survival.Position{X: 0, Y: 0}, Inventory: map[string]int{}, Version: 1}}}
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	eventRepo := &stubEventRepo{}
+	uc := UseCase{TxManager: stubTxManager{}, StateRepo: stateRepo, ActionRepo: actionRepo, EventRepo: eventRepo, World: worldmock.Provider{Snapshot: world.Snapshot{
+		WorldTimeSeconds: 10,
+		TimeOfDay:        "night",
+		VisibleTiles:     []world.Tile{{X: 1, Y: 0, Passable: true, BaseThreat: 4}, {X: -1, Y: 0, Passable: true, BaseThreat: 1}, {X: 0, Y: 1, Passable: true, BaseThreat: 1}, {X: 0, Y: -1, Passable: true, BaseThreat: 1}},
+		Creatures:        []world.Creature{{ID: "crt-1", Kind: world.CreatureWolf, Pos: world.Point{X: -3, Y: 0}, HP: 20, Damage: 4}},
+	}}, Settle: survival.SettlementService{}, Now: func() time.Time {
+		return time.Unix(1700004000, 0)
+	}}
+	out, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: "k-retreat-creature", Intent: survival.ActionIntent{Type: survival.ActionRetreat}})
+	if err != nil {
+		t.Fatalf("execute error: %v", err)
+	}
+	if out.UpdatedState.Position.X != 0 || out.UpdatedState.Position.Y != -1 {
+		t.Fatalf("expected retreat away from the wolf rather than the hot tile to (0,-1), got (%d,%d)", out.UpdatedState.Position.X, out.UpdatedState.Position.Y)
+	}
+}
@@ -0,0 +1,322 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+type tradeOfferActionHandler struct{ BaseHandler }
+type tradeAcceptActionHandler struct{ BaseHandler }
+type tradeCancelActionHandler struct{ BaseHandler }
+
+func validateTradeOfferActionParams(intent survival.ActionIntent) bool {
+	if strings.TrimSpace(intent.ToAgentID) == "" || !hasValidItems(intent.Items) {
+		return false
+	}
+	// An empty request makes the offer a gift.
+	return len(intent.RequestItems) == 0 || hasValidItems(intent.RequestItems)
+}
+
+func validateTradeOfferIDActionParams(intent survival.ActionIntent) bool {
+	return strings.TrimSpace(intent.OfferID) != ""
+}
+
+func (h tradeOfferActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	intent := ac.Tmp.ResolvedIntent
+	toAgentID := strings.TrimSpace(intent.ToAgentID)
+	if uc.TradeRepo == nil || toAgentID == ac.In.AgentID || !survival.HasItems(ac.View.StateWorking, intent.Items) {
+		return ErrActionPreconditionFailed
+	}
+	recipient, err := uc.StateRepo.GetByAgentID(ctx, toAgentID)
+	if errors.Is(err, ports.ErrNotFound) {
+		return ErrActionPreconditionFailed
+	}
+	if err != nil {
+		return err
+	}
+	if recipient.Dead {
+		return ErrActionPreconditionFailed
+	}
+	return nil
+}
+
+func (h tradeOfferActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	intent := ac.Tmp.ResolvedIntent
+	escrowed := survival.GoodsLeaving(ac.View.StateWorking, intent.Items, ac.In.NowAt)
+	ac.View.TradeOffer = &ports.TradeOfferRecord{
+		OfferID:      "trade-" + ac.In.AgentID + "-" + ac.In.IdempotencyKey,
+		FromAgentID:  ac.In.AgentID,
+		ToAgentID:    strings.TrimSpace(intent.ToAgentID),
+		OfferItems:   survival.ItemAmountsToMap(intent.Items),
+		RequestItems: survival.ItemAmountsToMap(intent.RequestItems),
+		OfferStacks:  escrowed.Stacks,
+		OfferTools:   escrowed.Tools,
+		Status:       survival.TradeStatusOpen,
+		CreatedAt:    ac.In.NowAt,
+		ExpiresAt:    ac.In.NowAt.Add(time.Duration(uc.Settle.RuleSet().Trade.OfferTTLMinutes) * time.Minute),
+	}
+	return settleTradeAction(ctx, uc, ac, "trade_offered")
+}
+
+func (h tradeAcceptActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	offer, err := loadTradeOffer(ctx, uc, ac.Tmp.ResolvedIntent.OfferID)
+	if err != nil {
+		return err
+	}
+	if offer.ToAgentID != ac.In.AgentID || offer.Status != survival.TradeStatusOpen {
+		return ErrActionPreconditionFailed
+	}
+	if !ac.In.NowAt.Before(offer.ExpiresAt) {
+		return ErrTradeOfferExpired
+	}
+	requested := itemAmountsFromMap(offer.RequestItems)
+	if !survival.HasItems(ac.View.StateWorking, requested) {
+		return ErrActionPreconditionFailed
+	}
+	if err := ensureTradeCapacity(ctx, uc, ac.View.StateWorking, offer); err != nil {
+		return err
+	}
+	ac.View.TradeOffer = &offer
+	resolveOfferedGoods(ac, offer)
+	ac.Tmp.ResolvedIntent.RequestItems = requested
+	return nil
+}
+
+// ensureTradeCapacity checks both sides have room for what they receive. The
+// acceptor's payment leaves before the offered goods arrive, and the
+// offerer's goods already sit in escrow outside the inventory.
+func ensureTradeCapacity(ctx context.Context, uc UseCase, acceptor survival.AgentStateAggregate, offer ports.TradeOfferRecord) error {
+	offered, paid := inventoryUsed(offer.OfferItems), inventoryUsed(offer.RequestItems)
+	if inventoryUsed(acceptor.Inventory)-paid+offered > survival.InventoryCapacity(acceptor) {
+		return ErrInventoryFull
+	}
+	offerer, err := uc.StateRepo.GetByAgentID(ctx, offer.FromAgentID)
+	if err != nil {
+		return err
+	}
+	if inventoryUsed(offerer.Inventory)+paid > survival.InventoryCapacity(offerer) {
+		return ErrInventoryFull
+	}
+	return nil
+}
+
+func (h tradeAcceptActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	return settleTradeAction(ctx, uc, ac, "trade_completed")
+}
+
+func (h tradeCancelActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	if err := runStandardActionPrecheck(ctx, uc, ac); err != nil {
+		return err
+	}
+	offer, err := loadTradeOffer(ctx, uc, ac.Tmp.ResolvedIntent.OfferID)
+	if err != nil {
+		return err
+	}
+	if offer.FromAgentID != ac.In.AgentID || offer.Status != survival.TradeStatusOpen {
+		return ErrActionPreconditionFailed
+	}
+	ac.View.TradeOffer = &offer
+	resolveOfferedGoods(ac, offer)
+	return nil
+}
+
+// resolveOfferedGoods hands the escrowed items with their age and wear to
+// whoever receives them next.
+func resolveOfferedGoods(ac *ActionContext, offer ports.TradeOfferRecord) {
+	ac.Tmp.ResolvedIntent.Items = itemAmountsFromMap(offer.OfferItems)
+	ac.Tmp.ResolvedIntent.Stacks = offer.OfferStacks
+	ac.Tmp.ResolvedIntent.ToolStacks = offer.OfferTools
+}
+
+func (h tradeCancelActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	_, eventType := closedTradeStatus(*ac.View.TradeOffer, ac.In.NowAt)
+	return settleTradeAction(ctx, uc, ac, eventType)
+}
+
+func settleTradeAction(ctx context.Context, uc UseCase, ac *ActionContext, eventType string) (ExecuteMode, error) {
+	mode, err := settleViaDomainOrInstant(ctx, uc, ac, settleOptions{applyTrade: true})
+	if errors.Is(err, survival.ErrTradeItemsMissing) {
+		return mode, ErrActionPreconditionFailed
+	}
+	if err != nil {
+		return mode, err
+	}
+	ac.Plan.EventsToAppend = append(ac.Plan.EventsToAppend, tradeEvent(eventType, *ac.View.TradeOffer, ac.In.NowAt))
+	return mode, nil
+}
+
+func loadTradeOffer(ctx context.Context, uc UseCase, offerID string) (ports.TradeOfferRecord, error) {
+	if uc.TradeRepo == nil {
+		return ports.TradeOfferRecord{}, ErrActionPreconditionFailed
+	}
+	offer, err := uc.TradeRepo.GetByOfferID(ctx, strings.TrimSpace(offerID))
+	if errors.Is(err, ports.ErrNotFound) {
+		return ports.TradeOfferRecord{}, ErrActionPreconditionFailed
+	}
+	return offer, err
+}
+
+// closedTradeStatus tells a plain cancel apart from reclaiming the escrow of
+// an offer that expired unanswered.
+func closedTradeStatus(offer ports.TradeOfferRecord, now time.Time) (status, eventType string) {
+	if !now.Before(offer.ExpiresAt) {
+		return survival.TradeStatusExpired, "trade_expired"
+	}
+	return survival.TradeStatusCancelled, "trade_cancelled"
+}
+
+// persistTradeAction records the offer transition and mirrors the trade event
+// into the counterpart's stream. Accepting also settles the offerer's
+// aggregate, so both sides commit or roll back together.
+func persistTradeAction(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	offer := ac.View.TradeOffer
+	if offer == nil || uc.TradeRepo == nil {
+		return nil
+	}
+	now := ac.In.NowAt
+	var counterpartID, eventType string
+	switch ac.Tmp.ResolvedIntent.Type {
+	case survival.ActionTradeOffer:
+		if err := uc.TradeRepo.Create(ctx, *offer); err != nil {
+			return err
+		}
+		counterpartID, eventType = offer.ToAgentID, "trade_offered"
+	case survival.ActionTradeAccept:
+		if err := uc.TradeRepo.UpdateStatus(ctx, offer.OfferID, survival.TradeStatusOpen, survival.TradeStatusAccepted, now); err != nil {
+			return err
+		}
+		payment := survival.GoodsLeaving(ac.View.StateWorking, itemAmountsFromMap(offer.RequestItems), now)
+		if err := completeTradeForOfferer(ctx, uc, *offer, payment, now); err != nil {
+			return err
+		}
+		counterpartID, eventType = offer.FromAgentID, "trade_completed"
+	case survival.ActionTradeCancel:
+		var status string
+		status, eventType = closedTradeStatus(*offer, now)
+		if err := uc.TradeRepo.UpdateStatus(ctx, offer.OfferID, survival.TradeStatusOpen, status, now); err != nil {
+			return err
+		}
+		counterpartID = offer.ToAgentID
+	default:
+		return nil
+	}
+	return appendCounterpartTradeEvent(ctx, uc, counterpartID, tradeEvent(eventType, *offer, now))
+}
+
+func appendCounterpartTradeEvent(ctx context.Context, uc UseCase, counterpartID string, evt survival.DomainEvent) error {
+	counterpart, err := uc.StateRepo.GetByAgentID(ctx, counterpartID)
+	if err != nil {
+		return err
+	}
+	evt.Payload["agent_id"] = counterpartID
+	evt.Payload["session_id"] = counterpart.ActiveSessionID()
+	return uc.EventRepo.Append(ctx, counterpartID, []survival.DomainEvent{evt})
+}
+
+func completeTradeForOfferer(ctx context.Context, uc UseCase, offer ports.TradeOfferRecord, payment survival.TradeGoods, now time.Time) error {
+	state, err := uc.StateRepo.GetByAgentID(ctx, offer.FromAgentID)
+	if err != nil {
+		return err
+	}
+	expectedVersion := state.Version
+	survival.CompleteTradeOffer(&state, itemAmountsFromMap(offer.OfferItems), payment, now)
+	state.Version++
+	state.UpdatedAt = now
+	return uc.StateRepo.SaveWithVersion(ctx, state, expectedVersion)
+}
+
+// expireTradeOffers closes the agent's offers that expired unanswered and
+// returns their escrow, so items are not locked up until the agent cancels.
+// An explicit cancel of an expired offer is left to the cancel handler, and
+// dead agents keep their escrow until they respawn.
+func expireTradeOffers(ctx context.Context, u UseCase, state survival.AgentStateAggregate, intent survival.ActionIntent, now time.Time) (survival.AgentStateAggregate, error) {
+	if u.TradeRepo == nil || state.Dead {
+		return state, nil
+	}
+	offers, err := u.TradeRepo.ListExpired(ctx, state.AgentID, now)
+	if err != nil {
+		return state, err
+	}
+	next := state
+	var events []survival.DomainEvent
+	for _, offer := range offers {
+		if intent.Type == survival.ActionTradeCancel && strings.TrimSpace(intent.OfferID) == offer.OfferID {
+			continue
+		}
+		err := u.TradeRepo.UpdateStatus(ctx, offer.OfferID, survival.TradeStatusOpen, survival.TradeStatusExpired, now)
+		if errors.Is(err, ports.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return state, err
+		}
+		survival.ReleaseEscrow(&next, survival.TradeGoods{
+			Items:  itemAmountsFromMap(offer.OfferItems),
+			Stacks: offer.OfferStacks,
+			Tools:  offer.OfferTools,
+		}, now)
+		events = append(events, tradeEvent("trade_expired", offer, now))
+		if err := appendCounterpartTradeEvent(ctx, u, offer.ToAgentID, tradeEvent("trade_expired", offer, now)); err != nil {
+			return state, err
+		}
+	}
+	if len(events) == 0 {
+		return state, nil
+	}
+	next.Version++
+	next.UpdatedAt = now
+	if err := u.StateRepo.SaveWithVersion(ctx, next, state.Version); err != nil {
+		return state, err
+	}
+	sessionID := state.ActiveSessionID()
+	for i := range events {
+		events[i].Payload["agent_id"] = state.AgentID
+		events[i].Payload["session_id"] = sessionID
+	}
+	if err := u.EventRepo.Append(ctx, state.AgentID, events); err != nil {
+		return state, err
+	}
+	return next, nil
+}
+
+func tradeEvent(eventType string, offer ports.TradeOfferRecord, now time.Time) survival.DomainEvent {
+	return survival.DomainEvent{
+		Type:       eventType,
+		OccurredAt: now,
+		Payload: map[string]any{
+			"offer_id":      offer.OfferID,
+			"from_agent_id": offer.FromAgentID,
+			"to_agent_id":   offer.ToAgentID,
+			"offer_items":   offer.OfferItems,
+			"request_items": offer.RequestItems,
+			"expires_at":    offer.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+func itemAmountsFromMap(items map[string]int) []survival.ItemAmount {
+	keys := make([]string, 0, len(items))
+	for k, v := range items {
+		if v > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	out := make([]survival.ItemAmount, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, survival.ItemAmount{ItemType: k, Count: items[k]})
+	}
+	return out
+}
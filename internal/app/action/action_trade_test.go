@@ -0,0 +1,300 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+type stubTradeRepo struct {
+	byID map[string]ports.TradeOfferRecord
+}
+
+func (r *stubTradeRepo) Create(_ context.Context, offer ports.TradeOfferRecord) error {
+	if r.byID == nil {
+		r.byID = map[string]ports.TradeOfferRecord{}
+	}
+	r.byID[offer.OfferID] = offer
+	return nil
+}
+
+func (r *stubTradeRepo) GetByOfferID(_ context.Context, offerID string) (ports.TradeOfferRecord, error) {
+	offer, ok := r.byID[offerID]
+	if !ok {
+		return ports.TradeOfferRecord{}, ports.ErrNotFound
+	}
+	return offer, nil
+}
+
+func (r *stubTradeRepo) ListExpired(_ context.Context, fromAgentID string, now time.Time) ([]ports.TradeOfferRecord, error) {
+	var out []ports.TradeOfferRecord
+	for _, offer := range r.byID {
+		if offer.FromAgentID == fromAgentID && offer.Status == survival.TradeStatusOpen && !now.Before(offer.ExpiresAt) {
+			out = append(out, offer)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OfferID < out[j].OfferID })
+	return out, nil
+}
+
+func (r *stubTradeRepo) UpdateStatus(_ context.Context, offerID, fromStatus, toStatus string, _ time.Time) error {
+	offer, ok := r.byID[offerID]
+	if !ok || offer.Status != fromStatus {
+		return ports.ErrConflict
+	}
+	offer.Status = toStatus
+	r.byID[offerID] = offer
+	return nil
+}
+
+func newTradeUseCase(now *time.Time) (UseCase, *stubStateRepo, *stubEventRepo, *stubTradeRepo) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80}, Inventory: map[string]int{"wood": 5}, Version: 1},
+		"agent-2": {AgentID: "agent-2", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80}, Inventory: map[string]int{"stone": 3}, Version: 1},
+	}}
+	eventRepo := &stubEventRepo{}
+	tradeRepo := &stubTradeRepo{}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  eventRepo,
+		TradeRepo:  tradeRepo,
+		World:      worldmock.Provider{Snapshot: world.Snapshot{TimeOfDay: "day"}},
+		Settle:     survival.SettlementService{},
+		Now:        func() time.Time { return *now },
+	}
+	return uc, stateRepo, eventRepo, tradeRepo
+}
+
+func offerWoodForStone(t *testing.T, uc UseCase) string {
+	t.Helper()
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-offer",
+		Intent: survival.ActionIntent{
+			Type:         survival.ActionTradeOffer,
+			ToAgentID:    "agent-2",
+			Items:        []survival.ItemAmount{{ItemType: "wood", Count: 2}},
+			RequestItems: []survival.ItemAmount{{ItemType: "stone", Count: 1}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("offer error: %v", err)
+	}
+	if out.UpdatedState.Inventory["wood"] != 3 || out.UpdatedState.Escrow["wood"] != 2 {
+		t.Fatalf("expected 2 wood moved to escrow, got inventory=%v escrow=%v", out.UpdatedState.Inventory, out.UpdatedState.Escrow)
+	}
+	return "trade-agent-1-k-offer"
+}
+
+func TestUseCase_TradeOfferAcceptSwapsItemsForBothAgents(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, stateRepo, eventRepo, tradeRepo := newTradeUseCase(&now)
+	offerID := offerWoodForStone(t, uc)
+
+	now = now.Add(time.Minute)
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-2",
+		IdempotencyKey: "k-accept",
+		Intent:         survival.ActionIntent{Type: survival.ActionTradeAccept, OfferID: offerID},
+	})
+	if err != nil {
+		t.Fatalf("accept error: %v", err)
+	}
+	if out.UpdatedState.Inventory["wood"] != 2 || out.UpdatedState.Inventory["stone"] != 2 {
+		t.Fatalf("unexpected acceptor inventory: %v", out.UpdatedState.Inventory)
+	}
+	offerer := stateRepo.byAgent["agent-1"]
+	if offerer.Inventory["stone"] != 1 || offerer.Inventory["wood"] != 3 || len(offerer.Escrow) != 0 {
+		t.Fatalf("unexpected offerer state: inventory=%v escrow=%v", offerer.Inventory, offerer.Escrow)
+	}
+	if tradeRepo.byID[offerID].Status != survival.TradeStatusAccepted {
+		t.Fatalf("expected accepted offer, got %s", tradeRepo.byID[offerID].Status)
+	}
+	completedFor := map[any]bool{}
+	for _, evt := range eventRepo.events {
+		if evt.Type == "trade_completed" {
+			completedFor[evt.Payload["agent_id"]] = true
+		}
+	}
+	if !completedFor["agent-1"] || !completedFor["agent-2"] {
+		t.Fatalf("expected trade_completed in both streams, got %v", completedFor)
+	}
+
+	_, err = uc.Execute(context.Background(), Request{
+		AgentID:        "agent-2",
+		IdempotencyKey: "k-accept-again",
+		Intent:         survival.ActionIntent{Type: survival.ActionTradeAccept, OfferID: offerID},
+	})
+	if !errors.Is(err, ErrActionPreconditionFailed) {
+		t.Fatalf("expected closed offer to be rejected, got %v", err)
+	}
+}
+
+func TestUseCase_TradeAcceptRejectsWhenEitherSideIsFull(t *testing.T) {
+	for name, full := range map[string]string{"acceptor": "agent-2", "offerer": "agent-1"} {
+		now := time.Unix(1700000000, 0)
+		uc, stateRepo, _, tradeRepo := newTradeUseCase(&now)
+		offerID := offerWoodForStone(t, uc)
+		state := stateRepo.byAgent[full]
+		state.Inventory["berry"] = survival.InventoryCapacity(state) - inventoryUsed(state.Inventory)
+		stateRepo.byAgent[full] = state
+
+		now = now.Add(time.Minute)
+		_, err := uc.Execute(context.Background(), Request{
+			AgentID:        "agent-2",
+			IdempotencyKey: "k-accept-" + name,
+			Intent:         survival.ActionIntent{Type: survival.ActionTradeAccept, OfferID: offerID},
+		})
+		if !errors.Is(err, ErrInventoryFull) {
+			t.Fatalf("%s full: expected inventory full, got %v", name, err)
+		}
+		if tradeRepo.byID[offerID].Status != survival.TradeStatusOpen {
+			t.Fatalf("%s full: expected offer left open, got %s", name, tradeRepo.byID[offerID].Status)
+		}
+	}
+}
+
+func TestUseCase_TradeOfferExpiresAndEscrowReturnsOnCancel(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, _, _, tradeRepo := newTradeUseCase(&now)
+	offerID := offerWoodForStone(t, uc)
+
+	now = now.Add(time.Duration(survival.DefaultRuleSet().Trade.OfferTTLMinutes) * time.Minute)
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-2",
+		IdempotencyKey: "k-accept-late",
+		Intent:         survival.ActionIntent{Type: survival.ActionTradeAccept, OfferID: offerID},
+	})
+	if !errors.Is(err, ErrTradeOfferExpired) {
+		t.Fatalf("expected ErrTradeOfferExpired, got %v", err)
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-cancel",
+		Intent:         survival.ActionIntent{Type: survival.ActionTradeCancel, OfferID: offerID},
+	})
+	if err != nil {
+		t.Fatalf("cancel error: %v", err)
+	}
+	if out.UpdatedState.Inventory["wood"] != 5 || len(out.UpdatedState.Escrow) != 0 {
+		t.Fatalf("expected escrow returned, got inventory=%v escrow=%v", out.UpdatedState.Inventory, out.UpdatedState.Escrow)
+	}
+	if tradeRepo.byID[offerID].Status != survival.TradeStatusExpired {
+		t.Fatalf("expected expired offer, got %s", tradeRepo.byID[offerID].Status)
+	}
+}
+
+func TestUseCase_TradeOfferRequiresKnownRecipientAndItems(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, _, _, _ := newTradeUseCase(&now)
+	for _, tc := range []struct {
+		key    string
+		intent survival.ActionIntent
+	}{
+		{"k-unknown", survival.ActionIntent{Type: survival.ActionTradeOffer, ToAgentID: "agent-404", Items: []survival.ItemAmount{{ItemType: "wood", Count: 1}}}},
+		{"k-self", survival.ActionIntent{Type: survival.ActionTradeOffer, ToAgentID: "agent-1", Items: []survival.ItemAmount{{ItemType: "wood", Count: 1}}}},
+		{"k-short", survival.ActionIntent{Type: survival.ActionTradeOffer, ToAgentID: "agent-2", Items: []survival.ItemAmount{{ItemType: "wood", Count: 9}}}},
+	} {
+		_, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", IdempotencyKey: tc.key, Intent: tc.intent})
+		if !errors.Is(err, ErrActionPreconditionFailed) {
+			t.Fatalf("%s: expected ErrActionPreconditionFailed, got %v", tc.key, err)
+		}
+	}
+}
+
+func TestUseCase_ExpiredTradeOfferReturnsEscrowOnOfferersNextAction(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, stateRepo, eventRepo, tradeRepo := newTradeUseCase(&now)
+	offerID := offerWoodForStone(t, uc)
+
+	now = now.Add(time.Duration(survival.DefaultRuleSet().Trade.OfferTTLMinutes) * time.Minute)
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-offer-again",
+		Intent: survival.ActionIntent{
+			Type:      survival.ActionTradeOffer,
+			ToAgentID: "agent-2",
+			Items:     []survival.ItemAmount{{ItemType: "wood", Count: 1}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("offer error: %v", err)
+	}
+	if out.UpdatedState.Inventory["wood"] != 4 || out.UpdatedState.Escrow["wood"] != 1 {
+		t.Fatalf("expected expired escrow back before the new offer, got inventory=%v escrow=%v", out.UpdatedState.Inventory, out.UpdatedState.Escrow)
+	}
+	if stateRepo.byAgent["agent-1"].Inventory["wood"] != 4 {
+		t.Fatalf("expected refund persisted, got %v", stateRepo.byAgent["agent-1"].Inventory)
+	}
+	if tradeRepo.byID[offerID].Status != survival.TradeStatusExpired {
+		t.Fatalf("expected expired offer, got %s", tradeRepo.byID[offerID].Status)
+	}
+	expiredFor := map[any]bool{}
+	for _, evt := range eventRepo.events {
+		if evt.Type == "trade_expired" {
+			expiredFor[evt.Payload["agent_id"]] = true
+		}
+	}
+	if !expiredFor["agent-1"] || !expiredFor["agent-2"] {
+		t.Fatalf("expected trade_expired in both streams, got %v", expiredFor)
+	}
+}
+
+func TestUseCase_TradeKeepsFoodAgeAndToolWear(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, stateRepo, _, _ := newTradeUseCase(&now)
+	berriesAt := now.Add(-100 * time.Minute)
+	potatoesAt := now.Add(-200 * time.Minute)
+	offerer := stateRepo.byAgent["agent-1"]
+	offerer.Inventory = map[string]int{"berry": 2, "tool_axe": 1}
+	offerer.Freshness = map[string][]survival.FoodStack{"berry": {{Count: 2, AcquiredAt: berriesAt}}}
+	offerer.ToolStacks = map[string][]survival.ToolStack{"tool_axe": {{Count: 1, Durability: 5}}}
+	stateRepo.byAgent["agent-1"] = offerer
+	acceptor := stateRepo.byAgent["agent-2"]
+	acceptor.Inventory = map[string]int{"potato": 3}
+	acceptor.Freshness = map[string][]survival.FoodStack{"potato": {{Count: 3, AcquiredAt: potatoesAt}}}
+	stateRepo.byAgent["agent-2"] = acceptor
+
+	if _, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "k-offer",
+		Intent: survival.ActionIntent{
+			Type:         survival.ActionTradeOffer,
+			ToAgentID:    "agent-2",
+			Items:        []survival.ItemAmount{{ItemType: "berry", Count: 2}, {ItemType: "tool_axe", Count: 1}},
+			RequestItems: []survival.ItemAmount{{ItemType: "potato", Count: 1}},
+		},
+	}); err != nil {
+		t.Fatalf("offer error: %v", err)
+	}
+	now = now.Add(time.Minute)
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-2",
+		IdempotencyKey: "k-accept",
+		Intent:         survival.ActionIntent{Type: survival.ActionTradeAccept, OfferID: "trade-agent-1-k-offer"},
+	})
+	if err != nil {
+		t.Fatalf("accept error: %v", err)
+	}
+	berries := out.UpdatedState.Freshness["berry"]
+	if len(berries) != 1 || !berries[0].AcquiredAt.Equal(berriesAt) {
+		t.Fatalf("expected traded berries to keep their age, got %+v", berries)
+	}
+	if got := out.UpdatedState.ToolStacks["tool_axe"]; len(got) != 1 || got[0].Durability != 5 {
+		t.Fatalf("expected traded axe to keep its wear, got %+v", got)
+	}
+	potatoes := stateRepo.byAgent["agent-1"].Freshness["potato"]
+	if len(potatoes) != 1 || potatoes[0].Count != 1 || !potatoes[0].AcquiredAt.Equal(potatoesAt) {
+		t.Fatalf("expected paid potato to keep its age, got %+v", potatoes)
+	}
+}
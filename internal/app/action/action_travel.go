@@ -0,0 +1,138 @@
+package action
+
+import (
+	"context"
+	"time"
+
+	"clawvival/internal/app/shared/stateview"
+	"clawvival/internal/app/shared/structures"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+// travelSearchMargin widens the remembered area searched around the straight
+// line between origin and target so paths can detour around obstacles.
+const travelSearchMargin = 8
+
+type travelActionHandler struct{ BaseHandler }
+
+func validateTravelActionParams(intent survival.ActionIntent) bool {
+	return intent.Pos != nil
+}
+
+func (h travelActionHandler) Precheck(ctx context.Context, uc UseCase, ac *ActionContext) error {
+	return runStandardActionPrecheck(ctx, uc, ac)
+}
+
+func (h travelActionHandler) ExecuteActionAndPlan(ctx context.Context, uc UseCase, ac *ActionContext) (ExecuteMode, error) {
+	origin := ac.View.StateWorking.Position
+	target := *ac.Tmp.ResolvedIntent.Pos
+	path, err := planTravelPath(ctx, uc, ac, origin, target)
+	if err != nil {
+		return ExecuteModeContinue, err
+	}
+
+	next := ac.View.StateWorking
+	travelMinutes := len(path) * survival.TravelStepMinutes
+	next.OngoingAction = &survival.OngoingActionInfo{
+		Type:    survival.ActionTravel,
+		Minutes: travelMinutes,
+		EndAt:   ac.In.NowAt.Add(time.Duration(travelMinutes) * time.Minute),
+		Path:    path,
+	}
+	next.Version++
+	next.UpdatedAt = ac.In.NowAt
+	event := survival.DomainEvent{
+		Type:       "travel_started",
+		OccurredAt: ac.In.NowAt,
+		Payload: map[string]any{
+			"agent_id":                  ac.In.AgentID,
+			"session_id":                ac.In.SessionID,
+			"from":                      map[string]int{"x": origin.X, "y": origin.Y},
+			"to":                        map[string]int{"x": target.X, "y": target.Y},
+			"steps_total":               len(path),
+			"end_at":                    next.OngoingAction.EndAt,
+			"world_time_before_seconds": ac.View.Snapshot.WorldTimeSeconds,
+			"world_time_after_seconds":  ac.View.Snapshot.WorldTimeSeconds,
+		},
+	}
+	if ac.In.Req.StrategyHash != "" {
+		event.Payload["strategy_hash"] = ac.In.Req.StrategyHash
+	}
+	ac.Plan.StateToSave = &next
+	ac.Plan.StateVersion = ac.View.StateWorking.Version
+	ac.Plan.EventsToAppend = []survival.DomainEvent{event}
+	ac.Plan.ExecutionToSave = &portsActionExecutionRecord{
+		AgentID:        ac.In.AgentID,
+		IdempotencyKey: ac.In.IdempotencyKey,
+		IntentType:     string(ac.Tmp.ResolvedIntent.Type),
+		Result: actionResult{
+			UpdatedState: next,
+			Events:       []survival.DomainEvent{event},
+			ResultCode:   survival.ResultOK,
+		},
+		AppliedAt: ac.In.NowAt,
+	}
+	ac.Plan.ResultCode = survival.ResultOK
+	ac.Plan.ShouldPersist = true
+	ac.Tmp.Completed = true
+	ac.Tmp.Response = Response{
+		WorldTimeBeforeSeconds: ac.View.Snapshot.WorldTimeSeconds,
+		WorldTimeAfterSeconds:  ac.View.Snapshot.WorldTimeSeconds,
+		UpdatedState:           stateview.MarkTravelProgress(next, ac.In.NowAt),
+		Events:                 []survival.DomainEvent{event},
+		Settlement:             settlementSummary([]survival.DomainEvent{event}),
+		ResultCode:             survival.ResultOK,
+	}
+	return ExecuteModeCompleted, nil
+}
+
+// planTravelPath runs A* over the tiles the agent remembers this session plus
+// what it sees now. Unknown tiles are never entered.
+func planTravelPath(ctx context.Context, uc UseCase, ac *ActionContext, origin, target survival.Position) ([]survival.Position, error) {
+	targetPos := &survival.Position{X: target.X, Y: target.Y}
+	if origin == target || abs(target.X-origin.X)+abs(target.Y-origin.Y) > survival.MaxTravelSteps {
+		return nil, &ActionInvalidPositionError{TargetPos: targetPos}
+	}
+	minX, minY := min(origin.X, target.X)-travelSearchMargin, min(origin.Y, target.Y)-travelSearchMargin
+	maxX, maxY := max(origin.X, target.X)+travelSearchMargin, max(origin.Y, target.Y)+travelSearchMargin
+	tiles := []world.Tile{}
+	if uc.MapRepo != nil {
+		remembered, err := uc.MapRepo.ListInBounds(ctx, ac.In.AgentID, ac.In.SessionID, minX, minY, maxX, maxY)
+		if err != nil {
+			return nil, err
+		}
+		for _, tile := range remembered {
+			tiles = append(tiles, world.Tile{X: tile.X, Y: tile.Y, Kind: world.TileKind(tile.TerrainType), Passable: tile.IsWalkable})
+		}
+		// The view only loads objects around the agent; the path may run past them.
+		layout := ac.View.Structures
+		if uc.ObjectRepo != nil {
+			objects, err := uc.ObjectRepo.ListInBounds(ctx, ac.In.AgentID, minX, minY, maxX, maxY)
+			if err != nil {
+				return nil, err
+			}
+			layout = structures.Build(objects)
+		}
+		tiles = layout.ApplyPassability(tiles, ac.In.AgentID)
+	}
+	walkable := map[world.Point]bool{}
+	for _, tile := range tiles {
+		walkable[world.Point{X: tile.X, Y: tile.Y}] = tile.Passable
+	}
+	for _, tile := range ac.View.Snapshot.VisibleTiles {
+		walkable[world.Point{X: tile.X, Y: tile.Y}] = tile.Passable
+	}
+	if known, ok := walkable[world.Point{X: target.X, Y: target.Y}]; ok && !known {
+		return nil, &ActionInvalidPositionError{TargetPos: targetPos, BlockingTilePos: targetPos}
+	}
+	steps, ok := world.FindPath(world.Point{X: origin.X, Y: origin.Y}, world.Point{X: target.X, Y: target.Y}, walkable)
+	if !ok || len(steps) > survival.MaxTravelSteps {
+		return nil, &ActionInvalidPositionError{TargetPos: targetPos}
+	}
+	path := make([]survival.Position, 0, len(steps))
+	for _, step := range steps {
+		path = append(path, survival.Position{X: step.X, Y: step.Y})
+	}
+	return path, nil
+}
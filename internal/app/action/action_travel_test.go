@@ -0,0 +1,187 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func travelTestTiles() []world.Tile {
+	tiles := []world.Tile{}
+	for y := 0; y <= 2; y++ {
+		for x := 0; x <= 2; x++ {
+			tiles = append(tiles, world.Tile{X: x, Y: y, Passable: x != 1 || y == 2})
+		}
+	}
+	return tiles
+}
+
+func TestUseCase_TravelPlansPathAroundObstaclesAndTerminateStopsMidway(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
+			Position:  survival.Position{X: 0, Y: 0},
+			Inventory: map[string]int{},
+			Version:   1,
+		},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			WorldTimeSeconds: 3600,
+			TimeOfDay:        "day",
+			ThreatLevel:      1,
+			VisibleTiles:     travelTestTiles(),
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return now },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "travel-start",
+		Intent:         survival.ActionIntent{Type: survival.ActionTravel, Pos: &survival.Position{X: 2, Y: 0}},
+	})
+	if err != nil {
+		t.Fatalf("start travel: %v", err)
+	}
+	ongoing := out.UpdatedState.OngoingAction
+	if ongoing == nil || ongoing.Type != survival.ActionTravel {
+		t.Fatalf("expected ongoing travel, got=%+v", ongoing)
+	}
+	if got, want := len(ongoing.Path), 6; got != want {
+		t.Fatalf("expected detour path of %d steps, got=%d (%+v)", want, got, ongoing.Path)
+	}
+	if ongoing.Path[len(ongoing.Path)-1] != (survival.Position{X: 2, Y: 0}) {
+		t.Fatalf("expected path to end at target, got=%+v", ongoing.Path)
+	}
+	for _, step := range ongoing.Path {
+		if step.X == 1 && step.Y != 2 {
+			t.Fatalf("expected path to avoid blocked tiles, got=%+v", ongoing.Path)
+		}
+	}
+
+	now = now.Add(3 * time.Minute)
+	out, err = uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "travel-terminate",
+		Intent:         survival.ActionIntent{Type: survival.ActionTerminate},
+	})
+	if err != nil {
+		t.Fatalf("terminate travel: %v", err)
+	}
+	if out.UpdatedState.OngoingAction != nil {
+		t.Fatalf("expected travel cleared by terminate")
+	}
+	if got, want := out.UpdatedState.Position, (survival.Position{X: 1, Y: 2}); got != want {
+		t.Fatalf("expected agent at third path step %+v, got=%+v", want, got)
+	}
+	foundEnded := false
+	for _, evt := range out.Events {
+		if evt.Type == "ongoing_action_ended" {
+			foundEnded = true
+			if got := evt.Payload["steps_done"]; got != 3 {
+				t.Fatalf("expected steps_done=3, got=%v", got)
+			}
+		}
+	}
+	if !foundEnded {
+		t.Fatalf("expected ongoing_action_ended event")
+	}
+}
+
+func TestUseCase_TravelRejectsUnknownDestination(t *testing.T) {
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Version: 1},
+	}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay:    "day",
+			ThreatLevel:  1,
+			VisibleTiles: travelTestTiles(),
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "travel-unknown",
+		Intent:         survival.ActionIntent{Type: survival.ActionTravel, Pos: &survival.Position{X: 9, Y: 9}},
+	})
+	var posErr *ActionInvalidPositionError
+	if !errors.As(err, &posErr) {
+		t.Fatalf("expected invalid position error, got %v", err)
+	}
+}
+
+func TestUseCase_TravelStopsAtWallBuiltAfterPlanning(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stateRepo := &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+		"agent-1": {
+			AgentID:   "agent-1",
+			Vitals:    survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
+			Inventory: map[string]int{},
+			Version:   1,
+		},
+	}}
+	objectRepo := &stubObjectRepo{byID: map[string]ports.WorldObjectRecord{}}
+	uc := UseCase{
+		TxManager:  stubTxManager{},
+		StateRepo:  stateRepo,
+		ActionRepo: &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}},
+		EventRepo:  &stubEventRepo{},
+		ObjectRepo: objectRepo,
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			TimeOfDay:    "day",
+			VisibleTiles: travelTestTiles(),
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return now },
+	}
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "travel-start",
+		Intent:         survival.ActionIntent{Type: survival.ActionTravel, Pos: &survival.Position{X: 2, Y: 0}},
+	})
+	if err != nil {
+		t.Fatalf("start travel: %v", err)
+	}
+	path := out.UpdatedState.OngoingAction.Path
+	blocked := path[4]
+	objectRepo.byID["wall-late"] = ports.WorldObjectRecord{ObjectID: "wall-late", ObjectType: "wall", X: blocked.X, Y: blocked.Y, HP: 100}
+
+	now = now.Add(time.Duration(len(path)*survival.TravelStepMinutes) * time.Minute)
+	out, err = uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "travel-end",
+		Intent:         survival.ActionIntent{Type: survival.ActionTerminate},
+	})
+	if err != nil {
+		t.Fatalf("end travel: %v", err)
+	}
+	if got := out.UpdatedState.Position; got != path[3] {
+		t.Fatalf("expected agent stopped before the new wall at %+v, got=%+v", path[3], got)
+	}
+	for _, evt := range out.Events {
+		if evt.Type == "ongoing_action_ended" && evt.Payload["steps_done"] != 4 {
+			t.Fatalf("expected steps_done=4, got=%v", evt.Payload["steps_done"])
+		}
+	}
+}
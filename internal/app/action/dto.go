@@ -7,6 +7,9 @@ type Request struct {
 	IdempotencyKey string
 	Intent         survival.ActionIntent
 	StrategyHash   string
+	// DryRun evaluates the action against current state without persisting
+	// anything or consuming the idempotency key.
+	DryRun bool
 }
 
 type Response struct {
@@ -16,4 +19,52 @@ type Response struct {
 	Events                 []survival.DomainEvent       `json:"events"`
 	Settlement             map[string]any               `json:"settlement,omitempty"`
 	ResultCode             survival.ResultCode          `json:"result_code"`
+	DryRun                 bool                         `json:"dry_run,omitempty"`
+	CooldownSeconds        int                          `json:"cooldown_seconds,omitempty"`
 }
+
+type PlanRequest struct {
+	AgentID        string
+	IdempotencyKey string
+	Steps          []survival.ActionIntent
+	StrategyHash   string
+	// Atomic runs every step in one transaction; any rejection rolls the
+	// whole plan back.
+	Atomic              bool
+	ContinueOnRejection bool
+	StopWhen            PlanStopConditions
+}
+
+// PlanStopConditions end a plan early once a settled step leaves a vital
+// below the given value. Zero disables a condition.
+type PlanStopConditions struct {
+	HPBelow     int
+	HungerBelow int
+	EnergyBelow int
+	ThirstBelow int
+}
+
+type PlanResponse struct {
+	Steps      []PlanStepResult
+	StopReason PlanStopReason
+}
+
+type PlanStepResult struct {
+	Index    int
+	Intent   survival.ActionType
+	Response *Response
+	Err      error
+}
+
+type PlanStopReason string
+
+const (
+	PlanStopCompleted      PlanStopReason = "completed"
+	PlanStopRejected       PlanStopReason = "rejected"
+	PlanStopOngoingStarted PlanStopReason = "ongoing_action_started"
+	PlanStopGameOver       PlanStopReason = "game_over"
+	PlanStopHPBelow        PlanStopReason = "hp_below"
+	PlanStopHungerBelow    PlanStopReason = "hunger_below"
+	PlanStopEnergyBelow    PlanStopReason = "energy_below"
+	PlanStopThirstBelow    PlanStopReason = "thirst_below"
+)
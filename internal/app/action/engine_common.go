@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/lighting"
 	"clawvival/internal/app/shared/stateview"
+	"clawvival/internal/app/shared/structures"
+	"clawvival/internal/app/shared/threats"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -32,7 +35,7 @@ type ongoingFinalizeResult struct {
 }
 
 func isInterruptibleOngoingActionType(t survival.ActionType) bool {
-	return t == survival.ActionRest || t == survival.ActionSleep
+	return t == survival.ActionRest || t == survival.ActionSleep || t == survival.ActionTravel
 }
 
 func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state survival.AgentStateAggregate, nowAt time.Time, forceTerminate bool) (ongoingFinalizeResult, error) {
@@ -46,6 +49,11 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 	if nowAt.Before(ongoing.EndAt) && !forceTerminate {
 		return ongoingFinalizeResult{}, nil
 	}
+	state, _, err := stateview.AdvanceTravel(ctx, u.ObjectRepo, agentID, state, nowAt)
+	if err != nil {
+		return ongoingFinalizeResult{}, err
+	}
+	ongoing = state.OngoingAction
 	startAt := ongoing.EndAt.Add(-time.Duration(ongoing.Minutes) * time.Minute)
 	deltaMinutes := int(nowAt.Sub(startAt).Minutes())
 	if deltaMinutes < 0 {
@@ -59,14 +67,17 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 	if err != nil {
 		return ongoingFinalizeResult{}, err
 	}
+	objects, err := loadWorldObjects(ctx, u.ObjectRepo, agentID, snapshot)
+	if err != nil {
+		return ongoingFinalizeResult{}, err
+	}
+	layout := structures.Build(objects)
+	lit := lighting.Compute(snapshot.TimeOfDay, objects, nowAt)
+	sheltered := layout.IsSheltered(state.Position.X, state.Position.Y)
 
 	var result survival.SettlementResult
 	if deltaMinutes > 0 {
-		intent := survival.ActionIntent{Type: ongoing.Type}
-		if ongoing.Type == survival.ActionSleep {
-			intent.BedID = ongoing.BedID
-			intent.BedQuality = ongoing.Quality
-		}
+		intent := ongoing.SettleIntent(deltaMinutes)
 		worldTimeBefore := snapshot.WorldTimeSeconds - int64(deltaMinutes*60)
 		if worldTimeBefore < 0 {
 			worldTimeBefore = 0
@@ -78,10 +89,17 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 			nowAt,
 			survival.WorldSnapshot{
 				TimeOfDay:         snapshot.TimeOfDay,
-				ThreatLevel:       snapshot.ThreatLevel,
+				ThreatLevel:       structures.ReduceThreat(snapshot.ThreatLevel, sheltered),
 				VisibilityPenalty: snapshot.VisibilityPenalty,
 				NearbyResource:    snapshot.NearbyResource,
+				Threats:           threats.Contacts(snapshot.Creatures),
+				Sheltered:         sheltered,
+				Season:            snapshot.Season,
+				Weather:           snapshot.Weather,
+				Biome:             stateview.CurrentBiomeAtPosition(state.Position, snapshot.VisibleTiles),
+				NearHeat:          lit.IsWarm(state.Position.X, state.Position.Y),
 				WorldTimeSeconds:  worldTimeBefore,
+				Blocked:           stateview.BlockedTiles(layout.ApplyPassability(snapshot.VisibleTiles, agentID)),
 			},
 		)
 		if err != nil {
@@ -96,9 +114,12 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 	}
 	result.UpdatedState.OngoingAction = nil
 	result.UpdatedState.UpdatedAt = nowAt
-	result.UpdatedState = stateview.Enrich(result.UpdatedState, snapshot.TimeOfDay, isCurrentTileLit(snapshot.TimeOfDay))
+	afterPos := result.UpdatedState.Position
+	result.UpdatedState = stateview.Enrich(result.UpdatedState, snapshot.TimeOfDay, lit.IsLit(afterPos.X, afterPos.Y))
+	result.UpdatedState = stateview.MarkSheltered(result.UpdatedState, layout.IsSheltered(afterPos.X, afterPos.Y))
+	result.UpdatedState = stateview.MarkTemperature(u.Settle.RuleSet(), result.UpdatedState, snapshot, lit.IsWarm(afterPos.X, afterPos.Y), layout.IsSheltered(afterPos.X, afterPos.Y))
 
-	sessionID := "session-" + agentID
+	sessionID := state.ActiveSessionID()
 	for i := range result.Events {
 		if result.Events[i].Payload == nil {
 			result.Events[i].Payload = map[string]any{}
@@ -106,7 +127,7 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 		result.Events[i].Payload["agent_id"] = agentID
 		result.Events[i].Payload["session_id"] = sessionID
 	}
-	result.Events = append(result.Events, survival.DomainEvent{
+	ended := survival.DomainEvent{
 		Type:       "ongoing_action_ended",
 		OccurredAt: nowAt,
 		Payload: map[string]any{
@@ -116,7 +137,13 @@ func finalizeOngoingAction(ctx context.Context, u UseCase, agentID string, state
 			"planned_minutes": ongoing.Minutes,
 			"forced":          forceTerminate,
 		},
-	})
+	}
+	if ongoing.Type == survival.ActionTravel {
+		progress := ongoing.WithTravelProgress(state.Position, nowAt)
+		ended.Payload["steps_done"] = progress.StepsDone
+		ended.Payload["steps_total"] = len(ongoing.Path)
+	}
+	result.Events = append(result.Events, ended)
 	if err := u.StateRepo.SaveWithVersion(ctx, result.UpdatedState, state.Version); err != nil {
 		return ongoingFinalizeResult{}, err
 	}
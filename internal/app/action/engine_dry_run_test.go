@@ -0,0 +1,116 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+// rollbackTxManager discards state and event writes when fn fails, as the
+// database transaction does.
+type rollbackTxManager struct {
+	states *stubStateRepo
+	events *stubEventRepo
+}
+
+func (m rollbackTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	states := maps.Clone(m.states.byAgent)
+	events := slices.Clone(m.events.events)
+	if err := fn(ctx); err != nil {
+		m.states.byAgent = states
+		m.events.events = events
+		return err
+	}
+	return nil
+}
+
+func TestUseCase_DryRunReturnsSettlementWithoutPersisting(t *testing.T) {
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	uc := newPlanTestUseCase(actionRepo)
+	eventRepo := &stubEventRepo{}
+	uc.EventRepo = eventRepo
+	stateRepo := uc.StateRepo.(*stubStateRepo)
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID: "agent-1",
+		Intent:  survival.ActionIntent{Type: survival.ActionMove, Direction: "E"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("dry run move: %v", err)
+	}
+	if !out.DryRun || out.UpdatedState.Position.X != 1 {
+		t.Fatalf("expected simulated move to x=1, got dry_run=%v pos=%+v", out.DryRun, out.UpdatedState.Position)
+	}
+	if out.Settlement == nil || out.Settlement["vitals_delta"] == nil {
+		t.Fatalf("expected would-be settlement with vitals delta, got=%v", out.Settlement)
+	}
+	moveCooldown, _ := survival.DefaultRuleSet().Cooldown(survival.ActionMove)
+	if got, want := out.CooldownSeconds, int(moveCooldown.Seconds()); got != want {
+		t.Fatalf("expected cooldown_seconds=%d, got=%d", want, got)
+	}
+	if saved := stateRepo.byAgent["agent-1"]; saved.Position.X != 0 || saved.Version != 1 {
+		t.Fatalf("expected stored state untouched, got pos=%+v version=%d", saved.Position, saved.Version)
+	}
+	if len(actionRepo.byKey) != 0 || len(eventRepo.events) != 0 {
+		t.Fatalf("expected no execution or events persisted")
+	}
+
+	if _, err := uc.Execute(context.Background(), Request{
+		AgentID:        "agent-1",
+		IdempotencyKey: "move-after-dry-run",
+		Intent:         survival.ActionIntent{Type: survival.ActionMove, Direction: "E"},
+	}); err != nil {
+		t.Fatalf("expected dry run to leave no cooldown behind, got %v", err)
+	}
+}
+
+func TestUseCase_DryRunReportsRejection(t *testing.T) {
+	uc := newPlanTestUseCase(&stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}})
+
+	_, err := uc.Execute(context.Background(), Request{
+		AgentID: "agent-1",
+		Intent:  survival.ActionIntent{Type: survival.ActionMove, Direction: "W"},
+		DryRun:  true,
+	})
+	if !errors.Is(err, ErrActionInvalidPosition) {
+		t.Fatalf("expected invalid position rejection, got %v", err)
+	}
+}
+
+func TestUseCase_DryRunRollsBackFinalizedOngoingAction(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc := newPlanTestUseCase(&stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}})
+	stateRepo := uc.StateRepo.(*stubStateRepo)
+	eventRepo := uc.EventRepo.(*stubEventRepo)
+	uc.TxManager = rollbackTxManager{states: stateRepo, events: eventRepo}
+	uc.Now = func() time.Time { return now }
+	resting := stateRepo.byAgent["agent-1"]
+	resting.OngoingAction = &survival.OngoingActionInfo{Type: survival.ActionRest, Minutes: 60, EndAt: now.Add(-time.Minute)}
+	stateRepo.byAgent["agent-1"] = resting
+
+	out, err := uc.Execute(context.Background(), Request{
+		AgentID: "agent-1",
+		Intent:  survival.ActionIntent{Type: survival.ActionMove, Direction: "E"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("dry run move: %v", err)
+	}
+	if out.UpdatedState.OngoingAction != nil || out.UpdatedState.Position.X != 1 {
+		t.Fatalf("expected simulated move after the rest settles, got %+v", out.UpdatedState)
+	}
+	saved := stateRepo.byAgent["agent-1"]
+	if saved.OngoingAction == nil || saved.Version != 1 || saved.Position.X != 0 {
+		t.Fatalf("expected the finished rest rolled back with the dry run, got %+v", saved)
+	}
+	if len(eventRepo.events) != 0 {
+		t.Fatalf("expected no events kept, got %+v", eventRepo.events)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/lighting"
+	"clawvival/internal/app/shared/objectwear"
+	"clawvival/internal/app/shared/structures"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -15,10 +18,10 @@ func (u UseCase) ValidateRequest(req Request) (ActionContext, error) {
 	req.IdempotencyKey = normalizeIdempotencyKey(req.IdempotencyKey)
 	req.Intent = normalizeValidatedIntent(req.Intent)
 
-	if req.AgentID == "" || req.IdempotencyKey == "" || !isSupportedActionType(req.Intent.Type) {
+	if req.AgentID == "" || (req.IdempotencyKey == "" && !req.DryRun) || !isSupportedActionType(req.Intent.Type) {
 		return ActionContext{}, ErrInvalidRequest
 	}
-	if !hasValidActionParams(req.Intent) {
+	if !hasValidActionParams(u.Settle.RuleSet(), req.Intent) {
 		return ActionContext{}, ErrInvalidActionParams
 	}
 
@@ -27,7 +30,6 @@ func (u UseCase) ValidateRequest(req Request) (ActionContext, error) {
 			Req:            req,
 			AgentID:        req.AgentID,
 			IdempotencyKey: req.IdempotencyKey,
-			SessionID:      "session-" + req.AgentID,
 		},
 		Tmp: ActionTmp{ResolvedIntent: req.Intent},
 	}, nil
@@ -39,7 +41,8 @@ func (u UseCase) ReplayIdempotent(ctx context.Context, ac *ActionContext) (Respo
 		before, after := worldTimeWindowFromExecution(exec)
 		updatedState := exec.Result.UpdatedState
 		if strings.TrimSpace(updatedState.SessionID) == "" {
-			updatedState.SessionID = ac.In.SessionID
+			updatedState.AgentID = ac.In.AgentID
+			updatedState.SessionID = updatedState.ActiveSessionID()
 		}
 		return Response{
 			WorldTimeBeforeSeconds: before,
@@ -61,8 +64,13 @@ func (u UseCase) LoadStateAndFinalizeOngoing(ctx context.Context, ac *ActionCont
 	if err != nil {
 		return err
 	}
+	ac.In.SessionID = state.ActiveSessionID()
 	state.SessionID = ac.In.SessionID
 	ac.View.StateBefore = state
+	state, err = expireTradeOffers(ctx, u, state, ac.Tmp.ResolvedIntent, ac.In.NowAt)
+	if err != nil {
+		return err
+	}
 	ac.View.StateWorking = state
 
 	finalized, err := finalizeOngoingAction(ctx, u, ac.In.AgentID, state, ac.In.NowAt, ac.Tmp.ResolvedIntent.Type == survival.ActionTerminate)
@@ -100,22 +108,61 @@ func (u UseCase) BuildContext(ctx context.Context, ac *ActionContext) error {
 	if err != nil {
 		return err
 	}
+	objects, err := loadWorldObjects(ctx, u.ObjectRepo, ac.In.AgentID, snapshot)
+	if err != nil {
+		return err
+	}
+	ac.View.Wear = objectwear.Apply(objects, snapshot, ac.In.NowAt, fixedStandardActionDeltaMinutes())
+	objects = ac.View.Wear.Live
+	ac.View.Lighting = lighting.Compute(snapshot.TimeOfDay, objects, ac.In.NowAt)
+	ac.View.Structures = structures.Build(objects)
+	snapshot.VisibleTiles = ac.View.Structures.ApplyPassability(snapshot.VisibleTiles, ac.In.AgentID)
 	ac.View.Snapshot = snapshot
 
-	preparedObj, err := prepareObjectAction(ctx, ac.In.NowAt, ac.View.StateWorking, ac.Tmp.ResolvedIntent, u.ObjectRepo, ac.In.AgentID)
+	preparedObj, err := prepareObjectAction(ctx, ac.In.NowAt, u.Settle.RuleSet(), ac.View.StateWorking, ac.Tmp.ResolvedIntent, u.ObjectRepo, ac.In.AgentID)
 	if err != nil {
 		return err
 	}
+	if preparedObj != nil {
+		worn, alive, found := ac.View.Wear.Find(preparedObj.record.ObjectID)
+		if found && !alive {
+			return ErrActionPreconditionFailed
+		}
+		if found {
+			preparedObj.record = worn
+		}
+	}
 	ac.View.PreparedObj = preparedObj
 	if ac.Tmp.ResolvedIntent.Type == survival.ActionSleep && preparedObj != nil {
 		ac.Tmp.ResolvedIntent.BedQuality = preparedObj.record.Quality
 	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionFarmPlant && preparedObj != nil {
+		crop, _ := survival.ParseCrop(ac.Tmp.ResolvedIntent.ItemType)
+		preparedObj.growMinutes = u.Settle.RuleSet().CropGrowMinutes(crop, snapshot.Season, snapshot.Weather)
+	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionRepair && preparedObj != nil {
+		ac.Tmp.ResolvedIntent.ObjectType = objectwear.TypeKey(preparedObj.record)
+	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionDeconstruct && preparedObj != nil {
+		ac.Tmp.ResolvedIntent.ObjectType = objectwear.TypeKey(preparedObj.record)
+		ac.Tmp.ResolvedIntent.ReturnSeed = preparedObj.returnSeed
+	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionContainerWithdraw && preparedObj != nil {
+		ac.Tmp.ResolvedIntent.Stacks = preparedObj.stacks
+		ac.Tmp.ResolvedIntent.ToolStacks = preparedObj.tools
+	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionFurnaceCollect && preparedObj != nil {
+		ac.Tmp.ResolvedIntent.Items = preparedObj.furnace.OutputItems()
+	}
+	if ac.Tmp.ResolvedIntent.Type == survival.ActionFarmHarvest && preparedObj != nil {
+		ac.Tmp.ResolvedIntent.ItemType = string(preparedObj.farm.CropType())
+	}
 
 	resolvedMoveIntent, moveErr := resolveMoveIntent(ac.View.StateWorking, ac.Tmp.ResolvedIntent, snapshot)
 	if moveErr != nil {
 		return moveErr
 	}
-	ac.Tmp.ResolvedIntent = resolveRetreatIntent(resolvedMoveIntent, ac.View.StateWorking.Position, snapshot.VisibleTiles)
+	ac.Tmp.ResolvedIntent = resolveRetreatIntent(resolvedMoveIntent, ac.View.StateWorking.Position, snapshot)
 
 	if ac.Tmp.ResolvedIntent.Type != survival.ActionRest {
 		eventsBeforeAction, err := listRecentEvents(ctx, u.EventRepo, ac.In.AgentID)
@@ -158,6 +205,10 @@ func (u UseCase) PersistAndRespond(ctx context.Context, ac *ActionContext) error
 		}
 	}
 
+	if err := persistObjectWear(ctx, u.ObjectRepo, ac); err != nil {
+		return err
+	}
+
 	for i := range ac.Plan.EventsToAppend {
 		if ac.Plan.EventsToAppend[i].Payload == nil {
 			ac.Plan.EventsToAppend[i].Payload = map[string]any{}
@@ -180,7 +231,19 @@ func (u UseCase) PersistAndRespond(ctx context.Context, ac *ActionContext) error
 	}
 
 	if ac.Plan.ApplyObjectAction {
-		if err := persistObjectAction(ctx, ac.In.NowAt, ac.Tmp.ResolvedIntent, ac.View.PreparedObj, u.ObjectRepo, ac.In.AgentID); err != nil {
+		if err := persistObjectAction(ctx, ac.In.NowAt, u.Settle.RuleSet(), ac.Tmp.ResolvedIntent, ac.View.PreparedObj, u.ObjectRepo, ac.In.AgentID); err != nil {
+			return err
+		}
+	}
+
+	if ac.Plan.ApplyCombat {
+		if err := persistCombatOutcome(ctx, u, ac); err != nil {
+			return err
+		}
+	}
+
+	if ac.Plan.ApplyTrade {
+		if err := persistTradeAction(ctx, u, ac); err != nil {
 			return err
 		}
 	}
@@ -217,6 +280,11 @@ func (u UseCase) PersistAndRespond(ctx context.Context, ac *ActionContext) error
 	}
 	attachBuiltObjectIDs(ac.Plan.EventsToAppend, builtObjectIDs)
 
+	if ac.Plan.OpenSession && u.SessionRepo != nil && ac.Plan.StateToSave != nil {
+		if err := u.SessionRepo.EnsureActive(ctx, ac.In.SessionID, ac.In.AgentID, ac.Plan.StateToSave.Version); err != nil {
+			return err
+		}
+	}
 	if ac.Plan.CloseSession && u.SessionRepo != nil {
 		if err := u.SessionRepo.Close(ctx, ac.In.SessionID, ac.Plan.CloseSessionCause, ac.In.NowAt); err != nil {
 			return err
@@ -255,8 +323,8 @@ func normalizeValidatedIntent(in survival.ActionIntent) survival.ActionIntent {
 	return normalizeIntent(out)
 }
 
-func hasValidActionParams(intent survival.ActionIntent) bool {
-	validator, ok := actionParamValidators()[intent.Type]
+func hasValidActionParams(rules *survival.RuleSet, intent survival.ActionIntent) bool {
+	validator, ok := actionParamValidators(rules)[intent.Type]
 	if !ok {
 		return true
 	}
@@ -0,0 +1,143 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"clawvival/internal/domain/survival"
+)
+
+const MaxPlanSteps = 20
+
+// PlanStepError reports which step of an atomic plan aborted it.
+type PlanStepError struct {
+	Index int
+	Err   error
+}
+
+func (e *PlanStepError) Error() string {
+	return fmt.Sprintf("plan step %d: %v", e.Index, e.Err)
+}
+
+func (e *PlanStepError) Unwrap() error {
+	return e.Err
+}
+
+// ExecutePlan runs the steps through the same pipeline as Execute. Each step
+// is stored under "<idempotency_key>#<index>" so resubmitting a plan replays
+// the steps that already ran. Steps run on a plan clock that starts now and
+// advances by each settled step's world time, so cooldowns are measured
+// between steps as if the agent had sent them one after another; a rejected
+// step takes no time.
+func (u UseCase) ExecutePlan(ctx context.Context, req PlanRequest) (PlanResponse, error) {
+	if normalizeIdempotencyKey(req.IdempotencyKey) == "" || len(req.Steps) == 0 || len(req.Steps) > MaxPlanSteps {
+		return PlanResponse{}, ErrInvalidRequest
+	}
+	steps := make([]ActionContext, 0, len(req.Steps))
+	for i, intent := range req.Steps {
+		ac, err := u.ValidateRequest(Request{
+			AgentID:        req.AgentID,
+			IdempotencyKey: planStepKey(req.IdempotencyKey, i),
+			Intent:         intent,
+			StrategyHash:   req.StrategyHash,
+		})
+		if err != nil {
+			return PlanResponse{}, &PlanStepError{Index: i, Err: err}
+		}
+		steps = append(steps, ac)
+	}
+	if req.Atomic {
+		return u.executeAtomicPlan(ctx, req, steps)
+	}
+
+	out := PlanResponse{Steps: make([]PlanStepResult, 0, len(steps)), StopReason: PlanStopCompleted}
+	clock := u.now()
+	for i := range steps {
+		steps[i].In.ClockAt = clock
+		result := PlanStepResult{Index: i, Intent: steps[i].Tmp.ResolvedIntent.Type}
+		var resp Response
+		err := u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
+			var err error
+			resp, err = u.runPipeline(txCtx, &steps[i])
+			return err
+		})
+		if err != nil {
+			u.recordFailure(err)
+			result.Err = err
+			out.Steps = append(out.Steps, result)
+			if req.ContinueOnRejection {
+				continue
+			}
+			out.StopReason = PlanStopRejected
+			return out, nil
+		}
+		u.recordSuccess(resp.ResultCode)
+		clock = clock.Add(settledDuration(resp))
+		result.Response = &resp
+		out.Steps = append(out.Steps, result)
+		if reason, stop := planStopReason(resp, req.StopWhen); stop {
+			out.StopReason = reason
+			return out, nil
+		}
+	}
+	return out, nil
+}
+
+func (u UseCase) executeAtomicPlan(ctx context.Context, req PlanRequest, steps []ActionContext) (PlanResponse, error) {
+	var out PlanResponse
+	err := u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
+		out = PlanResponse{Steps: make([]PlanStepResult, 0, len(steps)), StopReason: PlanStopCompleted}
+		clock := u.now()
+		for i := range steps {
+			steps[i].In.ClockAt = clock
+			resp, err := u.runPipeline(txCtx, &steps[i])
+			if err != nil {
+				return &PlanStepError{Index: i, Err: err}
+			}
+			clock = clock.Add(settledDuration(resp))
+			out.Steps = append(out.Steps, PlanStepResult{Index: i, Intent: steps[i].Tmp.ResolvedIntent.Type, Response: &resp})
+			if reason, stop := planStopReason(resp, req.StopWhen); stop {
+				out.StopReason = reason
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		u.recordFailure(err)
+		return PlanResponse{}, err
+	}
+	for _, step := range out.Steps {
+		u.recordSuccess(step.Response.ResultCode)
+	}
+	return out, nil
+}
+
+func settledDuration(resp Response) time.Duration {
+	return time.Duration(max(resp.WorldTimeAfterSeconds-resp.WorldTimeBeforeSeconds, 0)) * time.Second
+}
+
+func planStepKey(key string, index int) string {
+	return fmt.Sprintf("%s#%d", normalizeIdempotencyKey(key), index)
+}
+
+func planStopReason(resp Response, cond PlanStopConditions) (PlanStopReason, bool) {
+	vitals := resp.UpdatedState.Vitals
+	switch {
+	case resp.ResultCode == survival.ResultGameOver:
+		return PlanStopGameOver, true
+	case resp.UpdatedState.OngoingAction != nil:
+		return PlanStopOngoingStarted, true
+	case cond.HPBelow > 0 && vitals.HP < cond.HPBelow:
+		return PlanStopHPBelow, true
+	case cond.HungerBelow > 0 && vitals.Hunger < cond.HungerBelow:
+		return PlanStopHungerBelow, true
+	case cond.EnergyBelow > 0 && vitals.Energy < cond.EnergyBelow:
+		return PlanStopEnergyBelow, true
+	case cond.ThirstBelow > 0 && vitals.Thirst < cond.ThirstBelow:
+		return PlanStopThirstBelow, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,155 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	worldmock "clawvival/internal/adapter/world/mock"
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func newPlanTestUseCase(actionRepo *stubActionRepo) UseCase {
+	return UseCase{
+		TxManager: stubTxManager{},
+		StateRepo: &stubStateRepo{byAgent: map[string]survival.AgentStateAggregate{
+			"agent-1": {AgentID: "agent-1", Vitals: survival.Vitals{HP: 100, Hunger: 80, Energy: 60}, Position: survival.Position{X: 0, Y: 0}, Inventory: map[string]int{}, Version: 1},
+		}},
+		ActionRepo: actionRepo,
+		EventRepo:  &stubEventRepo{},
+		World: worldmock.Provider{Snapshot: world.Snapshot{
+			WorldTimeSeconds: 3600,
+			TimeOfDay:        "day",
+			ThreatLevel:      1,
+			VisibleTiles:     []world.Tile{{X: 0, Y: 0, Passable: true}, {X: 1, Y: 0, Passable: true}, {X: 2, Y: 0, Passable: true}},
+		}},
+		Settle: survival.SettlementService{},
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+}
+
+func TestUseCase_ExecutePlanAdvancesClockPastCooldownsAndReplaysUnderSameKey(t *testing.T) {
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	uc := newPlanTestUseCase(actionRepo)
+	req := PlanRequest{
+		AgentID:        "agent-1",
+		IdempotencyKey: "plan-1",
+		Steps: []survival.ActionIntent{
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: survival.ActionMove, Direction: "E"},
+		},
+	}
+
+	out, err := uc.ExecutePlan(context.Background(), req)
+	if err != nil {
+		t.Fatalf("execute plan: %v", err)
+	}
+	if out.StopReason != PlanStopRejected || len(out.Steps) != 3 {
+		t.Fatalf("expected stop after rejected third step, got reason=%s steps=%d", out.StopReason, len(out.Steps))
+	}
+	second := out.Steps[1].Response
+	if second == nil || second.UpdatedState.Position.X != 2 {
+		t.Fatalf("expected second move applied past the move cooldown, got=%+v", out.Steps[1])
+	}
+	start := time.Unix(1700000000, 0)
+	wantAt := start.Add(time.Duration(out.Steps[0].Response.WorldTimeAfterSeconds-out.Steps[0].Response.WorldTimeBeforeSeconds) * time.Second)
+	if len(second.Events) == 0 || !second.Events[0].OccurredAt.Equal(wantAt) {
+		t.Fatalf("expected second step on the plan clock at %v, got=%+v", wantAt, second.Events)
+	}
+	if !errors.Is(out.Steps[2].Err, ErrActionInvalidPosition) {
+		t.Fatalf("expected blocked third move, got=%v", out.Steps[2].Err)
+	}
+	if _, ok := actionRepo.byKey["agent-1|plan-1#1"]; !ok {
+		t.Fatalf("expected second step stored under derived idempotency key")
+	}
+
+	again, err := uc.ExecutePlan(context.Background(), req)
+	if err != nil {
+		t.Fatalf("replay plan: %v", err)
+	}
+	if again.Steps[1].Response == nil || again.Steps[1].Response.UpdatedState.Version != second.UpdatedState.Version {
+		t.Fatalf("expected second step replayed, got=%+v", again.Steps[1])
+	}
+}
+
+func TestUseCase_ExecutePlanStopsWhenOngoingActionStarts(t *testing.T) {
+	uc := newPlanTestUseCase(&stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}})
+
+	out, err := uc.ExecutePlan(context.Background(), PlanRequest{
+		AgentID:        "agent-1",
+		IdempotencyKey: "plan-rest",
+		Steps: []survival.ActionIntent{
+			{Type: survival.ActionRest, RestMinutes: 30},
+			{Type: survival.ActionMove, Direction: "E"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("execute plan: %v", err)
+	}
+	if out.StopReason != PlanStopOngoingStarted || len(out.Steps) != 1 {
+		t.Fatalf("expected stop once rest started, got reason=%s steps=%d", out.StopReason, len(out.Steps))
+	}
+}
+
+func TestUseCase_ExecutePlanStopsWhenVitalDropsBelowThreshold(t *testing.T) {
+	uc := newPlanTestUseCase(&stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}})
+
+	out, err := uc.ExecutePlan(context.Background(), PlanRequest{
+		AgentID:        "agent-1",
+		IdempotencyKey: "plan-energy",
+		Steps: []survival.ActionIntent{
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: survival.ActionMove, Direction: "E"},
+		},
+		StopWhen: PlanStopConditions{EnergyBelow: 60},
+	})
+	if err != nil {
+		t.Fatalf("execute plan: %v", err)
+	}
+	if out.StopReason != PlanStopEnergyBelow || len(out.Steps) != 1 {
+		t.Fatalf("expected energy stop after first step, got reason=%s steps=%d", out.StopReason, len(out.Steps))
+	}
+}
+
+func TestUseCase_ExecuteAtomicPlanFailsWithStepIndex(t *testing.T) {
+	uc := newPlanTestUseCase(&stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}})
+
+	_, err := uc.ExecutePlan(context.Background(), PlanRequest{
+		AgentID:        "agent-1",
+		IdempotencyKey: "plan-atomic",
+		Atomic:         true,
+		Steps: []survival.ActionIntent{
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: survival.ActionMove, Direction: "E"},
+		},
+	})
+	var stepErr *PlanStepError
+	if !errors.As(err, &stepErr) || stepErr.Index != 2 || !errors.Is(err, ErrActionInvalidPosition) {
+		t.Fatalf("expected blocked move at step 2, got %v", err)
+	}
+}
+
+func TestUseCase_ExecutePlanRejectsMalformedStepBeforeRunning(t *testing.T) {
+	actionRepo := &stubActionRepo{byKey: map[string]ports.ActionExecutionRecord{}}
+	uc := newPlanTestUseCase(actionRepo)
+
+	_, err := uc.ExecutePlan(context.Background(), PlanRequest{
+		AgentID:        "agent-1",
+		IdempotencyKey: "plan-bad",
+		Steps: []survival.ActionIntent{
+			{Type: survival.ActionMove, Direction: "E"},
+			{Type: "teleport"},
+		},
+	})
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected invalid request, got %v", err)
+	}
+	if len(actionRepo.byKey) != 0 {
+		t.Fatalf("expected no step executed for malformed plan")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"clawvival/internal/app/ports"
 	"clawvival/internal/domain/survival"
 )
 
@@ -29,6 +30,10 @@ func (r *stubSessionRepo) Close(context.Context, string, survival.DeathCause, ti
 	return nil
 }
 
+func (r *stubSessionRepo) ListByAgentID(context.Context, string) ([]ports.AgentSessionRecord, error) {
+	return nil, nil
+}
+
 func TestRunStandardActionPrecheck_EnsuresSessionAndChecksCooldown(t *testing.T) {
 	now := time.Date(2026, 2, 19, 10, 0, 0, 0, time.UTC)
 	sessionRepo := &stubSessionRepo{}
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/lighting"
+	"clawvival/internal/app/shared/objectwear"
+	"clawvival/internal/app/shared/structures"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -49,6 +52,9 @@ type ActionInput struct {
 	AgentID        string
 	IdempotencyKey string
 	SessionID      string
+	// ClockAt, when set, is used as NowAt instead of the wall clock. Plans set
+	// it so each step starts when the previous one settled.
+	ClockAt time.Time
 }
 
 type ActionView struct {
@@ -57,7 +63,11 @@ type ActionView struct {
 	StateWorking survival.AgentStateAggregate
 	EventsBefore []survival.DomainEvent
 	Snapshot     world.Snapshot
+	Lighting     lighting.Map
+	Structures   structures.Layout
 	PreparedObj  *preparedObjectAction
+	Wear         objectwear.Result
+	TradeOffer   *ports.TradeOfferRecord
 	Finalized    ongoingFinalizeResult
 }
 
@@ -72,6 +82,9 @@ type ActionWritePlan struct {
 	ApplyGatherDepletion bool
 	ApplyObjectAction    bool
 	CreateBuiltObjects   bool
+	ApplyCombat          bool
+	ApplyTrade           bool
+	OpenSession          bool
 	CloseSession         bool
 	CloseSessionCause    survival.DeathCause
 }
@@ -97,15 +110,30 @@ func actionRegistry() map[survival.ActionType]ActionSpec {
 		survival.ActionRest:              {Type: survival.ActionRest, Mode: ActionModeStartOngoing, CanTerminate: true, Handler: restActionHandler{}},
 		survival.ActionSleep:             {Type: survival.ActionSleep, Mode: ActionModeStartOngoing, CanTerminate: true, Handler: sleepActionHandler{}},
 		survival.ActionMove:              {Type: survival.ActionMove, Mode: ActionModeSettle, Handler: moveActionHandler{}},
+		survival.ActionTravel:            {Type: survival.ActionTravel, Mode: ActionModeStartOngoing, CanTerminate: true, Handler: travelActionHandler{}},
 		survival.ActionBuild:             {Type: survival.ActionBuild, Mode: ActionModeSettle, Handler: buildActionHandler{}},
 		survival.ActionFarmPlant:         {Type: survival.ActionFarmPlant, Mode: ActionModeSettle, Handler: farmPlantActionHandler{}},
 		survival.ActionFarmHarvest:       {Type: survival.ActionFarmHarvest, Mode: ActionModeSettle, Handler: farmHarvestActionHandler{}},
+		survival.ActionFarmWater:         {Type: survival.ActionFarmWater, Mode: ActionModeSettle, Handler: farmWaterActionHandler{}},
+		survival.ActionRepair:            {Type: survival.ActionRepair, Mode: ActionModeSettle, Handler: repairActionHandler{}},
+		survival.ActionDeconstruct:       {Type: survival.ActionDeconstruct, Mode: ActionModeSettle, Handler: deconstructActionHandler{}},
+		survival.ActionFurnaceFuel:       {Type: survival.ActionFurnaceFuel, Mode: ActionModeSettle, Handler: furnaceFuelActionHandler{}},
+		survival.ActionFurnaceLoad:       {Type: survival.ActionFurnaceLoad, Mode: ActionModeSettle, Handler: furnaceLoadActionHandler{}},
+		survival.ActionFurnaceCollect:    {Type: survival.ActionFurnaceCollect, Mode: ActionModeSettle, Handler: furnaceCollectActionHandler{}},
 		survival.ActionContainerDeposit:  {Type: survival.ActionContainerDeposit, Mode: ActionModeSettle, Handler: containerDepositActionHandler{}},
 		survival.ActionContainerWithdraw: {Type: survival.ActionContainerWithdraw, Mode: ActionModeSettle, Handler: containerWithdrawActionHandler{}},
 		survival.ActionRetreat:           {Type: survival.ActionRetreat, Mode: ActionModeSettle, Handler: retreatActionHandler{}},
 		survival.ActionCraft:             {Type: survival.ActionCraft, Mode: ActionModeSettle, Handler: craftActionHandler{}},
 		survival.ActionEat:               {Type: survival.ActionEat, Mode: ActionModeSettle, Handler: eatActionHandler{}},
+		survival.ActionAttack:            {Type: survival.ActionAttack, Mode: ActionModeSettle, Handler: attackActionHandler{}},
+		survival.ActionDrink:             {Type: survival.ActionDrink, Mode: ActionModeSettle, Handler: drinkActionHandler{}},
+		survival.ActionTradeOffer:        {Type: survival.ActionTradeOffer, Mode: ActionModeSettle, Handler: tradeOfferActionHandler{}},
+		survival.ActionTradeAccept:       {Type: survival.ActionTradeAccept, Mode: ActionModeSettle, Handler: tradeAcceptActionHandler{}},
+		survival.ActionTradeCancel:       {Type: survival.ActionTradeCancel, Mode: ActionModeSettle, Handler: tradeCancelActionHandler{}},
 		survival.ActionTerminate:         {Type: survival.ActionTerminate, Mode: ActionModeFinalizeOnly, Handler: terminateActionHandler{}},
+		survival.ActionRespawn:           {Type: survival.ActionRespawn, Mode: ActionModeSettle, Handler: respawnActionHandler{}},
+		survival.ActionEquip:             {Type: survival.ActionEquip, Mode: ActionModeSettle, Handler: equipActionHandler{}},
+		survival.ActionUnequip:           {Type: survival.ActionUnequip, Mode: ActionModeSettle, Handler: unequipActionHandler{}},
 	}
 }
 
@@ -115,15 +143,30 @@ func supportedActionTypes() []survival.ActionType {
 		survival.ActionRest,
 		survival.ActionSleep,
 		survival.ActionMove,
+		survival.ActionTravel,
 		survival.ActionBuild,
 		survival.ActionFarmPlant,
 		survival.ActionFarmHarvest,
+		survival.ActionFarmWater,
+		survival.ActionRepair,
+		survival.ActionDeconstruct,
+		survival.ActionFurnaceFuel,
+		survival.ActionFurnaceLoad,
+		survival.ActionFurnaceCollect,
 		survival.ActionContainerDeposit,
 		survival.ActionContainerWithdraw,
 		survival.ActionRetreat,
 		survival.ActionCraft,
 		survival.ActionEat,
+		survival.ActionAttack,
+		survival.ActionDrink,
+		survival.ActionTradeOffer,
+		survival.ActionTradeAccept,
+		survival.ActionTradeCancel,
 		survival.ActionTerminate,
+		survival.ActionRespawn,
+		survival.ActionEquip,
+		survival.ActionUnequip,
 	}
 }
 
@@ -136,20 +179,37 @@ func isSupportedActionType(t survival.ActionType) bool {
 	return false
 }
 
-func actionParamValidators() map[survival.ActionType]func(survival.ActionIntent) bool {
+// actionParamValidators checks intent shape; validators that depend on the
+// catalog read the use case's rule set.
+func actionParamValidators(rules *survival.RuleSet) map[survival.ActionType]func(survival.ActionIntent) bool {
 	return map[survival.ActionType]func(survival.ActionIntent) bool{
 		survival.ActionGather:            validateGatherActionParams,
 		survival.ActionRest:              validateRestActionParams,
 		survival.ActionSleep:             validateSleepActionParams,
 		survival.ActionMove:              validateMoveActionParams,
-		survival.ActionBuild:             validateBuildActionParams,
+		survival.ActionTravel:            validateTravelActionParams,
+		survival.ActionBuild:             func(intent survival.ActionIntent) bool { return validateBuildActionParams(rules, intent) },
 		survival.ActionFarmPlant:         validateFarmPlantActionParams,
 		survival.ActionFarmHarvest:       validateFarmHarvestActionParams,
+		survival.ActionFarmWater:         validateFarmWaterActionParams,
+		survival.ActionRepair:            validateRepairActionParams,
+		survival.ActionDeconstruct:       validateDeconstructActionParams,
+		survival.ActionFurnaceFuel:       func(intent survival.ActionIntent) bool { return validateFurnaceFuelActionParams(rules, intent) },
+		survival.ActionFurnaceLoad:       func(intent survival.ActionIntent) bool { return validateFurnaceLoadActionParams(rules, intent) },
+		survival.ActionFurnaceCollect:    validateFurnaceCollectActionParams,
 		survival.ActionContainerDeposit:  validateContainerActionParams,
 		survival.ActionContainerWithdraw: validateContainerActionParams,
 		survival.ActionRetreat:           validateRetreatActionParams,
 		survival.ActionCraft:             validateCraftActionParams,
-		survival.ActionEat:               validateEatActionParams,
+		survival.ActionEat:               func(intent survival.ActionIntent) bool { return validateEatActionParams(rules, intent) },
+		survival.ActionAttack:            validateAttackActionParams,
+		survival.ActionDrink:             validateDrinkActionParams,
+		survival.ActionTradeOffer:        validateTradeOfferActionParams,
+		survival.ActionTradeAccept:       validateTradeOfferIDActionParams,
+		survival.ActionTradeCancel:       validateTradeOfferIDActionParams,
 		survival.ActionTerminate:         validateTerminateActionParams,
+		survival.ActionRespawn:           validateRespawnActionParams,
+		survival.ActionEquip:             validateEquipActionParams,
+		survival.ActionUnequip:           validateUnequipActionParams,
 	}
 }
@@ -9,7 +9,7 @@ import (
 func TestSupportedActionTypes_MatchesRegistry(t *testing.T) {
 	registry := actionRegistry()
 	supported := supportedActionTypes()
-	validators := actionParamValidators()
+	validators := actionParamValidators(survival.DefaultRuleSet())
 
 	for _, actionType := range supported {
 		if _, ok := registry[actionType]; !ok {
@@ -21,6 +21,10 @@ var (
 	ErrResourceDepleted         = errors.New("resource depleted")
 	ErrInventoryFull            = errors.New("inventory full")
 	ErrContainerFull            = errors.New("container full")
+	ErrContainerNotEmpty        = errors.New("container not empty")
+	ErrTradeOfferExpired        = errors.New("trade offer expired")
+
+	errDryRunRollback = errors.New("dry run rollback")
 )
 
 type ResourceDepletedError struct {
@@ -70,6 +74,9 @@ type UseCase struct {
 	ObjectRepo   ports.WorldObjectRepository
 	ResourceRepo ports.AgentResourceNodeRepository
 	SessionRepo  ports.AgentSessionRepository
+	CreatureRepo ports.WorldCreatureRepository
+	TradeRepo    ports.TradeOfferRepository
+	MapRepo      ports.AgentMapRepository
 	World        ports.WorldProvider
 	Metrics      ports.ActionMetrics
 	Settle       survival.SettlementService
@@ -81,62 +88,107 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 	if err != nil {
 		return Response{}, err
 	}
-
-	nowFn := u.Now
-	if nowFn == nil {
-		nowFn = time.Now
+	if ac.In.Req.DryRun {
+		return u.simulate(ctx, &ac)
 	}
-	ac.In.NowAt = nowFn()
 
 	var out Response
 	err = u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
-		replay, ok, err := u.ReplayIdempotent(txCtx, &ac)
+		out, err = u.runPipeline(txCtx, &ac)
+		return err
+	})
+	if err != nil {
+		u.recordFailure(err)
+		return Response{}, err
+	}
+	u.recordSuccess(out.ResultCode)
+
+	return out, nil
+}
+
+// simulate runs the pipeline up to planning and always rolls the transaction
+// back, so ongoing actions finalized along the way are not persisted either.
+func (u UseCase) simulate(ctx context.Context, ac *ActionContext) (Response, error) {
+	var out Response
+	err := u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
+		var err error
+		out, err = u.runPipeline(txCtx, ac)
 		if err != nil {
 			return err
 		}
-		if ok {
-			out = replay
-			return nil
-		}
-		if err := u.LoadStateAndFinalizeOngoing(txCtx, &ac); err != nil {
-			return err
-		}
-		if err := u.ResolveSpec(&ac); err != nil {
-			return err
-		}
-		if err := u.BuildContext(txCtx, &ac); err != nil {
-			return err
-		}
-		if err := u.RunPrechecks(txCtx, &ac); err != nil {
-			return err
-		}
-		mode, err := u.ExecuteActionAndPlan(txCtx, &ac)
+		return errDryRunRollback
+	})
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		return Response{}, err
+	}
+	out.DryRun = true
+	cooldown, _ := u.Settle.RuleSet().Cooldown(ac.Tmp.ResolvedIntent.Type)
+	out.CooldownSeconds = int(cooldown.Seconds())
+	return out, nil
+}
+
+func (u UseCase) now() time.Time {
+	if u.Now == nil {
+		return time.Now()
+	}
+	return u.Now()
+}
+
+func (u UseCase) runPipeline(txCtx context.Context, ac *ActionContext) (Response, error) {
+	ac.In.NowAt = ac.In.ClockAt
+	if ac.In.NowAt.IsZero() {
+		ac.In.NowAt = u.now()
+	}
+
+	if !ac.In.Req.DryRun {
+		replay, ok, err := u.ReplayIdempotent(txCtx, ac)
 		if err != nil {
-			return err
-		}
-		if err := u.PersistAndRespond(txCtx, &ac); err != nil {
-			return err
+			return Response{}, err
 		}
-		if mode == ExecuteModeCompleted {
-			out = u.BuildCompletedResponse(&ac)
-			return nil
+		if ok {
+			return replay, nil
 		}
-		out = u.BuildSettledResponse(&ac)
-		return nil
-	})
+	}
+	if err := u.LoadStateAndFinalizeOngoing(txCtx, ac); err != nil {
+		return Response{}, err
+	}
+	if err := u.ResolveSpec(ac); err != nil {
+		return Response{}, err
+	}
+	if err := u.BuildContext(txCtx, ac); err != nil {
+		return Response{}, err
+	}
+	if err := u.RunPrechecks(txCtx, ac); err != nil {
+		return Response{}, err
+	}
+	mode, err := u.ExecuteActionAndPlan(txCtx, ac)
 	if err != nil {
-		if u.Metrics != nil {
-			if errors.Is(err, ports.ErrConflict) {
-				u.Metrics.RecordConflict()
-			} else {
-				u.Metrics.RecordFailure()
-			}
-		}
 		return Response{}, err
 	}
+	if !ac.In.Req.DryRun {
+		if err := u.PersistAndRespond(txCtx, ac); err != nil {
+			return Response{}, err
+		}
+	}
+	if mode == ExecuteModeCompleted {
+		return u.BuildCompletedResponse(ac), nil
+	}
+	return u.BuildSettledResponse(ac), nil
+}
+
+func (u UseCase) recordSuccess(resultCode survival.ResultCode) {
 	if u.Metrics != nil {
-		u.Metrics.RecordSuccess(out.ResultCode)
+		u.Metrics.RecordSuccess(resultCode)
 	}
+}
 
-	return out, nil
+func (u UseCase) recordFailure(err error) {
+	if u.Metrics == nil {
+		return
+	}
+	if errors.Is(err, ports.ErrConflict) {
+		u.Metrics.RecordConflict()
+	} else {
+		u.Metrics.RecordFailure()
+	}
 }
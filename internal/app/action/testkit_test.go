@@ -3,9 +3,11 @@ package action
 import (
 	"context"
 	"strings"
+	"time"
 
 	"clawvival/internal/app/ports"
 	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
 )
 
 type stubTxManager struct{}
@@ -134,6 +136,13 @@ func (r *stubResourceNodeRepo) Upsert(_ context.Context, record ports.AgentResou
 	return nil
 }
 
+func (r *stubResourceNodeRepo) Deplete(ctx context.Context, record ports.AgentResourceNodeRecord, now time.Time) error {
+	if existing, ok := r.byTarget[record.AgentID+"|"+record.TargetID]; ok && existing.DepletedUntil.After(now) {
+		return ports.ErrConflict
+	}
+	return r.Upsert(ctx, record)
+}
+
 func (r *stubResourceNodeRepo) GetByTargetID(_ context.Context, agentID, targetID string) (ports.AgentResourceNodeRecord, error) {
 	if r.byTarget == nil {
 		return ports.AgentResourceNodeRecord{}, ports.ErrNotFound
@@ -177,9 +186,12 @@ func (r *stubObjectRepo) GetByObjectID(_ context.Context, _ string, objectID str
 	return obj, nil
 }
 
-func (r *stubObjectRepo) ListByAgentID(_ context.Context, _ string) ([]ports.WorldObjectRecord, error) {
+func (r *stubObjectRepo) ListInBounds(_ context.Context, _ string, minX, minY, maxX, maxY int) ([]ports.WorldObjectRecord, error) {
 	out := make([]ports.WorldObjectRecord, 0, len(r.byID))
 	for _, obj := range r.byID {
+		if obj.X < minX || obj.X > maxX || obj.Y < minY || obj.Y > maxY {
+			continue
+		}
 		out = append(out, obj)
 	}
 	return out, nil
@@ -189,6 +201,49 @@ func (r *stubObjectRepo) Update(_ context.Context, _ string, obj ports.WorldObje
 	if r.byID == nil {
 		r.byID = map[string]ports.WorldObjectRecord{}
 	}
+	if cur, ok := r.byID[obj.ObjectID]; ok && cur.Version != obj.Version {
+		return ports.ErrConflict
+	}
+	obj.Version++
 	r.byID[obj.ObjectID] = obj
 	return nil
 }
+
+func (r *stubObjectRepo) UpdateWear(_ context.Context, _ string, obj ports.WorldObjectRecord, checkedAt time.Time) error {
+	cur, ok := r.byID[obj.ObjectID]
+	if !ok || !cur.DecayCheckedAt.Equal(checkedAt) {
+		return nil
+	}
+	cur.HP = obj.HP
+	cur.DecayCheckedAt = obj.DecayCheckedAt
+	cur.WearCarry = obj.WearCarry
+	r.byID[obj.ObjectID] = cur
+	return nil
+}
+
+func (r *stubObjectRepo) Delete(_ context.Context, _ string, objectID string) error {
+	if _, ok := r.byID[objectID]; !ok {
+		return ports.ErrNotFound
+	}
+	delete(r.byID, objectID)
+	return nil
+}
+
+type stubCreatureRepo struct {
+	updated map[string]world.Creature
+}
+
+func (r *stubCreatureRepo) Damage(_ context.Context, creature world.Creature, damage int) (int, error) {
+	if r.updated == nil {
+		r.updated = map[string]world.Creature{}
+	}
+	if prev, ok := r.updated[creature.ID]; ok {
+		creature = prev
+	}
+	creature.HP -= damage
+	if creature.HP < 0 {
+		creature.HP = 0
+	}
+	r.updated[creature.ID] = creature
+	return creature.HP, nil
+}
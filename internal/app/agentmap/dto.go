@@ -0,0 +1,39 @@
+package agentmap
+
+import "clawvival/internal/domain/world"
+
+type Request struct {
+	AgentID string
+	// SessionID defaults to the agent's current session.
+	SessionID string
+	// Bounds defaults to DefaultRadius tiles around the agent.
+	Bounds *Bounds
+}
+
+type Bounds struct {
+	MinX int `json:"min_x"`
+	MinY int `json:"min_y"`
+	MaxX int `json:"max_x"`
+	MaxY int `json:"max_y"`
+}
+
+type Response struct {
+	AgentID   string      `json:"agent_id"`
+	SessionID string      `json:"session_id"`
+	AgentPos  world.Point `json:"agent_pos"`
+	Bounds    Bounds      `json:"bounds"`
+	// Rows has one string per y from MinY to MaxY with one legend symbol per
+	// x from MinX to MaxX.
+	Rows       []string          `json:"rows"`
+	Legend     map[string]string `json:"legend"`
+	KnownTiles int               `json:"known_tiles"`
+	Resources  []Feature         `json:"resources"`
+	Objects    []Feature         `json:"objects"`
+}
+
+type Feature struct {
+	ID         string      `json:"id,omitempty"`
+	Type       string      `json:"type"`
+	Pos        world.Point `json:"pos"`
+	LastSeenAt int64       `json:"last_seen_at"`
+}
@@ -0,0 +1,126 @@
+package agentmap
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/resourcestate"
+	"clawvival/internal/domain/world"
+)
+
+var ErrInvalidRequest = errors.New("invalid map request")
+
+const (
+	DefaultRadius = 15
+	MaxSpan       = 64
+	unknownSymbol = "?"
+)
+
+var terrainSymbols = map[string]string{
+	string(world.TileGrass): ".",
+	string(world.TileTree):  "T",
+	string(world.TileRock):  "R",
+	string(world.TileDirt):  ",",
+	string(world.TileWater): "~",
+}
+
+type UseCase struct {
+	StateRepo ports.AgentStateRepository
+	MapRepo   ports.AgentMapRepository
+}
+
+func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
+	agentID := strings.TrimSpace(req.AgentID)
+	if agentID == "" || u.StateRepo == nil || u.MapRepo == nil {
+		return Response{}, ErrInvalidRequest
+	}
+	state, err := u.StateRepo.GetByAgentID(ctx, agentID)
+	if err != nil {
+		return Response{}, err
+	}
+	pos := world.Point{X: state.Position.X, Y: state.Position.Y}
+	bounds := Bounds{MinX: pos.X - DefaultRadius, MinY: pos.Y - DefaultRadius, MaxX: pos.X + DefaultRadius, MaxY: pos.Y + DefaultRadius}
+	if req.Bounds != nil {
+		bounds = *req.Bounds
+	}
+	if !bounds.valid() {
+		return Response{}, ErrInvalidRequest
+	}
+	sessionID := strings.TrimSpace(req.SessionID)
+	if sessionID == "" {
+		sessionID = state.ActiveSessionID()
+	}
+	tiles, err := u.MapRepo.ListInBounds(ctx, agentID, sessionID, bounds.MinX, bounds.MinY, bounds.MaxX, bounds.MaxY)
+	if err != nil {
+		return Response{}, err
+	}
+	out := encode(bounds, tiles)
+	out.AgentID = agentID
+	out.SessionID = sessionID
+	out.AgentPos = pos
+	return out, nil
+}
+
+// valid measures the span unsigned once the order is checked, so extreme
+// coordinates cannot overflow into a small span.
+func (b Bounds) valid() bool {
+	return b.MinX <= b.MaxX && b.MinY <= b.MaxY &&
+		uint64(b.MaxX)-uint64(b.MinX) < MaxSpan && uint64(b.MaxY)-uint64(b.MinY) < MaxSpan
+}
+
+// encode packs terrain into one symbol per tile and lists only the tiles
+// that held something when last seen.
+func encode(bounds Bounds, tiles []ports.AgentMapTileRecord) Response {
+	width := bounds.MaxX - bounds.MinX + 1
+	height := bounds.MaxY - bounds.MinY + 1
+	grid := make([][]string, height)
+	for y := range grid {
+		grid[y] = make([]string, width)
+		for x := range grid[y] {
+			grid[y][x] = unknownSymbol
+		}
+	}
+	out := Response{
+		Bounds:    bounds,
+		Legend:    map[string]string{unknownSymbol: "unknown"},
+		Resources: []Feature{},
+		Objects:   []Feature{},
+	}
+	for _, tile := range tiles {
+		if tile.X < bounds.MinX || tile.X > bounds.MaxX || tile.Y < bounds.MinY || tile.Y > bounds.MaxY {
+			continue
+		}
+		symbol, ok := terrainSymbols[tile.TerrainType]
+		if !ok {
+			symbol = unknownSymbol
+		} else {
+			out.Legend[symbol] = tile.TerrainType
+		}
+		grid[tile.Y-bounds.MinY][tile.X-bounds.MinX] = symbol
+		out.KnownTiles++
+		pos := world.Point{X: tile.X, Y: tile.Y}
+		if tile.ResourceType != "" {
+			out.Resources = append(out.Resources, Feature{
+				ID:         resourcestate.BuildResourceTargetID(tile.X, tile.Y, tile.ResourceType),
+				Type:       tile.ResourceType,
+				Pos:        pos,
+				LastSeenAt: tile.LastSeenAt.Unix(),
+			})
+		}
+		if tile.ObjectID != "" {
+			out.Objects = append(out.Objects, Feature{
+				ID:         tile.ObjectID,
+				Type:       tile.ObjectType,
+				Pos:        pos,
+				LastSeenAt: tile.LastSeenAt.Unix(),
+			})
+		}
+	}
+	out.Rows = make([]string, 0, height)
+	for _, row := range grid {
+		out.Rows = append(out.Rows, strings.Join(row, ""))
+	}
+	return out
+}
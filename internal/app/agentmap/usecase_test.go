@@ -0,0 +1,124 @@
+package agentmap
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+type mapStateRepo struct {
+	state survival.AgentStateAggregate
+}
+
+func (r mapStateRepo) GetByAgentID(context.Context, string) (survival.AgentStateAggregate, error) {
+	return r.state, nil
+}
+
+func (r mapStateRepo) SaveWithVersion(context.Context, survival.AgentStateAggregate, int64) error {
+	return nil
+}
+
+type mapRepo struct {
+	tiles     []ports.AgentMapTileRecord
+	sessionID string
+	bounds    Bounds
+}
+
+func (r *mapRepo) Upsert(context.Context, []ports.AgentMapTileRecord) error {
+	return nil
+}
+
+func (r *mapRepo) ListInBounds(_ context.Context, _, sessionID string, minX, minY, maxX, maxY int) ([]ports.AgentMapTileRecord, error) {
+	r.sessionID = sessionID
+	r.bounds = Bounds{MinX: minX, MinY: minY, MaxX: maxX, MaxY: maxY}
+	return r.tiles, nil
+}
+
+func TestUseCase_EncodesRememberedTilesAsRows(t *testing.T) {
+	seen := time.Unix(1700000000, 0)
+	repo := &mapRepo{tiles: []ports.AgentMapTileRecord{
+		{X: 10, Y: 20, TerrainType: "grass", IsWalkable: true, ObjectID: "obj-box", ObjectType: "box", LastSeenAt: seen},
+		{X: 11, Y: 20, TerrainType: "tree", ResourceType: "wood", LastSeenAt: seen},
+		{X: 12, Y: 21, TerrainType: "water", LastSeenAt: seen},
+	}}
+	uc := UseCase{
+		StateRepo: mapStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1", SessionID: "session-1", Position: survival.Position{X: 10, Y: 20}}},
+		MapRepo:   repo,
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", Bounds: &Bounds{MinX: 10, MinY: 20, MaxX: 12, MaxY: 21}})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if repo.sessionID != "session-1" || resp.SessionID != "session-1" {
+		t.Fatalf("expected current session, got repo=%q resp=%q", repo.sessionID, resp.SessionID)
+	}
+	if len(resp.Rows) != 2 || resp.Rows[0] != ".T?" || resp.Rows[1] != "??~" {
+		t.Fatalf("unexpected rows: %q", resp.Rows)
+	}
+	if resp.Legend["T"] != "tree" || resp.Legend["?"] != "unknown" || resp.KnownTiles != 3 {
+		t.Fatalf("unexpected legend or count: %+v %d", resp.Legend, resp.KnownTiles)
+	}
+	if len(resp.Resources) != 1 || resp.Resources[0].ID != "res_11_20_wood" || resp.Resources[0].LastSeenAt != seen.Unix() {
+		t.Fatalf("unexpected resources: %+v", resp.Resources)
+	}
+	if len(resp.Objects) != 1 || resp.Objects[0].ID != "obj-box" || resp.Objects[0].Type != "box" {
+		t.Fatalf("unexpected objects: %+v", resp.Objects)
+	}
+}
+
+func TestUseCase_DefaultsBoundsAroundAgent(t *testing.T) {
+	repo := &mapRepo{}
+	uc := UseCase{
+		StateRepo: mapStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1", Position: survival.Position{X: 3, Y: -4}}},
+		MapRepo:   repo,
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	want := Bounds{MinX: 3 - DefaultRadius, MinY: -4 - DefaultRadius, MaxX: 3 + DefaultRadius, MaxY: -4 + DefaultRadius}
+	if repo.bounds != want || resp.Bounds != want {
+		t.Fatalf("expected bounds %+v, got repo=%+v resp=%+v", want, repo.bounds, resp.Bounds)
+	}
+	if len(resp.Rows) != 2*DefaultRadius+1 || resp.KnownTiles != 0 {
+		t.Fatalf("expected an all-unknown window, got %d rows known=%d", len(resp.Rows), resp.KnownTiles)
+	}
+}
+
+func TestUseCase_AcceptsNarrowBoundsAtExtremeCoordinates(t *testing.T) {
+	repo := &mapRepo{}
+	uc := UseCase{StateRepo: mapStateRepo{}, MapRepo: repo}
+	bounds := Bounds{MinX: math.MaxInt - 2, MinY: math.MinInt, MaxX: math.MaxInt, MaxY: math.MinInt + 1}
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", Bounds: &bounds})
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if len(resp.Rows) != 2 || len(resp.Rows[0]) != 3 {
+		t.Fatalf("expected a 3x2 window, got %+v", resp.Rows)
+	}
+}
+
+func TestUseCase_RejectsInvalidBounds(t *testing.T) {
+	uc := UseCase{StateRepo: mapStateRepo{}, MapRepo: &mapRepo{}}
+	for _, bounds := range []Bounds{
+		{MinX: 5, MinY: 0, MaxX: 4, MaxY: 0},
+		{MinX: 0, MinY: 0, MaxX: MaxSpan, MaxY: 0},
+		{MinX: math.MinInt, MinY: 0, MaxX: math.MaxInt, MaxY: 0},
+		{MinX: 0, MinY: math.MinInt, MaxX: 0, MaxY: math.MaxInt},
+		{MinX: -1, MinY: 0, MaxX: math.MaxInt, MaxY: 0},
+	} {
+		if _, err := uc.Execute(context.Background(), Request{AgentID: "agent-1", Bounds: &bounds}); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("expected ErrInvalidRequest for %+v, got %v", bounds, err)
+		}
+	}
+	if _, err := uc.Execute(context.Background(), Request{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected ErrInvalidRequest for empty agent, got %v", err)
+	}
+}
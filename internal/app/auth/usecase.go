@@ -26,9 +26,10 @@ var (
 type RegisterRequest struct{}
 
 type RegisterResponse struct {
-	AgentID  string `json:"agent_id"`
-	AgentKey string `json:"agent_key"`
-	IssuedAt string `json:"issued_at"`
+	AgentID   string `json:"agent_id"`
+	AgentKey  string `json:"agent_key"`
+	SessionID string `json:"session_id"`
+	IssuedAt  string `json:"issued_at"`
 }
 
 type VerifyRequest struct {
@@ -39,6 +40,7 @@ type VerifyRequest struct {
 type RegisterUseCase struct {
 	Credentials ports.AgentCredentialRepository
 	StateRepo   ports.AgentStateRepository
+	SessionRepo ports.AgentSessionRepository
 	TxManager   ports.TxManager
 	Now         func() time.Time
 }
@@ -71,6 +73,7 @@ func (u RegisterUseCase) Execute(ctx context.Context, _ RegisterRequest) (Regist
 			return RegisterResponse{}, err
 		}
 		hash := credentialHash(salt, agentKey)
+		sessionID := survival.NewSessionID(agentID, now)
 
 		err = u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
 			if err := u.Credentials.Create(txCtx, ports.AgentCredentialRecord{
@@ -84,9 +87,10 @@ func (u RegisterUseCase) Execute(ctx context.Context, _ RegisterRequest) (Regist
 			}
 			seed := survival.AgentStateAggregate{
 				AgentID:           agentID,
-				Vitals:            survival.Vitals{HP: 100, Hunger: 80, Energy: 60},
-				Position:          survival.Position{X: 0, Y: 0},
-				Home:              survival.Position{X: 0, Y: 0},
+				SessionID:         sessionID,
+				Vitals:            survival.SpawnVitals(),
+				Position:          survival.SpawnPosition,
+				Home:              survival.SpawnPosition,
 				Inventory:         map[string]int{},
 				InventoryCapacity: survival.DefaultInventoryCapacity,
 				InventoryUsed:     0,
@@ -95,7 +99,13 @@ func (u RegisterUseCase) Execute(ctx context.Context, _ RegisterRequest) (Regist
 				Version:           1,
 				UpdatedAt:         now,
 			}
-			return u.StateRepo.SaveWithVersion(txCtx, seed, 0)
+			if err := u.StateRepo.SaveWithVersion(txCtx, seed, 0); err != nil {
+				return err
+			}
+			if u.SessionRepo == nil {
+				return nil
+			}
+			return u.SessionRepo.EnsureActive(txCtx, sessionID, agentID, seed.Version)
 		})
 		if err == ports.ErrConflict {
 			continue
@@ -104,9 +114,10 @@ func (u RegisterUseCase) Execute(ctx context.Context, _ RegisterRequest) (Regist
 			return RegisterResponse{}, err
 		}
 		return RegisterResponse{
-			AgentID:  agentID,
-			AgentKey: agentKey,
-			IssuedAt: now.Format(time.RFC3339),
+			AgentID:   agentID,
+			AgentKey:  agentKey,
+			SessionID: sessionID,
+			IssuedAt:  now.Format(time.RFC3339),
 		}, nil
 	}
 
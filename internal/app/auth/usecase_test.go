@@ -39,6 +39,9 @@ func TestRegisterUseCase_CreatesCredentialAndSeedState(t *testing.T) {
 	if state.last.Version != 1 {
 		t.Fatalf("expected seed version=1, got %d", state.last.Version)
 	}
+	if resp.SessionID == "" || state.last.SessionID != resp.SessionID {
+		t.Fatalf("expected generated session stored on seed state, got %q vs %q", state.last.SessionID, resp.SessionID)
+	}
 }
 
 func TestVerifyUseCase_AcceptsValidCredentials(t *testing.T) {
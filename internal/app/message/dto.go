@@ -0,0 +1,18 @@
+package message
+
+type Request struct {
+	AgentID   string
+	Mode      string
+	Text      string
+	ToAgentID string
+	// Channel labels a broadcast; it does not limit who receives it.
+	Channel string
+}
+
+type Response struct {
+	MessageID   string   `json:"message_id"`
+	Mode        string   `json:"mode"`
+	Channel     string   `json:"channel,omitempty"`
+	DeliveredTo []string `json:"delivered_to"`
+	SentAt      int64    `json:"sent_at"`
+}
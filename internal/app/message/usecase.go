@@ -0,0 +1,228 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+const (
+	ModeSay       = "say"
+	ModeWhisper   = "whisper"
+	ModeBroadcast = "broadcast"
+
+	SayRadius          = survival.VisionRadiusDay
+	WhisperRadius      = 1
+	MaxTextLength      = 280
+	RateLimitPerMinute = 6
+)
+
+var (
+	ErrInvalidRequest      = errors.New("invalid message request")
+	ErrRateLimited         = errors.New("message rate limit exceeded")
+	ErrAgentDead           = errors.New("dead agents cannot send messages")
+	ErrRecipientOutOfRange = errors.New("recipient is out of whisper range")
+)
+
+type UseCase struct {
+	TxManager   ports.TxManager
+	StateRepo   ports.AgentStateRepository
+	EventRepo   ports.EventRepository
+	MessageRepo ports.MessageRepository
+	Agents      ports.AgentLocator
+	Now         func() time.Time
+}
+
+func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
+	req, err := normalizeRequest(req)
+	if err != nil {
+		return Response{}, err
+	}
+	if u.TxManager == nil || u.StateRepo == nil || u.EventRepo == nil || u.MessageRepo == nil || u.Agents == nil {
+		return Response{}, ErrInvalidRequest
+	}
+	nowFn := u.Now
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	nowAt := nowFn()
+
+	var resp Response
+	err = u.TxManager.RunInTx(ctx, func(txCtx context.Context) error {
+		sender, err := u.StateRepo.GetByAgentID(txCtx, req.AgentID)
+		if err != nil {
+			return err
+		}
+		if sender.Dead {
+			return ErrAgentDead
+		}
+		if err := u.MessageRepo.LockSender(txCtx, req.AgentID); err != nil {
+			return err
+		}
+		sent, err := u.MessageRepo.CountSentSince(txCtx, req.AgentID, nowAt.Add(-time.Minute))
+		if err != nil {
+			return err
+		}
+		if sent >= RateLimitPerMinute {
+			return ErrRateLimited
+		}
+		recipients, err := u.resolveRecipients(txCtx, req, sender.Position)
+		if err != nil {
+			return err
+		}
+
+		messageID := "msg-" + req.AgentID + "-" + strconv.FormatInt(nowAt.UnixNano(), 10)
+		base := ports.MessageRecord{
+			MessageID:   messageID,
+			FromAgentID: req.AgentID,
+			Mode:        req.Mode,
+			Channel:     req.Channel,
+			Text:        req.Text,
+			X:           sender.Position.X,
+			Y:           sender.Position.Y,
+			CreatedAt:   nowAt,
+		}
+		// The undirected row records the send itself, so rate limits count
+		// messages nobody heard; for broadcasts it is also the delivery.
+		rows := []ports.MessageRecord{base}
+		if req.Mode != ModeBroadcast {
+			for _, recipientID := range recipients {
+				row := base
+				row.ToAgentID = recipientID
+				rows = append(rows, row)
+			}
+		}
+		if err := u.MessageRepo.Save(txCtx, rows); err != nil {
+			return err
+		}
+
+		if err := u.EventRepo.Append(txCtx, req.AgentID, []survival.DomainEvent{
+			messageEvent("message_sent", sender, nowAt, map[string]any{
+				"message_id":   messageID,
+				"mode":         req.Mode,
+				"channel":      req.Channel,
+				"text":         req.Text,
+				"to_agent_id":  req.ToAgentID,
+				"delivered_to": recipients,
+			}),
+		}); err != nil {
+			return err
+		}
+		for _, recipientID := range recipients {
+			recipient, err := u.StateRepo.GetByAgentID(txCtx, recipientID)
+			if err != nil {
+				return err
+			}
+			if err := u.EventRepo.Append(txCtx, recipientID, []survival.DomainEvent{
+				messageEvent("message_received", recipient, nowAt, map[string]any{
+					"message_id":    messageID,
+					"mode":          req.Mode,
+					"channel":       req.Channel,
+					"text":          req.Text,
+					"from_agent_id": req.AgentID,
+				}),
+			}); err != nil {
+				return err
+			}
+		}
+
+		resp = Response{
+			MessageID:   messageID,
+			Mode:        req.Mode,
+			Channel:     req.Channel,
+			DeliveredTo: recipients,
+			SentAt:      nowAt.Unix(),
+		}
+		return nil
+	})
+	if err != nil {
+		return Response{}, err
+	}
+	return resp, nil
+}
+
+// resolveRecipients lists the living agents who hear the message. Broadcasts
+// reach everyone but are stored once and read by every agent's inbox. There
+// are no channel subscriptions: a broadcast's channel is a label passed along
+// for readers to filter on and never narrows who hears it.
+func (u UseCase) resolveRecipients(ctx context.Context, req Request, from survival.Position) ([]string, error) {
+	var listeners []string
+	var err error
+	switch req.Mode {
+	case ModeSay:
+		listeners, err = u.Agents.ListLivingNear(ctx, from, SayRadius)
+	case ModeWhisper:
+		target, err := u.StateRepo.GetByAgentID(ctx, req.ToAgentID)
+		if err != nil {
+			return nil, err
+		}
+		if target.Dead || manhattan(from, target.Position) > WhisperRadius {
+			return nil, ErrRecipientOutOfRange
+		}
+		return []string{req.ToAgentID}, nil
+	default:
+		listeners, err = u.Agents.ListLiving(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(listeners))
+	for _, agentID := range listeners {
+		if agentID != req.AgentID {
+			out = append(out, agentID)
+		}
+	}
+	return out, nil
+}
+
+func normalizeRequest(req Request) (Request, error) {
+	req.AgentID = strings.TrimSpace(req.AgentID)
+	req.Mode = strings.ToLower(strings.TrimSpace(req.Mode))
+	req.Text = strings.TrimSpace(req.Text)
+	req.ToAgentID = strings.TrimSpace(req.ToAgentID)
+	req.Channel = strings.TrimSpace(req.Channel)
+	if req.AgentID == "" || req.Text == "" || utf8.RuneCountInString(req.Text) > MaxTextLength {
+		return Request{}, ErrInvalidRequest
+	}
+	switch req.Mode {
+	case ModeSay:
+		req.ToAgentID = ""
+		req.Channel = ""
+	case ModeWhisper:
+		if req.ToAgentID == "" || req.ToAgentID == req.AgentID {
+			return Request{}, ErrInvalidRequest
+		}
+		req.Channel = ""
+	case ModeBroadcast:
+		if req.Channel == "" {
+			return Request{}, ErrInvalidRequest
+		}
+		req.ToAgentID = ""
+	default:
+		return Request{}, ErrInvalidRequest
+	}
+	return req, nil
+}
+
+func messageEvent(eventType string, owner survival.AgentStateAggregate, at time.Time, payload map[string]any) survival.DomainEvent {
+	payload["agent_id"] = owner.AgentID
+	payload["session_id"] = owner.ActiveSessionID()
+	return survival.DomainEvent{Type: eventType, OccurredAt: at, Payload: payload}
+}
+
+func manhattan(a, b survival.Position) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
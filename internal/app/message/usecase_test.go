@@ -0,0 +1,236 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+type messageTxManager struct{}
+
+func (messageTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type messageStateRepo struct {
+	byAgent map[string]survival.AgentStateAggregate
+}
+
+func (r messageStateRepo) GetByAgentID(_ context.Context, agentID string) (survival.AgentStateAggregate, error) {
+	state, ok := r.byAgent[agentID]
+	if !ok {
+		return survival.AgentStateAggregate{}, ports.ErrNotFound
+	}
+	return state, nil
+}
+
+func (r messageStateRepo) SaveWithVersion(context.Context, survival.AgentStateAggregate, int64) error {
+	return nil
+}
+
+func (r messageStateRepo) ListLivingNear(_ context.Context, center survival.Position, radius int) ([]string, error) {
+	out := []string{}
+	for agentID, state := range r.byAgent {
+		if !state.Dead && manhattan(center, state.Position) <= radius {
+			out = append(out, agentID)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (r messageStateRepo) ListLiving(context.Context) ([]string, error) {
+	out := []string{}
+	for agentID, state := range r.byAgent {
+		if !state.Dead {
+			out = append(out, agentID)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+type messageEventRepo struct {
+	byAgent map[string][]survival.DomainEvent
+}
+
+func (r *messageEventRepo) Append(_ context.Context, agentID string, events []survival.DomainEvent) error {
+	if r.byAgent == nil {
+		r.byAgent = map[string][]survival.DomainEvent{}
+	}
+	r.byAgent[agentID] = append(r.byAgent[agentID], events...)
+	return nil
+}
+
+func (r *messageEventRepo) ListByAgentID(_ context.Context, agentID string, _ int) ([]survival.DomainEvent, error) {
+	return r.byAgent[agentID], nil
+}
+
+type messageRepo struct {
+	rows  []ports.MessageRecord
+	locks []string
+}
+
+func (r *messageRepo) Save(_ context.Context, messages []ports.MessageRecord) error {
+	r.rows = append(r.rows, messages...)
+	return nil
+}
+
+func (r *messageRepo) CountSentSince(_ context.Context, agentID string, since time.Time) (int, error) {
+	seen := map[string]bool{}
+	for _, row := range r.rows {
+		if row.FromAgentID == agentID && !row.CreatedAt.Before(since) {
+			seen[row.MessageID] = true
+		}
+	}
+	return len(seen), nil
+}
+
+func (r *messageRepo) ListInbox(context.Context, string, time.Time, int) ([]ports.MessageRecord, error) {
+	return nil, nil
+}
+
+func (r *messageRepo) LockSender(_ context.Context, agentID string) error {
+	r.locks = append(r.locks, agentID)
+	return nil
+}
+
+func newMessageUseCase(states map[string]survival.AgentStateAggregate, now *time.Time) (UseCase, *messageRepo, *messageEventRepo) {
+	stateRepo := messageStateRepo{byAgent: states}
+	msgRepo := &messageRepo{}
+	eventRepo := &messageEventRepo{}
+	return UseCase{
+		TxManager:   messageTxManager{},
+		StateRepo:   stateRepo,
+		EventRepo:   eventRepo,
+		MessageRepo: msgRepo,
+		Agents:      stateRepo,
+		Now:         func() time.Time { return *now },
+	}, msgRepo, eventRepo
+}
+
+func TestUseCase_SayDeliversOnlyWithinRadius(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, repo, events := newMessageUseCase(map[string]survival.AgentStateAggregate{
+		"a1": {AgentID: "a1", Position: survival.Position{X: 0, Y: 0}},
+		"a2": {AgentID: "a2", Position: survival.Position{X: 2, Y: 3}},
+		"a3": {AgentID: "a3", Position: survival.Position{X: SayRadius + 1, Y: 0}},
+		"a4": {AgentID: "a4", Position: survival.Position{X: 1, Y: 0}, Dead: true},
+	}, &now)
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "say", Text: " wood here "})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(resp.DeliveredTo) != 1 || resp.DeliveredTo[0] != "a2" {
+		t.Fatalf("expected delivery to a2 only, got %v", resp.DeliveredTo)
+	}
+	if len(repo.rows) != 2 || repo.rows[1].ToAgentID != "a2" || repo.rows[1].Text != "wood here" {
+		t.Fatalf("unexpected stored rows: %+v", repo.rows)
+	}
+	if got := events.byAgent["a1"]; len(got) != 1 || got[0].Type != "message_sent" {
+		t.Fatalf("expected message_sent in sender stream, got %+v", got)
+	}
+	if got := events.byAgent["a2"]; len(got) != 1 || got[0].Type != "message_received" || got[0].Payload["from_agent_id"] != "a1" {
+		t.Fatalf("expected message_received in recipient stream, got %+v", got)
+	}
+	if _, ok := events.byAgent["a3"]; ok {
+		t.Fatalf("expected out-of-range agent to hear nothing")
+	}
+}
+
+func TestUseCase_WhisperRequiresAdjacentRecipient(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, _, _ := newMessageUseCase(map[string]survival.AgentStateAggregate{
+		"a1": {AgentID: "a1", Position: survival.Position{X: 0, Y: 0}},
+		"a2": {AgentID: "a2", Position: survival.Position{X: 2, Y: 0}},
+	}, &now)
+
+	_, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "whisper", Text: "psst", ToAgentID: "a2"})
+	if !errors.Is(err, ErrRecipientOutOfRange) {
+		t.Fatalf("expected ErrRecipientOutOfRange, got %v", err)
+	}
+	_, err = uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "whisper", Text: "psst", ToAgentID: "missing"})
+	if !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUseCase_RateLimitsSendersPerMinute(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, repo, _ := newMessageUseCase(map[string]survival.AgentStateAggregate{
+		"a1": {AgentID: "a1"},
+	}, &now)
+
+	for i := 0; i < RateLimitPerMinute; i++ {
+		now = now.Add(time.Second)
+		if _, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "broadcast", Channel: "camp", Text: "hello"}); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	now = now.Add(time.Second)
+	if _, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "broadcast", Channel: "camp", Text: "hello"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "broadcast", Channel: "camp", Text: "hello"}); err != nil {
+		t.Fatalf("expected limit to reset after a minute, got %v", err)
+	}
+	if got := repo.rows[0]; got.ToAgentID != "" || got.Channel != "camp" {
+		t.Fatalf("expected undirected broadcast row, got %+v", got)
+	}
+}
+
+func TestUseCase_RejectsInvalidRequests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, _, _ := newMessageUseCase(map[string]survival.AgentStateAggregate{
+		"a1": {AgentID: "a1"},
+	}, &now)
+
+	cases := []Request{
+		{AgentID: "a1", Mode: "say", Text: "   "},
+		{AgentID: "a1", Mode: "shout", Text: "hi"},
+		{AgentID: "a1", Mode: "whisper", Text: "hi"},
+		{AgentID: "a1", Mode: "whisper", Text: "hi", ToAgentID: "a1"},
+		{AgentID: "a1", Mode: "broadcast", Text: "hi"},
+	}
+	for _, req := range cases {
+		if _, err := uc.Execute(context.Background(), req); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("expected ErrInvalidRequest for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestUseCase_BroadcastReachesEveryLivingAgentOnce(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc, repo, events := newMessageUseCase(map[string]survival.AgentStateAggregate{
+		"a1": {AgentID: "a1"},
+		"a2": {AgentID: "a2", Position: survival.Position{X: 500, Y: -500}},
+		"a3": {AgentID: "a3", Dead: true},
+	}, &now)
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "a1", Mode: "broadcast", Channel: "camp", Text: "hello"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(resp.DeliveredTo) != 1 || resp.DeliveredTo[0] != "a2" {
+		t.Fatalf("expected delivery to living agents only, got %v", resp.DeliveredTo)
+	}
+	if len(repo.rows) != 1 || repo.rows[0].ToAgentID != "" {
+		t.Fatalf("expected a single undirected row for inboxes, got %+v", repo.rows)
+	}
+	if got := events.byAgent["a2"]; len(got) != 1 || got[0].Type != "message_received" || got[0].Payload["channel"] != "camp" {
+		t.Fatalf("expected message_received in recipient stream, got %+v", got)
+	}
+	if _, ok := events.byAgent["a3"]; ok {
+		t.Fatalf("expected dead agent to hear nothing")
+	}
+	if len(repo.locks) != 1 || repo.locks[0] != "a1" {
+		t.Fatalf("expected sender locked before counting, got %v", repo.locks)
+	}
+}
@@ -14,6 +14,9 @@ type Response struct {
 	Snapshot           world.Snapshot               `json:"snapshot"`
 	WorldTimeSeconds   int64                        `json:"world_time_seconds"`
 	TimeOfDay          string                       `json:"time_of_day"`
+	Day                int64                        `json:"day"`
+	Season             string                       `json:"season"`
+	Weather            string                       `json:"weather"`
 	NextPhaseInSeconds int                          `json:"next_phase_in_seconds"`
 	HPDrainFeedback    HPDrainFeedback              `json:"hp_drain_feedback"`
 	View               View                         `json:"view"`
@@ -24,6 +27,16 @@ type Response struct {
 	Resources          []ObservedResource           `json:"resources"`
 	Threats            []ObservedThreat             `json:"threats"`
 	LocalThreatLevel   int                          `json:"local_threat_level"`
+	Inbox              []InboxMessage               `json:"inbox"`
+}
+
+type InboxMessage struct {
+	MessageID   string `json:"message_id"`
+	FromAgentID string `json:"from_agent_id"`
+	Mode        string `json:"mode"`
+	Channel     string `json:"channel,omitempty"`
+	Text        string `json:"text"`
+	SentAt      int64  `json:"sent_at"`
 }
 
 type View struct {
@@ -38,23 +51,54 @@ type WorldMeta struct {
 }
 
 type Rules struct {
-	StandardTickMinutes int                       `json:"standard_tick_minutes"`
-	DrainsPer30m        DrainsPer30m              `json:"drains_per_30m"`
-	Thresholds          Thresholds                `json:"thresholds"`
-	Visibility          Visibility                `json:"visibility"`
-	Farming             Farming                   `json:"farming"`
-	Seed                Seed                      `json:"seed"`
-	ProductionRecipes   []ProductionRecipe        `json:"production_recipes"`
-	BuildCosts          map[string]map[string]int `json:"build_costs"`
-	FoodRecoveries      map[string]int            `json:"food_recoveries"`
+	StandardTickMinutes int                               `json:"standard_tick_minutes"`
+	DrainsPer30m        DrainsPer30m                      `json:"drains_per_30m"`
+	Thresholds          Thresholds                        `json:"thresholds"`
+	Visibility          Visibility                        `json:"visibility"`
+	Farming             Farming                           `json:"farming"`
+	Seed                Seed                              `json:"seed"`
+	ProductionRecipes   []ProductionRecipe                `json:"production_recipes"`
+	BuildCosts          map[string]map[string]int         `json:"build_costs"`
+	FoodRecoveries      map[string]int                    `json:"food_recoveries"`
+	ToolDurability      map[string]int                    `json:"tool_durability"`
+	WeatherEffects      map[string]survival.WeatherEffect `json:"weather_effects"`
+	SeasonFarmGrow      map[string]int                    `json:"season_farm_grow_percent"`
+	Temperature         survival.TemperatureModel         `json:"temperature"`
+	Durability          Durability                        `json:"durability"`
+	Furnace             Furnace                           `json:"furnace"`
+	Spoilage            Spoilage                          `json:"spoilage"`
+	Gear                map[string]survival.GearRule      `json:"gear"`
+}
+
+type Spoilage struct {
+	ShelfLifeMinutes     map[string]int `json:"shelf_life_minutes"`
+	StalePercent         int            `json:"stale_percent"`
+	StaleRecoveryPercent int            `json:"stale_recovery_percent"`
+	StoredSpoilPercent   int            `json:"stored_spoil_percent"`
+	SpoilsInto           string         `json:"spoils_into"`
+}
+
+type Furnace struct {
+	FuelMinutes   map[string]int `json:"fuel_minutes"`
+	RecipeMinutes map[int]int    `json:"recipe_minutes"`
+	QueueLimit    int            `json:"queue_limit"`
+}
+
+type Durability struct {
+	MaxHP                     int                       `json:"max_hp"`
+	DecayPerHour              map[string]int            `json:"decay_per_hour"`
+	ThreatObjectDamagePerHour int                       `json:"threat_damage_per_hour"`
+	RepairCosts               map[string]map[string]int `json:"repair_costs"`
 }
 
 type DrainsPer30m struct {
 	HungerDrain            int     `json:"hunger_drain"`
 	EnergyDrain            int     `json:"energy_drain"`
+	ThirstDrain            int     `json:"thirst_drain"`
 	HPDrainModel           string  `json:"hp_drain_model"`
 	HPDrainFromHungerCoeff float64 `json:"hp_drain_from_hunger_coeff"`
 	HPDrainFromEnergyCoeff float64 `json:"hp_drain_from_energy_coeff"`
+	HPDrainFromThirstCoeff float64 `json:"hp_drain_from_thirst_coeff"`
 	HPDrainCap             int     `json:"hp_drain_cap"`
 }
 
@@ -70,9 +114,12 @@ type Visibility struct {
 }
 
 type Farming struct {
-	FarmGrowMinutes  int     `json:"farm_grow_minutes"`
-	WheatYieldRange  []int   `json:"wheat_yield_range"`
-	SeedReturnChance float64 `json:"seed_return_chance"`
+	FarmGrowMinutes       int                          `json:"farm_grow_minutes"`
+	SeedReturnChance      float64                      `json:"seed_return_chance"`
+	WaterBoostMinutes     int                          `json:"water_boost_minutes"`
+	WaterGrowBonusPercent int                          `json:"water_grow_bonus_percent"`
+	WitherMinutes         int                          `json:"wither_minutes"`
+	Crops                 map[string]survival.CropRule `json:"crops"`
 }
 
 type Seed struct {
@@ -90,6 +137,7 @@ type ProductionRecipe struct {
 type ActionCost struct {
 	DeltaHunger  int                          `json:"delta_hunger"`
 	DeltaEnergy  int                          `json:"delta_energy"`
+	DeltaThirst  int                          `json:"delta_thirst"`
 	DeltaHP      int                          `json:"delta_hp,omitempty"`
 	Requirements []string                     `json:"requirements"`
 	Variants     map[string]ActionCostVariant `json:"variants,omitempty"`
@@ -106,6 +154,7 @@ type HPDrainFeedback struct {
 	EstimatedLossPer30 int      `json:"estimated_loss_per_30m"`
 	HungerComponent    int      `json:"hunger_component"`
 	EnergyComponent    int      `json:"energy_component"`
+	ThirstComponent    int      `json:"thirst_component"`
 	CapPer30           int      `json:"cap_per_30m"`
 	Causes             []string `json:"causes"`
 }
@@ -116,18 +165,25 @@ type ObservedTile struct {
 	IsWalkable   bool        `json:"is_walkable"`
 	IsLit        bool        `json:"is_lit"`
 	IsVisible    bool        `json:"is_visible"`
+	IsSheltered  bool        `json:"is_sheltered,omitempty"`
 	ResourceType string      `json:"-"`
 	BaseThreat   int         `json:"-"`
 }
 
 type ObservedObject struct {
-	ID            string      `json:"id"`
-	Type          string      `json:"type"`
-	Quality       string      `json:"quality,omitempty"`
-	Pos           world.Point `json:"pos"`
-	CapacitySlots int         `json:"capacity_slots,omitempty"`
-	UsedSlots     int         `json:"used_slots,omitempty"`
-	State         string      `json:"state,omitempty"`
+	ID             string         `json:"id"`
+	Type           string         `json:"type"`
+	Quality        string         `json:"quality,omitempty"`
+	Pos            world.Point    `json:"pos"`
+	HP             int            `json:"hp,omitempty"`
+	CapacitySlots  int            `json:"capacity_slots,omitempty"`
+	UsedSlots      int            `json:"used_slots,omitempty"`
+	State          string         `json:"state,omitempty"`
+	Crop           string         `json:"crop,omitempty"`
+	ReadyInSeconds int            `json:"ready_in_seconds,omitempty"`
+	FuelMinutes    int            `json:"fuel_minutes,omitempty"`
+	QueuedJobs     int            `json:"queued_jobs,omitempty"`
+	Output         map[string]int `json:"output,omitempty"`
 }
 
 type ObservedResource struct {
@@ -142,4 +198,5 @@ type ObservedThreat struct {
 	Type        string      `json:"type"`
 	Pos         world.Point `json:"pos"`
 	DangerScore int         `json:"danger_score"`
+	HP          int         `json:"hp,omitempty"`
 }
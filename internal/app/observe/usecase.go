@@ -10,8 +10,14 @@ import (
 
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/shared/cooldown"
+	"clawvival/internal/app/shared/farmstate"
+	"clawvival/internal/app/shared/furnacestate"
+	"clawvival/internal/app/shared/lighting"
+	"clawvival/internal/app/shared/objectwear"
 	"clawvival/internal/app/shared/resourcestate"
 	"clawvival/internal/app/shared/stateview"
+	"clawvival/internal/app/shared/structures"
+	"clawvival/internal/app/shared/threats"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -22,6 +28,8 @@ const (
 	fixedViewRadius   = 5
 	fixedViewSize     = fixedViewRadius*2 + 1
 	nightVisionRadius = survival.VisionRadiusNight
+	inboxWindow       = time.Hour
+	inboxLimit        = 20
 )
 
 type UseCase struct {
@@ -29,6 +37,8 @@ type UseCase struct {
 	ObjectRepo   ports.WorldObjectRepository
 	EventRepo    ports.EventRepository
 	ResourceRepo ports.AgentResourceNodeRepository
+	MessageRepo  ports.MessageRepository
+	MapRepo      ports.AgentMapRepository
 	World        ports.WorldProvider
 	Settle       survival.SettlementService
 	Now          func() time.Time
@@ -51,7 +61,7 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 	if err != nil {
 		return Response{}, err
 	}
-	state.SessionID = "session-" + req.AgentID
+	state.SessionID = state.ActiveSessionID()
 	snapshot, err := u.World.SnapshotForAgent(ctx, req.AgentID, world.Point{X: state.Position.X, Y: state.Position.Y})
 	if err != nil {
 		return Response{}, err
@@ -68,27 +78,52 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 		}
 	}
 	applyDepletedResourcesToSnapshot(&snapshot, depleted)
-	state = stateview.Enrich(state, snapshot.TimeOfDay, isCurrentTileLit(snapshot.TimeOfDay))
-	state.CurrentZone = stateview.CurrentZoneAtPosition(state.Position, snapshot.VisibleTiles)
-	state.ActionCooldowns = cooldown.RemainingByAction(events, nowFn())
-	tiles := buildWindowTiles(world.Point{X: state.Position.X, Y: state.Position.Y}, snapshot.TimeOfDay, snapshot.VisibleTiles)
-	objects := []ObservedObject{}
+	var rows []ports.WorldObjectRecord
 	if u.ObjectRepo != nil {
-		rows, err := u.ObjectRepo.ListByAgentID(ctx, req.AgentID)
+		minX, minY, maxX, maxY := structures.Bounds(snapshot.Center, max(fixedViewRadius, snapshot.ViewRadius))
+		rows, err = u.ObjectRepo.ListInBounds(ctx, req.AgentID, minX, minY, maxX, maxY)
 		if err != nil {
 			return Response{}, err
 		}
-		objects = projectObjects(tiles, rows)
+		// Wear is only persisted by actions; observe reports it as of now.
+		rows = objectwear.Apply(rows, snapshot, nowAt, survival.StandardTickMinutes).Live
+	}
+	lit := lighting.Compute(snapshot.TimeOfDay, rows, nowAt)
+	layout := structures.Build(rows)
+	snapshot.VisibleTiles = layout.ApplyPassability(snapshot.VisibleTiles, req.AgentID)
+	sheltered := layout.IsSheltered(state.Position.X, state.Position.Y)
+	state = stateview.Enrich(state, snapshot.TimeOfDay, lit.IsLit(state.Position.X, state.Position.Y))
+	state = stateview.MarkSheltered(state, sheltered)
+	state = stateview.MarkTemperature(u.Settle.RuleSet(), state, snapshot, lit.IsWarm(state.Position.X, state.Position.Y), sheltered)
+	state.CurrentZone = stateview.CurrentZoneAtPosition(state.Position, snapshot.VisibleTiles)
+	state.ActionCooldowns = cooldown.RemainingByAction(u.Settle.RuleSet(), events, nowFn())
+	state = stateview.MarkTravelProgress(state, nowAt)
+	tiles := buildWindowTiles(world.Point{X: state.Position.X, Y: state.Position.Y}, snapshot.TimeOfDay, snapshot.Weather, snapshot.VisibleTiles, lit, layout)
+	objects := []ObservedObject{}
+	if u.ObjectRepo != nil {
+		objects = projectObjects(tiles, rows, nowAt, u.Settle.RuleSet())
 	}
 	resources := projectResources(tiles, depleted)
 	snapshot.NearbyResource = summarizeNearby(resources)
+	if u.MapRepo != nil {
+		if err := u.MapRepo.Upsert(ctx, mapSightings(req.AgentID, state.SessionID, tiles, objects, resources, nowAt)); err != nil {
+			return Response{}, err
+		}
+	}
+	inbox, err := u.loadInbox(ctx, req.AgentID, nowAt)
+	if err != nil {
+		return Response{}, err
+	}
 	return Response{
 		State:              state,
 		Snapshot:           snapshot,
 		WorldTimeSeconds:   snapshot.WorldTimeSeconds,
 		TimeOfDay:          snapshot.TimeOfDay,
+		Day:                snapshot.Day,
+		Season:             snapshot.Season,
+		Weather:            snapshot.Weather,
 		NextPhaseInSeconds: snapshot.NextPhaseInSeconds,
-		HPDrainFeedback:    toHPDrainFeedback(stateview.EstimateHPDrain(state.Vitals, survival.StandardTickMinutes)),
+		HPDrainFeedback:    toHPDrainFeedback(stateview.EstimateHPDrain(u.Settle.RuleSet(), state.Vitals, survival.StandardTickMinutes)),
 		View: View{
 			Width:  fixedViewSize,
 			Height: fixedViewSize,
@@ -96,27 +131,65 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 			Radius: fixedViewRadius,
 		},
 		World: WorldMeta{
-			Rules: defaultRules(),
+			Rules: defaultRules(u.Settle.RuleSet()),
 		},
-		ActionCosts:      defaultActionCosts(),
+		ActionCosts:      defaultActionCosts(u.Settle.RuleSet()),
 		Tiles:            tiles,
 		Objects:          objects,
 		Resources:        resources,
-		Threats:          projectThreats(tiles),
-		LocalThreatLevel: snapshot.ThreatLevel,
+		Threats:          projectThreats(tiles, snapshot.Creatures),
+		LocalThreatLevel: structures.ReduceThreat(snapshot.ThreatLevel, sheltered),
+		Inbox:            inbox,
 	}, nil
 }
 
+func (u UseCase) loadInbox(ctx context.Context, agentID string, nowAt time.Time) ([]InboxMessage, error) {
+	out := []InboxMessage{}
+	if u.MessageRepo == nil {
+		return out, nil
+	}
+	rows, err := u.MessageRepo.ListInbox(ctx, agentID, nowAt.Add(-inboxWindow), inboxLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out = append(out, InboxMessage{
+			MessageID:   row.MessageID,
+			FromAgentID: row.FromAgentID,
+			Mode:        row.Mode,
+			Channel:     row.Channel,
+			Text:        row.Text,
+			SentAt:      row.CreatedAt.Unix(),
+		})
+	}
+	return out, nil
+}
+
 func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state survival.AgentStateAggregate, nowAt time.Time) (survival.AgentStateAggregate, error) {
 	if state.Dead {
 		return state, nil
 	}
 
-	ongoing := state.OngoingAction
-	if ongoing != nil {
-		if nowAt.Before(ongoing.EndAt) {
-			return state, nil
+	if state.OngoingAction != nil {
+		advanced, moved, err := stateview.AdvanceTravel(ctx, u.ObjectRepo, agentID, state, nowAt)
+		if err != nil {
+			return survival.AgentStateAggregate{}, err
+		}
+		if nowAt.Before(advanced.OngoingAction.EndAt) {
+			if !moved {
+				return state, nil
+			}
+			// Save the steps taken so threats and other agents see where the
+			// traveller is now.
+			advanced.Version++
+			advanced.UpdatedAt = nowAt
+			if err := u.StateRepo.SaveWithVersion(ctx, advanced, state.Version); err != nil {
+				return survival.AgentStateAggregate{}, err
+			}
+			return advanced, nil
 		}
+		state = advanced
+		ongoing := state.OngoingAction
 
 		startAt := ongoing.EndAt.Add(-time.Duration(ongoing.Minutes) * time.Minute)
 		deltaMinutes := int(nowAt.Sub(startAt).Minutes())
@@ -131,6 +204,17 @@ func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state
 		if err != nil {
 			return survival.AgentStateAggregate{}, err
 		}
+		var rows []ports.WorldObjectRecord
+		if u.ObjectRepo != nil {
+			minX, minY, maxX, maxY := structures.Bounds(world.Point{X: state.Position.X, Y: state.Position.Y}, 0)
+			rows, err = u.ObjectRepo.ListInBounds(ctx, agentID, minX, minY, maxX, maxY)
+			if err != nil {
+				return survival.AgentStateAggregate{}, err
+			}
+		}
+		layout := structures.Build(rows)
+		sheltered := layout.IsSheltered(state.Position.X, state.Position.Y)
+		nearHeat := lighting.Compute(snapshot.TimeOfDay, rows, nowAt).IsWarm(state.Position.X, state.Position.Y)
 
 		result := survival.SettlementResult{
 			UpdatedState: state,
@@ -138,11 +222,7 @@ func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state
 			ResultCode:   survival.ResultOK,
 		}
 		if deltaMinutes > 0 {
-			intent := survival.ActionIntent{Type: ongoing.Type}
-			if ongoing.Type == survival.ActionSleep {
-				intent.BedID = ongoing.BedID
-				intent.BedQuality = ongoing.Quality
-			}
+			intent := ongoing.SettleIntent(deltaMinutes)
 			worldTimeBefore := snapshot.WorldTimeSeconds - int64(deltaMinutes*60)
 			if worldTimeBefore < 0 {
 				worldTimeBefore = 0
@@ -154,10 +234,17 @@ func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state
 				nowAt,
 				survival.WorldSnapshot{
 					TimeOfDay:         snapshot.TimeOfDay,
-					ThreatLevel:       snapshot.ThreatLevel,
+					ThreatLevel:       structures.ReduceThreat(snapshot.ThreatLevel, sheltered),
 					VisibilityPenalty: snapshot.VisibilityPenalty,
 					NearbyResource:    snapshot.NearbyResource,
+					Threats:           threats.Contacts(snapshot.Creatures),
+					Sheltered:         sheltered,
+					Season:            snapshot.Season,
+					Weather:           snapshot.Weather,
+					Biome:             stateview.CurrentBiomeAtPosition(state.Position, snapshot.VisibleTiles),
+					NearHeat:          nearHeat,
 					WorldTimeSeconds:  worldTimeBefore,
+					Blocked:           stateview.BlockedTiles(layout.ApplyPassability(snapshot.VisibleTiles, agentID)),
 				},
 			)
 			if err != nil {
@@ -167,7 +254,7 @@ func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state
 		result.UpdatedState.OngoingAction = nil
 		result.UpdatedState.UpdatedAt = nowAt
 
-		sessionID := "session-" + agentID
+		sessionID := state.ActiveSessionID()
 		for i := range result.Events {
 			if result.Events[i].Payload == nil {
 				result.Events[i].Payload = map[string]any{}
@@ -200,8 +287,8 @@ func (u UseCase) settleBeforeObserve(ctx context.Context, agentID string, state
 	return state, nil
 }
 
-func defaultActionCosts() map[string]ActionCost {
-	profiles := survival.DefaultActionCostProfiles()
+func defaultActionCosts(rules *survival.RuleSet) map[string]ActionCost {
+	profiles := rules.ActionCostProfiles()
 	out := make(map[string]ActionCost, len(profiles))
 	for action, profile := range profiles {
 		variants := map[string]ActionCostVariant{}
@@ -219,7 +306,7 @@ func defaultActionCosts() map[string]ActionCost {
 			if variants == nil {
 				variants = map[string]ActionCostVariant{}
 			}
-			for itemType, hunger := range survival.FoodRecoveryRules() {
+			for itemType, hunger := range rules.FoodRecoveryRules() {
 				variants[itemType] = ActionCostVariant{
 					DeltaHunger: hunger,
 					DeltaEnergy: 0,
@@ -229,6 +316,7 @@ func defaultActionCosts() map[string]ActionCost {
 		out[string(action)] = ActionCost{
 			DeltaHunger:  profile.DeltaHunger,
 			DeltaEnergy:  profile.DeltaEnergy,
+			DeltaThirst:  profile.DeltaThirst,
 			DeltaHP:      profile.DeltaHP,
 			Requirements: append([]string(nil), profile.Requirements...),
 			Variants:     variants,
@@ -243,21 +331,24 @@ func toHPDrainFeedback(in stateview.HPDrainEstimate) HPDrainFeedback {
 		EstimatedLossPer30: in.EstimatedLoss,
 		HungerComponent:    in.HungerComponent,
 		EnergyComponent:    in.EnergyComponent,
+		ThirstComponent:    in.ThirstComponent,
 		CapPer30:           in.Cap,
 		Causes:             in.Causes,
 	}
 }
 
-func defaultRules() Rules {
+func defaultRules(rules *survival.RuleSet) Rules {
 	return Rules{
 		StandardTickMinutes: survival.StandardTickMinutes,
 		DrainsPer30m: DrainsPer30m{
-			HungerDrain:            survival.BaseHungerDrainPer30,
+			HungerDrain:            rules.Drains.BaseHungerPer30,
 			EnergyDrain:            0,
+			ThirstDrain:            rules.Drains.BaseThirstPer30,
 			HPDrainModel:           "dynamic_capped",
-			HPDrainFromHungerCoeff: survival.HPDrainFromHungerCoeff,
-			HPDrainFromEnergyCoeff: survival.HPDrainFromEnergyCoeff,
-			HPDrainCap:             survival.HPDrainCapPer30,
+			HPDrainFromHungerCoeff: rules.Drains.HPFromHungerCoeff,
+			HPDrainFromEnergyCoeff: rules.Drains.HPFromEnergyCoeff,
+			HPDrainFromThirstCoeff: rules.Drains.HPFromThirstCoeff,
+			HPDrainCap:             rules.Drains.HPCapPer30,
 		},
 		Thresholds: Thresholds{
 			CriticalHP: survival.CriticalHPThreshold,
@@ -269,17 +360,43 @@ func defaultRules() Rules {
 			TorchLightRadius:  survival.TorchLightRadius,
 		},
 		Farming: Farming{
-			FarmGrowMinutes:  survival.DefaultFarmGrowMinutes,
-			WheatYieldRange:  []int{survival.WheatYieldMin, survival.WheatYieldMax},
-			SeedReturnChance: survival.SeedReturnChance,
+			FarmGrowMinutes:       rules.CropRuleFor(survival.CropWheat).GrowMinutes,
+			SeedReturnChance:      survival.SeedReturnChance,
+			WaterBoostMinutes:     rules.Farming.WaterBoostMinutes,
+			WaterGrowBonusPercent: rules.Farming.WaterGrowBonusPercent,
+			WitherMinutes:         rules.Farming.WitherMinutes,
+			Crops:                 rules.CropRules(),
 		},
 		Seed: Seed{
 			SeedDropChance:   survival.SeedDropChance,
 			SeedPityMaxFails: survival.SeedPityMaxFails,
 		},
-		ProductionRecipes: toProductionRecipes(survival.ProductionRecipeRules()),
-		BuildCosts:        cloneNestedIntMap(survival.BuildCostRules()),
-		FoodRecoveries:    cloneIntMap(survival.FoodRecoveryRules()),
+		ProductionRecipes: toProductionRecipes(rules.ProductionRecipeRules()),
+		BuildCosts:        cloneNestedIntMap(rules.BuildCostRules()),
+		FoodRecoveries:    cloneIntMap(rules.FoodRecoveryRules()),
+		ToolDurability:    survival.ToolDurabilityRules(),
+		WeatherEffects:    survival.WeatherEffectRules(),
+		SeasonFarmGrow:    survival.SeasonFarmGrowRules(),
+		Temperature:       rules.TemperatureRules(),
+		Durability: Durability{
+			MaxHP:                     survival.ObjectMaxHP,
+			DecayPerHour:              survival.ObjectDecayRules(),
+			ThreatObjectDamagePerHour: survival.ThreatObjectDamagePerHour,
+			RepairCosts:               rules.RepairCostRules(),
+		},
+		Furnace: Furnace{
+			FuelMinutes:   rules.FurnaceFuelRules(),
+			RecipeMinutes: rules.FurnaceRecipeRules(),
+			QueueLimit:    survival.FurnaceQueueLimit,
+		},
+		Spoilage: Spoilage{
+			ShelfLifeMinutes:     survival.FoodShelfLifeRules(),
+			StalePercent:         survival.FoodStalePercent,
+			StaleRecoveryPercent: survival.FoodStaleRecoveryPercent,
+			StoredSpoilPercent:   survival.StoredFoodSpoilPercent,
+			SpoilsInto:           survival.ItemRottenFood,
+		},
+		Gear: survival.GearRules(),
 	}
 }
 
@@ -355,6 +472,40 @@ func projectResources(tiles []ObservedTile, depleted map[string]int) []ObservedR
 	return out
 }
 
+// mapSightings records every visible tile in the agent's map memory, clearing
+// resources and objects that are no longer there.
+func mapSightings(agentID, sessionID string, tiles []ObservedTile, objects []ObservedObject, resources []ObservedResource, nowAt time.Time) []ports.AgentMapTileRecord {
+	resourceAt := make(map[string]string, len(resources))
+	for _, res := range resources {
+		resourceAt[posKey(res.Pos.X, res.Pos.Y)] = res.Type
+	}
+	objectAt := make(map[string]ObservedObject, len(objects))
+	for _, obj := range objects {
+		objectAt[posKey(obj.Pos.X, obj.Pos.Y)] = obj
+	}
+	out := make([]ports.AgentMapTileRecord, 0, len(tiles))
+	for _, t := range tiles {
+		if !t.IsVisible {
+			continue
+		}
+		key := posKey(t.Pos.X, t.Pos.Y)
+		obj := objectAt[key]
+		out = append(out, ports.AgentMapTileRecord{
+			AgentID:      agentID,
+			SessionID:    sessionID,
+			X:            t.Pos.X,
+			Y:            t.Pos.Y,
+			TerrainType:  t.TerrainType,
+			IsWalkable:   t.IsWalkable,
+			ResourceType: resourceAt[key],
+			ObjectID:     obj.ID,
+			ObjectType:   obj.Type,
+			LastSeenAt:   nowAt,
+		})
+	}
+	return out
+}
+
 func summarizeNearby(resources []ObservedResource) map[string]int {
 	out := map[string]int{}
 	for _, res := range resources {
@@ -405,8 +556,26 @@ func loadDepletedTargets(ctx context.Context, repo ports.AgentResourceNodeReposi
 	return out, nil
 }
 
-func projectThreats(tiles []ObservedTile) []ObservedThreat {
-	out := make([]ObservedThreat, 0, len(tiles))
+func projectThreats(tiles []ObservedTile, creatures []world.Creature) []ObservedThreat {
+	out := make([]ObservedThreat, 0, len(tiles)+len(creatures))
+	visible := map[string]bool{}
+	for _, t := range tiles {
+		if t.IsVisible {
+			visible[posKey(t.Pos.X, t.Pos.Y)] = true
+		}
+	}
+	for _, c := range creatures {
+		if !c.Alive() || !visible[posKey(c.Pos.X, c.Pos.Y)] {
+			continue
+		}
+		out = append(out, ObservedThreat{
+			ID:          c.ID,
+			Type:        string(c.Kind),
+			Pos:         c.Pos,
+			DangerScore: c.DangerScore(),
+			HP:          c.HP,
+		})
+	}
 	for _, t := range tiles {
 		if !t.IsVisible || t.BaseThreat <= 0 {
 			continue
@@ -421,7 +590,7 @@ func projectThreats(tiles []ObservedTile) []ObservedThreat {
 	return out
 }
 
-func projectObjects(tiles []ObservedTile, objects []ports.WorldObjectRecord) []ObservedObject {
+func projectObjects(tiles []ObservedTile, objects []ports.WorldObjectRecord, nowAt time.Time, rules *survival.RuleSet) []ObservedObject {
 	visible := map[string]bool{}
 	for _, t := range tiles {
 		if t.IsVisible {
@@ -438,10 +607,15 @@ func projectObjects(tiles []ObservedTile, objects []ports.WorldObjectRecord) []O
 			Type:          normalizeObjectType(obj),
 			Quality:       strings.ToUpper(strings.TrimSpace(obj.Quality)),
 			Pos:           world.Point{X: obj.X, Y: obj.Y},
+			HP:            obj.HP,
 			CapacitySlots: obj.CapacitySlots,
 			UsedSlots:     obj.UsedSlots,
 		}
-		if state := extractObjectState(obj); state != "" {
+		if entry.Type == "farm_plot" {
+			applyFarmState(&entry, obj, nowAt, rules)
+		} else if entry.Type == "furnace" {
+			applyFurnaceState(&entry, obj, nowAt, rules)
+		} else if state := extractObjectState(obj); state != "" {
 			entry.State = state
 		}
 		out = append(out, entry)
@@ -477,12 +651,45 @@ func extractObjectState(obj ports.WorldObjectRecord) string {
 	return strings.ToUpper(strings.TrimSpace(state))
 }
 
-func buildWindowTiles(center world.Point, timeOfDay string, visible []world.Tile) []ObservedTile {
-	isLit := timeOfDay == "day"
+// applyFarmState reports the plot as grown up to now rather than as last
+// persisted by a farm action.
+func applyFarmState(entry *ObservedObject, obj ports.WorldObjectRecord, nowAt time.Time, rules *survival.RuleSet) {
+	farm, err := farmstate.Parse(obj.ObjectState)
+	if err != nil {
+		return
+	}
+	farm = farmstate.Advance(farm, nowAt, rules)
+	entry.State = farm.State
+	if farm.State == farmstate.StateIdle {
+		return
+	}
+	entry.Crop = string(farm.CropType())
+	if farm.State == farmstate.StateGrowing && farm.ReadyAtUnix > nowAt.Unix() {
+		entry.ReadyInSeconds = int(farm.ReadyAtUnix - nowAt.Unix())
+	}
+}
+
+// applyFurnaceState reports queued jobs as smelted up to now.
+func applyFurnaceState(entry *ObservedObject, obj ports.WorldObjectRecord, nowAt time.Time, rules *survival.RuleSet) {
+	furnace, err := furnacestate.Parse(obj.ObjectState)
+	if err != nil {
+		return
+	}
+	furnace = furnacestate.Advance(furnace, nowAt, rules)
+	entry.State = furnace.Status()
+	entry.FuelMinutes = furnace.FuelMinutes
+	entry.QueuedJobs = len(furnace.Queue)
+	if len(furnace.Output) > 0 {
+		entry.Output = furnace.Output
+	}
+}
+
+func buildWindowTiles(center world.Point, timeOfDay, weather string, visible []world.Tile, lit lighting.Map, layout structures.Layout) []ObservedTile {
 	visionRadius := fixedViewRadius
-	if !isLit {
+	if timeOfDay != "day" {
 		visionRadius = nightVisionRadius
 	}
+	visionRadius = survival.VisionRadius(visionRadius, weather)
 	visibleByPos := make(map[string]world.Tile, len(visible))
 	for _, tile := range visible {
 		visibleByPos[posKey(tile.X, tile.Y)] = tile
@@ -503,13 +710,17 @@ func buildWindowTiles(center world.Point, timeOfDay string, visible []world.Tile
 				continue
 			}
 			dist := abs(x-center.X) + abs(y-center.Y)
-			isVisible := dist <= visionRadius
+			isLit := lit.IsLit(x, y)
+			// Lighting only extends sight after dark; by day IsLit is true
+			// everywhere and would void the vision radius.
+			isVisible := dist <= visionRadius || (timeOfDay == "night" && isLit)
 			out = append(out, ObservedTile{
 				Pos:          world.Point{X: x, Y: y},
 				TerrainType:  string(tile.Kind),
 				IsWalkable:   tile.Passable,
 				IsLit:        isLit,
 				IsVisible:    isVisible,
+				IsSheltered:  layout.IsSheltered(x, y),
 				ResourceType: tile.Resource,
 				BaseThreat:   tile.BaseThreat,
 			})
@@ -535,7 +746,3 @@ func abs(v int) int {
 	}
 	return v
 }
-
-func isCurrentTileLit(timeOfDay string) bool {
-	return strings.EqualFold(strings.TrimSpace(timeOfDay), "day")
-}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -62,22 +63,22 @@ func TestUseCase_BuildsFixedViewMetadata(t *testing.T) {
 	if resp.World.Rules.StandardTickMinutes != survival.StandardTickMinutes {
 		t.Fatalf("expected standard tick %d, got=%d", survival.StandardTickMinutes, resp.World.Rules.StandardTickMinutes)
 	}
-	if resp.World.Rules.DrainsPer30m.HungerDrain != survival.BaseHungerDrainPer30 || resp.World.Rules.DrainsPer30m.EnergyDrain != 0 {
+	if resp.World.Rules.DrainsPer30m.HungerDrain != 0 || resp.World.Rules.DrainsPer30m.EnergyDrain != 0 {
 		t.Fatalf("unexpected drains_per_30m: %+v", resp.World.Rules.DrainsPer30m)
 	}
 	if got := resp.ActionCosts["gather"]; got.DeltaHunger != -2 || got.DeltaEnergy != -6 {
 		t.Fatalf("gather action cost mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["sleep"]; got.DeltaHunger != 15 || got.DeltaEnergy != survival.SleepBaseEnergyRecovery || got.DeltaHP != survival.SleepBaseHPRecovery {
+	if got := resp.ActionCosts["sleep"]; got.DeltaHunger != 15 || got.DeltaEnergy != 35 || got.DeltaHP != 6 {
 		t.Fatalf("sleep action cost mismatch: %+v", got)
 	}
 	if got := resp.ActionCosts["sleep"].Variants["bed_quality_good"]; got.DeltaHunger != 20 || got.DeltaEnergy != 45 || got.DeltaHP != 10 {
 		t.Fatalf("sleep good-bed variant mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["eat"].Variants["berry"]; got.DeltaHunger != survival.FoodBerryHungerRecovery || got.DeltaEnergy != 0 {
+	if got := resp.ActionCosts["eat"].Variants["berry"]; got.DeltaHunger != 20 || got.DeltaEnergy != 0 {
 		t.Fatalf("eat berry variant mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["eat"].Variants["jam"]; got.DeltaHunger != survival.FoodJamHungerRecovery || got.DeltaEnergy != 0 {
+	if got := resp.ActionCosts["eat"].Variants["jam"]; got.DeltaHunger != 80 || got.DeltaEnergy != 0 {
 		t.Fatalf("eat jam variant mismatch: %+v", got)
 	}
 	if got, ok := resp.ActionCosts["terminate"]; !ok {
@@ -85,8 +86,8 @@ func TestUseCase_BuildsFixedViewMetadata(t *testing.T) {
 	} else if got.DeltaHunger != 0 || got.DeltaEnergy != 0 {
 		t.Fatalf("terminate action cost mismatch: %+v", got)
 	}
-	if got := resp.World.Rules.Farming.WheatYieldRange; len(got) != 2 || got[0] != survival.WheatYieldMin || got[1] != survival.WheatYieldMax {
-		t.Fatalf("expected wheat_yield_range [%d,%d], got=%v", survival.WheatYieldMin, survival.WheatYieldMax, got)
+	if got, want := resp.World.Rules.Farming.Crops["wheat"], survival.DefaultRuleSet().CropRuleFor(survival.CropWheat); got != want {
+		t.Fatalf("expected wheat crop rule %+v, got=%+v", want, got)
 	}
 	if got := resp.World.Rules.ProductionRecipes; len(got) < 4 {
 		t.Fatalf("expected production_recipes, got=%v", got)
@@ -121,7 +122,7 @@ func TestUseCase_BuildsFixedViewMetadata(t *testing.T) {
 			t.Fatalf("unexpected bed_good build cost: %+v", got["bed_good"])
 		}
 	}
-	if got := resp.World.Rules.FoodRecoveries; got["berry"] != survival.FoodBerryHungerRecovery || got["wheat"] != survival.FoodWheatHungerRecovery || got["bread"] != survival.FoodBreadHungerRecovery || got["jam"] != survival.FoodJamHungerRecovery {
+	if got := resp.World.Rules.FoodRecoveries; got["berry"] != 20 || got["wheat"] != 15 || got["bread"] != 30 || got["jam"] != 80 {
 		t.Fatalf("unexpected food recoveries: %+v", got)
 	}
 	b, err := json.Marshal(resp.ActionCosts["gather"])
@@ -202,6 +203,35 @@ func TestUseCase_ProjectsTilesResourcesAndThreats(t *testing.T) {
 	}
 }
 
+func TestUseCase_ProjectsVisibleCreaturesAsThreats(t *testing.T) {
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
+			AgentID:  "agent-1",
+			Position: survival.Position{X: 0, Y: 0},
+		}},
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay: "night",
+			VisibleTiles: []world.Tile{
+				{X: 0, Y: 0, Kind: world.TileGrass, Passable: true},
+				{X: 1, Y: 0, Kind: world.TileGrass, Passable: true},
+				{X: 5, Y: 0, Kind: world.TileGrass, Passable: true},
+			},
+			Creatures: []world.Creature{
+				{ID: "crt-near", Kind: world.CreatureWolf, Pos: world.Point{X: 1, Y: 0}, HP: 20, Damage: 4},
+				{ID: "crt-dark", Kind: world.CreatureWolf, Pos: world.Point{X: 5, Y: 0}, HP: 20, Damage: 4},
+			},
+		}},
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(resp.Threats) != 1 || resp.Threats[0].ID != "crt-near" || resp.Threats[0].Type != "wolf" || resp.Threats[0].HP != 20 {
+		t.Fatalf("expected only the visible wolf projected, got %+v", resp.Threats)
+	}
+}
+
 func TestUseCase_ProjectsVisibleObjectsOnly(t *testing.T) {
 	uc := UseCase{
 		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
@@ -232,6 +262,39 @@ func TestUseCase_ProjectsVisibleObjectsOnly(t *testing.T) {
 	}
 }
 
+func TestUseCase_ProjectsFarmGrowthUpToNow(t *testing.T) {
+	now := time.Unix(1700003600, 0)
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
+			AgentID:  "agent-1",
+			Position: survival.Position{X: 0, Y: 0},
+		}},
+		ObjectRepo: observeObjectRepo{objects: []ports.WorldObjectRecord{
+			{ObjectID: "farm-ready", ObjectType: "farm_plot", X: 0, Y: 0, ObjectState: `{"state":"GROWING","crop":"wheat","planted_at_unix":1700000000,"target_minutes":60}`},
+			{ObjectID: "farm-growing", ObjectType: "farm_plot", X: 0, Y: 0, ObjectState: `{"state":"GROWING","crop":"potato","planted_at_unix":1700000000,"target_minutes":150}`},
+		}},
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay:    "day",
+			VisibleTiles: []world.Tile{{X: 0, Y: 0, Kind: world.TileGrass, Passable: true}},
+		}},
+		Now: func() time.Time { return now },
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(resp.Objects) != 2 {
+		t.Fatalf("expected two farm objects, got %+v", resp.Objects)
+	}
+	if got := resp.Objects[0]; got.State != "READY" || got.Crop != "wheat" {
+		t.Fatalf("expected wheat plot ready by now, got %+v", got)
+	}
+	if got := resp.Objects[1]; got.State != "GROWING" || got.Crop != "potato" || got.ReadyInSeconds != 90*60 {
+		t.Fatalf("expected potato plot growing with 90m left, got %+v", got)
+	}
+}
+
 func TestUseCase_NightVisibilityRadiusMasksOuterTiles(t *testing.T) {
 	tiles := make([]world.Tile, 0, 121)
 	for y := -5; y <= 5; y++ {
@@ -276,6 +339,145 @@ func TestUseCase_NightVisibilityRadiusMasksOuterTiles(t *testing.T) {
 	}
 }
 
+func TestUseCase_DaylightKeepsWindowCornersOutsideVisionRadius(t *testing.T) {
+	tiles := make([]world.Tile, 0, 121)
+	for y := -5; y <= 5; y++ {
+		for x := -5; x <= 5; x++ {
+			tiles = append(tiles, world.Tile{X: x, Y: y, Kind: world.TileGrass, Passable: true})
+		}
+	}
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
+			AgentID:  "agent-1",
+			Position: survival.Position{X: 0, Y: 0},
+		}},
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay:    "day",
+			VisibleTiles: tiles,
+		}},
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	for _, tile := range resp.Tiles {
+		if tile.Pos.X == 5 && tile.Pos.Y == 5 && tile.IsVisible {
+			t.Fatalf("expected window corner outside day vision radius, got %+v", tile)
+		}
+		if tile.Pos.X == 5 && tile.Pos.Y == 0 && !tile.IsVisible {
+			t.Fatalf("expected tile at day vision radius visible, got %+v", tile)
+		}
+	}
+}
+
+func TestUseCase_TorchLightsTilesBeyondNightVisionRadius(t *testing.T) {
+	tiles := make([]world.Tile, 0, 121)
+	for y := -5; y <= 5; y++ {
+		for x := -5; x <= 5; x++ {
+			tiles = append(tiles, world.Tile{X: x, Y: y, Kind: world.TileGrass, Passable: true})
+		}
+	}
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
+			AgentID:  "agent-1",
+			Position: survival.Position{X: 0, Y: 0},
+		}},
+		ObjectRepo: observeObjectRepo{objects: []ports.WorldObjectRecord{
+			{ObjectID: "obj-torch", ObjectType: "torch", X: 5, Y: 5},
+		}},
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay:    "night",
+			VisibleTiles: tiles,
+		}},
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	for _, tile := range resp.Tiles {
+		if tile.Pos.X == 5 && tile.Pos.Y == 5 && (!tile.IsLit || !tile.IsVisible) {
+			t.Fatalf("expected torch tile lit and visible at night, got %+v", tile)
+		}
+		if tile.Pos.X == 0 && tile.Pos.Y == 0 && tile.IsLit {
+			t.Fatalf("expected agent tile outside torch radius to stay dark, got %+v", tile)
+		}
+	}
+	hasDark := false
+	for _, effect := range resp.State.StatusEffects {
+		if effect == "IN_DARK" {
+			hasDark = true
+		}
+	}
+	if !hasDark {
+		t.Fatalf("expected IN_DARK for unlit agent tile, got %v", resp.State.StatusEffects)
+	}
+}
+
+func TestUseCase_WallRingSheltersAgentAndLowersThreat(t *testing.T) {
+	tiles := make([]world.Tile, 0, 121)
+	for y := -5; y <= 5; y++ {
+		for x := -5; x <= 5; x++ {
+			tiles = append(tiles, world.Tile{X: x, Y: y, Kind: world.TileGrass, Passable: true})
+		}
+	}
+	ring := []ports.WorldObjectRecord{}
+	for y := -1; y <= 1; y++ {
+		for x := -1; x <= 1; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			objectType := "wall"
+			if x == 1 && y == 0 {
+				objectType = "door"
+			}
+			ring = append(ring, ports.WorldObjectRecord{ObjectID: fmt.Sprintf("obj-%d-%d", x, y), ObjectType: objectType, X: x, Y: y, OwnerAgentID: "agent-1"})
+		}
+	}
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{
+			AgentID:  "agent-1",
+			Position: survival.Position{X: 0, Y: 0},
+		}},
+		ObjectRepo: observeObjectRepo{objects: ring},
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay:    "day",
+			ThreatLevel:  3,
+			VisibleTiles: tiles,
+		}},
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if resp.LocalThreatLevel != 3-survival.ShelterThreatReduction {
+		t.Fatalf("expected sheltered threat level, got %d", resp.LocalThreatLevel)
+	}
+	for _, tile := range resp.Tiles {
+		switch {
+		case tile.Pos.X == 0 && tile.Pos.Y == 0 && !tile.IsSheltered:
+			t.Fatalf("expected ring interior sheltered, got %+v", tile)
+		case tile.Pos.X == -1 && tile.Pos.Y == 0 && tile.IsWalkable:
+			t.Fatalf("expected wall tile not walkable, got %+v", tile)
+		case tile.Pos.X == 1 && tile.Pos.Y == 0 && !tile.IsWalkable:
+			t.Fatalf("expected own door walkable, got %+v", tile)
+		case tile.Pos.X == 3 && tile.Pos.Y == 0 && tile.IsSheltered:
+			t.Fatalf("expected tile outside ring unsheltered, got %+v", tile)
+		}
+	}
+	hasSheltered := false
+	for _, effect := range resp.State.StatusEffects {
+		if effect == "SHELTERED" {
+			hasSheltered = true
+		}
+	}
+	if !hasSheltered {
+		t.Fatalf("expected SHELTERED status effect, got %v", resp.State.StatusEffects)
+	}
+}
+
 func TestUseCase_HidesDepletedGatherTargetAndUpdatesNearbySummary(t *testing.T) {
 	now := time.Unix(1700000000, 0)
 	uc := UseCase{
@@ -427,6 +629,41 @@ func TestUseCase_SettlesDueOngoingActionBeforeObserveProjection(t *testing.T) {
 	}
 }
 
+func TestUseCase_ObserveSavesTravelStepsTakenSoFar(t *testing.T) {
+	now := time.Unix(1700200000, 0)
+	path := []survival.Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}}
+	minutes := len(path) * survival.TravelStepMinutes
+	stateRepo := &observeStateRepo{state: survival.AgentStateAggregate{
+		AgentID:   "agent-1",
+		Vitals:    survival.Vitals{HP: 80, Hunger: 80, Energy: 60},
+		Inventory: map[string]int{},
+		Version:   3,
+		OngoingAction: &survival.OngoingActionInfo{
+			Type:    survival.ActionTravel,
+			Minutes: minutes,
+			EndAt:   now.Add(time.Duration(minutes-2*survival.TravelStepMinutes) * time.Minute),
+			Path:    path,
+		},
+	}}
+	uc := UseCase{
+		StateRepo: stateRepo,
+		World:     observeWorldProvider{snapshot: world.Snapshot{TimeOfDay: "day"}},
+		Settle:    survival.SettlementService{},
+		Now:       func() time.Time { return now },
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if resp.State.Position != path[1] || resp.State.OngoingAction == nil || resp.State.OngoingAction.StepsDone != 2 {
+		t.Fatalf("expected traveller two steps along, got pos=%+v ongoing=%+v", resp.State.Position, resp.State.OngoingAction)
+	}
+	if stateRepo.saveCalls != 1 || stateRepo.lastSaved.Position != path[1] || stateRepo.lastSaved.OngoingAction == nil {
+		t.Fatalf("expected travel progress saved mid-travel, got calls=%d saved=%+v", stateRepo.saveCalls, stateRepo.lastSaved)
+	}
+}
+
 func TestUseCase_OngoingSettleEventWorldTimeMatchesElapsedWindow(t *testing.T) {
 	now := time.Unix(1700200000, 0)
 	baseNow := now
@@ -574,6 +811,65 @@ func TestUseCase_DoesNotSettleIdleEvenAfterFullTick(t *testing.T) {
 	}
 }
 
+func TestUseCase_IncludesRecentInboxMessages(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	messages := &observeMessageRepo{rows: []ports.MessageRecord{
+		{MessageID: "msg-2", FromAgentID: "agent-2", Mode: "whisper", Text: "follow me", CreatedAt: now.Add(-time.Minute)},
+	}}
+	uc := UseCase{
+		StateRepo:   &observeStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1"}},
+		MessageRepo: messages,
+		World:       observeWorldProvider{snapshot: world.Snapshot{}},
+		Now:         func() time.Time { return now },
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(resp.Inbox) != 1 || resp.Inbox[0].FromAgentID != "agent-2" || resp.Inbox[0].Text != "follow me" {
+		t.Fatalf("unexpected inbox: %+v", resp.Inbox)
+	}
+	if got, want := messages.since, now.Add(-inboxWindow); !got.Equal(want) {
+		t.Fatalf("expected inbox window from %v, got %v", want, got)
+	}
+}
+
+func TestUseCase_RemembersVisibleTilesInSessionMap(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	maps := &observeMapRepo{}
+	uc := UseCase{
+		StateRepo: &observeStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1", SessionID: "session-1"}},
+		ObjectRepo: observeObjectRepo{objects: []ports.WorldObjectRecord{
+			{ObjectID: "obj-box", ObjectType: "box", X: 1, Y: 0},
+		}},
+		MapRepo: maps,
+		World: observeWorldProvider{snapshot: world.Snapshot{
+			TimeOfDay: "night",
+			VisibleTiles: []world.Tile{
+				{X: 0, Y: 0, Kind: world.TileTree, Resource: "wood"},
+				{X: 1, Y: 0, Kind: world.TileGrass, Passable: true},
+				{X: 5, Y: 0, Kind: world.TileRock, Resource: "stone"},
+			},
+		}},
+		Now: func() time.Time { return now },
+	}
+
+	if _, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"}); err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if len(maps.upserted) != 2 {
+		t.Fatalf("expected only the two tiles within night vision, got %+v", maps.upserted)
+	}
+	tree, grass := maps.upserted[0], maps.upserted[1]
+	if tree.SessionID != "session-1" || tree.TerrainType != "tree" || tree.ResourceType != "wood" || !tree.LastSeenAt.Equal(now) {
+		t.Fatalf("unexpected tree sighting: %+v", tree)
+	}
+	if !grass.IsWalkable || grass.ObjectID != "obj-box" || grass.ObjectType != "box" {
+		t.Fatalf("unexpected grass sighting: %+v", grass)
+	}
+}
+
 type observeStateRepo struct {
 	state               survival.AgentStateAggregate
 	err                 error
@@ -633,17 +929,31 @@ func (r observeObjectRepo) GetByObjectID(_ context.Context, _ string, _ string)
 	return ports.WorldObjectRecord{}, ports.ErrNotFound
 }
 
-func (r observeObjectRepo) ListByAgentID(_ context.Context, _ string) ([]ports.WorldObjectRecord, error) {
+func (r observeObjectRepo) ListInBounds(_ context.Context, _ string, minX, minY, maxX, maxY int) ([]ports.WorldObjectRecord, error) {
 	if r.err != nil {
 		return nil, r.err
 	}
-	return r.objects, nil
+	out := []ports.WorldObjectRecord{}
+	for _, obj := range r.objects {
+		if obj.X >= minX && obj.X <= maxX && obj.Y >= minY && obj.Y <= maxY {
+			out = append(out, obj)
+		}
+	}
+	return out, nil
 }
 
 func (r observeObjectRepo) Update(_ context.Context, _ string, _ ports.WorldObjectRecord) error {
 	return nil
 }
 
+func (r observeObjectRepo) UpdateWear(_ context.Context, _ string, _ ports.WorldObjectRecord, _ time.Time) error {
+	return nil
+}
+
+func (r observeObjectRepo) Delete(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
 func (r *observeEventRepo) Append(_ context.Context, agentID string, events []survival.DomainEvent) error {
 	if r.eventsByAgent == nil {
 		r.eventsByAgent = map[string][]survival.DomainEvent{}
@@ -673,6 +983,10 @@ func (r observeResourceRepo) Upsert(_ context.Context, _ ports.AgentResourceNode
 	return nil
 }
 
+func (r observeResourceRepo) Deplete(_ context.Context, _ ports.AgentResourceNodeRecord, _ time.Time) error {
+	return nil
+}
+
 func (r observeResourceRepo) GetByTargetID(_ context.Context, _ string, _ string) (ports.AgentResourceNodeRecord, error) {
 	return ports.AgentResourceNodeRecord{}, ports.ErrNotFound
 }
@@ -714,3 +1028,38 @@ var _ ports.WorldProvider = observeDynamicWorldProvider{}
 var _ ports.WorldObjectRepository = observeObjectRepo{}
 var _ ports.AgentResourceNodeRepository = observeResourceRepo{}
 var _ ports.EventRepository = &observeEventRepo{}
+
+type observeMessageRepo struct {
+	rows  []ports.MessageRecord
+	since time.Time
+}
+
+func (r *observeMessageRepo) Save(_ context.Context, _ []ports.MessageRecord) error {
+	return nil
+}
+
+func (r *observeMessageRepo) CountSentSince(_ context.Context, _ string, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *observeMessageRepo) ListInbox(_ context.Context, _ string, since time.Time, _ int) ([]ports.MessageRecord, error) {
+	r.since = since
+	return r.rows, nil
+}
+
+func (r *observeMessageRepo) LockSender(context.Context, string) error {
+	return nil
+}
+
+type observeMapRepo struct {
+	upserted []ports.AgentMapTileRecord
+}
+
+func (r *observeMapRepo) Upsert(_ context.Context, tiles []ports.AgentMapTileRecord) error {
+	r.upserted = append(r.upserted, tiles...)
+	return nil
+}
+
+func (r *observeMapRepo) ListInBounds(_ context.Context, _, _ string, _, _, _, _ int) ([]ports.AgentMapTileRecord, error) {
+	return r.upserted, nil
+}
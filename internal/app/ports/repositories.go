@@ -47,13 +47,30 @@ type WorldObjectRecord struct {
 	CapacitySlots int
 	UsedSlots     int
 	ObjectState   string
+	OwnerAgentID  string
+	// DecayCheckedAt is when wear was last applied to HP.
+	DecayCheckedAt time.Time
+	// WearCarry is partial wear not yet taken off HP, see survival.ObjectWearSince.
+	WearCarry int
+	// Version is bumped by every Update; an Update carrying a stale version
+	// fails with ErrConflict.
+	Version int64
 }
 
+// In shared world mode the read methods see every agent's objects, while
+// Update only touches objects the agent owns or shared boxes and Delete only
+// the agent's own. Update is checked against the record's Version so two
+// agents writing the same box cannot overwrite each other.
 type WorldObjectRepository interface {
 	Save(ctx context.Context, agentID string, obj WorldObjectRecord) error
 	GetByObjectID(ctx context.Context, agentID, objectID string) (WorldObjectRecord, error)
-	ListByAgentID(ctx context.Context, agentID string) ([]WorldObjectRecord, error)
+	// ListInBounds lists live objects within the inclusive box.
+	ListInBounds(ctx context.Context, agentID string, minX, minY, maxX, maxY int) ([]WorldObjectRecord, error)
 	Update(ctx context.Context, agentID string, obj WorldObjectRecord) error
+	// UpdateWear writes only hp, decay_checked_at and wear_carry, and only if
+	// the row was last checked at checkedAt, so wear is charged once.
+	UpdateWear(ctx context.Context, agentID string, obj WorldObjectRecord, checkedAt time.Time) error
+	Delete(ctx context.Context, agentID, objectID string) error
 }
 
 type AgentResourceNodeRecord struct {
@@ -67,13 +84,84 @@ type AgentResourceNodeRecord struct {
 
 type AgentResourceNodeRepository interface {
 	Upsert(ctx context.Context, record AgentResourceNodeRecord) error
+	// Deplete marks the node depleted unless it is still depleted at now,
+	// returning ErrConflict when another gather already claimed it.
+	Deplete(ctx context.Context, record AgentResourceNodeRecord, now time.Time) error
 	GetByTargetID(ctx context.Context, agentID, targetID string) (AgentResourceNodeRecord, error)
 	ListByAgentID(ctx context.Context, agentID string) ([]AgentResourceNodeRecord, error)
 }
 
+type TradeOfferRecord struct {
+	OfferID      string
+	FromAgentID  string
+	ToAgentID    string
+	OfferItems   map[string]int
+	RequestItems map[string]int
+	// OfferStacks and OfferTools keep the age and wear of the escrowed items.
+	OfferStacks map[string][]survival.FoodStack
+	OfferTools  map[string][]survival.ToolStack
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+type TradeOfferRepository interface {
+	Create(ctx context.Context, offer TradeOfferRecord) error
+	GetByOfferID(ctx context.Context, offerID string) (TradeOfferRecord, error)
+	// ListExpired lists the agent's open offers that expired at or before now.
+	ListExpired(ctx context.Context, fromAgentID string, now time.Time) ([]TradeOfferRecord, error)
+	// UpdateStatus moves an offer out of fromStatus and returns ErrConflict
+	// when another request already moved it.
+	UpdateStatus(ctx context.Context, offerID, fromStatus, toStatus string, at time.Time) error
+}
+
+type MessageRecord struct {
+	MessageID   string
+	FromAgentID string
+	// ToAgentID is empty on the row recording the send; for broadcasts that
+	// row is what every agent's inbox reads.
+	ToAgentID string
+	Mode      string
+	Channel   string
+	Text      string
+	X         int
+	Y         int
+	CreatedAt time.Time
+}
+
+type MessageRepository interface {
+	// Save stores the send plus one row per direct recipient, all under the
+	// same MessageID.
+	Save(ctx context.Context, messages []MessageRecord) error
+	// LockSender holds other sends by agentID until the transaction ends, so
+	// CountSentSince sees every message that could beat it to Save.
+	LockSender(ctx context.Context, agentID string) error
+	CountSentSince(ctx context.Context, agentID string, since time.Time) (int, error)
+	ListInbox(ctx context.Context, agentID string, since time.Time, limit int) ([]MessageRecord, error)
+}
+
+// AgentLocator finds living agents to deliver messages to.
+type AgentLocator interface {
+	// ListLivingNear returns living agents within Manhattan radius of center.
+	ListLivingNear(ctx context.Context, center survival.Position, radius int) ([]string, error)
+	ListLiving(ctx context.Context) ([]string, error)
+}
+
+type AgentSessionRecord struct {
+	SessionID  string
+	AgentID    string
+	StartTick  int64
+	Status     string
+	DeathCause string
+	StartedAt  time.Time
+	// EndedAt is zero while the session is alive.
+	EndedAt time.Time
+}
+
 type AgentSessionRepository interface {
 	EnsureActive(ctx context.Context, sessionID, agentID string, startTick int64) error
 	Close(ctx context.Context, sessionID string, cause survival.DeathCause, endedAt time.Time) error
+	ListByAgentID(ctx context.Context, agentID string) ([]AgentSessionRecord, error)
 }
 
 type AgentCredentialRecord struct {
@@ -88,3 +176,24 @@ type AgentCredentialRepository interface {
 	Create(ctx context.Context, credential AgentCredentialRecord) error
 	GetByAgentID(ctx context.Context, agentID string) (AgentCredentialRecord, error)
 }
+
+// AgentMapTileRecord is the last sighting of one tile within a session.
+type AgentMapTileRecord struct {
+	AgentID      string
+	SessionID    string
+	X            int
+	Y            int
+	TerrainType  string
+	IsWalkable   bool
+	ResourceType string
+	ObjectID     string
+	ObjectType   string
+	LastSeenAt   time.Time
+}
+
+type AgentMapRepository interface {
+	// Upsert replaces the previous sighting of each tile.
+	Upsert(ctx context.Context, tiles []AgentMapTileRecord) error
+	// ListInBounds returns remembered tiles with min <= x,y <= max.
+	ListInBounds(ctx context.Context, agentID, sessionID string, minX, minY, maxX, maxY int) ([]AgentMapTileRecord, error)
+}
@@ -9,3 +9,8 @@ import (
 type WorldProvider interface {
 	SnapshotForAgent(ctx context.Context, agentID string, center world.Point) (world.Snapshot, error)
 }
+
+type WorldCreatureRepository interface {
+	// Damage applies damage to the stored creature and returns its HP after.
+	Damage(ctx context.Context, creature world.Creature, damage int) (int, error)
+}
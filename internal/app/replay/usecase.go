@@ -102,6 +102,7 @@ func reconstruct(events []survival.DomainEvent) survival.AgentStateAggregate {
 		state.Vitals.HP = int(num(after["hp"]))
 		state.Vitals.Hunger = int(num(after["hunger"]))
 		state.Vitals.Energy = int(num(after["energy"]))
+		state.Vitals.Thirst = int(num(after["thirst"]))
 		state.Position.X = int(num(after["x"]))
 		state.Position.Y = int(num(after["y"]))
 		break
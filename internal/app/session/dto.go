@@ -0,0 +1,21 @@
+package session
+
+type Request struct {
+	AgentID string
+}
+
+type Response struct {
+	AgentID          string    `json:"agent_id"`
+	CurrentSessionID string    `json:"current_session_id"`
+	Sessions         []Session `json:"sessions"`
+}
+
+type Session struct {
+	SessionID       string `json:"session_id"`
+	Status          string `json:"status"`
+	StartTick       int64  `json:"start_tick"`
+	StartedAt       int64  `json:"started_at"`
+	EndedAt         int64  `json:"ended_at,omitempty"`
+	DeathCause      string `json:"death_cause,omitempty"`
+	SurvivalSeconds int64  `json:"survival_seconds"`
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"clawvival/internal/app/ports"
+)
+
+var ErrInvalidRequest = errors.New("invalid sessions request")
+
+type UseCase struct {
+	StateRepo ports.AgentStateRepository
+	Sessions  ports.AgentSessionRepository
+	Now       func() time.Time
+}
+
+func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
+	agentID := strings.TrimSpace(req.AgentID)
+	if agentID == "" || u.StateRepo == nil || u.Sessions == nil {
+		return Response{}, ErrInvalidRequest
+	}
+	state, err := u.StateRepo.GetByAgentID(ctx, agentID)
+	if err != nil {
+		return Response{}, err
+	}
+	rows, err := u.Sessions.ListByAgentID(ctx, agentID)
+	if err != nil {
+		return Response{}, err
+	}
+	nowFn := u.Now
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	now := nowFn()
+
+	sessions := make([]Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toSession(row, now))
+	}
+	return Response{
+		AgentID:          agentID,
+		CurrentSessionID: state.ActiveSessionID(),
+		Sessions:         sessions,
+	}, nil
+}
+
+// toSession measures survival up to now for sessions that are still alive.
+func toSession(row ports.AgentSessionRecord, now time.Time) Session {
+	out := Session{
+		SessionID:  row.SessionID,
+		Status:     row.Status,
+		StartTick:  row.StartTick,
+		StartedAt:  row.StartedAt.Unix(),
+		DeathCause: row.DeathCause,
+	}
+	end := now
+	if !row.EndedAt.IsZero() {
+		end = row.EndedAt
+		out.EndedAt = row.EndedAt.Unix()
+	}
+	if survived := end.Sub(row.StartedAt); survived > 0 {
+		out.SurvivalSeconds = int64(survived / time.Second)
+	}
+	return out
+}
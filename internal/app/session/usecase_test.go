@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+)
+
+type sessionStateRepo struct {
+	state survival.AgentStateAggregate
+}
+
+func (r sessionStateRepo) GetByAgentID(context.Context, string) (survival.AgentStateAggregate, error) {
+	return r.state, nil
+}
+
+func (r sessionStateRepo) SaveWithVersion(context.Context, survival.AgentStateAggregate, int64) error {
+	return nil
+}
+
+type sessionRepo struct {
+	rows []ports.AgentSessionRecord
+}
+
+func (r sessionRepo) EnsureActive(context.Context, string, string, int64) error {
+	return nil
+}
+
+func (r sessionRepo) Close(context.Context, string, survival.DeathCause, time.Time) error {
+	return nil
+}
+
+func (r sessionRepo) ListByAgentID(context.Context, string) ([]ports.AgentSessionRecord, error) {
+	return r.rows, nil
+}
+
+func TestUseCase_ListsSessionsWithSurvivalDuration(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	now := start.Add(3 * time.Hour)
+	uc := UseCase{
+		StateRepo: sessionStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1", SessionID: "session-agent-1-b"}},
+		Sessions: sessionRepo{rows: []ports.AgentSessionRecord{
+			{SessionID: "session-agent-1-b", AgentID: "agent-1", StartTick: 7, Status: "alive", StartedAt: start.Add(2 * time.Hour)},
+			{SessionID: "session-agent-1-a", AgentID: "agent-1", StartTick: 1, Status: "dead", DeathCause: "starvation", StartedAt: start, EndedAt: start.Add(90 * time.Minute)},
+		}},
+		Now: func() time.Time { return now },
+	}
+
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if resp.CurrentSessionID != "session-agent-1-b" || len(resp.Sessions) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if got := resp.Sessions[0]; got.SurvivalSeconds != 3600 || got.EndedAt != 0 {
+		t.Fatalf("expected alive session to count up to now, got %+v", got)
+	}
+	if got := resp.Sessions[1]; got.SurvivalSeconds != 5400 || got.EndedAt != start.Add(90*time.Minute).Unix() || got.DeathCause != "starvation" {
+		t.Fatalf("unexpected ended session: %+v", got)
+	}
+}
+
+func TestUseCase_RejectsEmptyAgentID(t *testing.T) {
+	uc := UseCase{StateRepo: sessionStateRepo{}, Sessions: sessionRepo{}}
+	if _, err := uc.Execute(context.Background(), Request{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected ErrInvalidRequest, got %v", err)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"clawvival/internal/domain/survival"
 )
 
-func RemainingForAction(events []survival.DomainEvent, intentType survival.ActionType, now time.Time) (int, bool) {
-	cooldown, ok := survival.ActionCooldownDurations[intentType]
+func RemainingForAction(rules *survival.RuleSet, events []survival.DomainEvent, intentType survival.ActionType, now time.Time) (int, bool) {
+	cooldown, ok := rules.Cooldown(intentType)
 	if !ok {
 		return 0, false
 	}
@@ -28,10 +28,10 @@ func RemainingForAction(events []survival.DomainEvent, intentType survival.Actio
 	return remainingSeconds, true
 }
 
-func RemainingByAction(events []survival.DomainEvent, now time.Time) map[string]int {
+func RemainingByAction(rules *survival.RuleSet, events []survival.DomainEvent, now time.Time) map[string]int {
 	out := map[string]int{}
-	for actionType := range survival.ActionCooldownDurations {
-		if remaining, ok := RemainingForAction(events, actionType, now); ok {
+	for actionType := range rules.Cooldowns {
+		if remaining, ok := RemainingForAction(rules, events, actionType, now); ok {
 			out[string(actionType)] = remaining
 		}
 	}
@@ -71,9 +71,9 @@ func eventForIntent(intentType survival.ActionType, occurredAt time.Time) surviv
 	}
 }
 
-func RemainingByActionWithCurrent(events []survival.DomainEvent, now time.Time, current survival.ActionType) map[string]int {
+func RemainingByActionWithCurrent(rules *survival.RuleSet, events []survival.DomainEvent, now time.Time, current survival.ActionType) map[string]int {
 	extended := make([]survival.DomainEvent, 0, len(events)+1)
 	extended = append(extended, events...)
 	extended = append(extended, eventForIntent(current, now))
-	return RemainingByAction(extended, now)
+	return RemainingByAction(rules, extended, now)
 }
@@ -0,0 +1,134 @@
+package farmstate
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"clawvival/internal/domain/survival"
+)
+
+const (
+	StateIdle     = "IDLE"
+	StateGrowing  = "GROWING"
+	StateReady    = "READY"
+	StateWithered = "WITHERED"
+)
+
+// State is the JSON object_state stored on a farm_plot world object.
+type State struct {
+	State            string `json:"state"`
+	Crop             string `json:"crop,omitempty"`
+	PlantedAtUnix    int64  `json:"planted_at_unix,omitempty"`
+	ReadyAtUnix      int64  `json:"ready_at_unix,omitempty"`
+	UpdatedAtUnix    int64  `json:"updated_at_unix,omitempty"`
+	TargetMinutes    int    `json:"target_minutes,omitempty"`
+	GrowthMinutes    int    `json:"growth_minutes,omitempty"`
+	WaterMinutes     int    `json:"water_minutes,omitempty"`
+	UnwateredMinutes int    `json:"unwatered_minutes,omitempty"`
+}
+
+func Parse(raw string) (State, error) {
+	out := State{State: StateIdle}
+	if strings.TrimSpace(raw) == "" {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return State{}, err
+	}
+	out.State = strings.ToUpper(strings.TrimSpace(out.State))
+	if out.State == "" {
+		out.State = StateIdle
+	}
+	return out, nil
+}
+
+func (s State) Encode() (string, error) {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// Planted starts a new crop on the plot at now.
+func Planted(crop survival.CropType, targetMinutes int, now time.Time, rules *survival.RuleSet) State {
+	plot := survival.FarmPlot{Crop: crop, TargetMinutes: targetMinutes}
+	return fromPlot(State{PlantedAtUnix: now.Unix()}, plot, now.Unix(), rules)
+}
+
+// Advance grows the planted crop up to now. Whole minutes are consumed so the
+// remainder carries over to the next call.
+func Advance(s State, now time.Time, rules *survival.RuleSet) State {
+	if s.State != StateGrowing && s.State != StateReady {
+		return s
+	}
+	plot := s.plot()
+	from := s.UpdatedAtUnix
+	if from == 0 {
+		from = s.PlantedAtUnix
+	}
+	minutes := int((now.Unix() - from) / 60)
+	if minutes <= 0 {
+		return s
+	}
+	rules.TickFarm(&plot, minutes)
+	return fromPlot(s, plot, from+int64(minutes)*60, rules)
+}
+
+// Water applies a watering boost; withered or empty plots cannot be watered.
+func Water(s State, rules *survival.RuleSet) (State, bool) {
+	if s.State != StateGrowing && s.State != StateReady {
+		return s, false
+	}
+	plot := s.plot()
+	if !rules.WaterFarm(&plot) {
+		return s, false
+	}
+	return fromPlot(s, plot, s.UpdatedAtUnix, rules), true
+}
+
+func (s State) CropType() survival.CropType {
+	crop, ok := survival.ParseCrop(s.Crop)
+	if !ok {
+		return survival.CropWheat
+	}
+	return crop
+}
+
+func (s State) plot() survival.FarmPlot {
+	target := s.TargetMinutes
+	if target <= 0 && s.ReadyAtUnix > s.PlantedAtUnix && s.PlantedAtUnix > 0 {
+		// Plots planted before growth tracking only stored the ready time.
+		target = int((s.ReadyAtUnix - s.PlantedAtUnix) / 60)
+	}
+	return survival.FarmPlot{
+		Crop:             s.CropType(),
+		GrowthMinutes:    s.GrowthMinutes,
+		TargetMinutes:    target,
+		WaterMinutes:     s.WaterMinutes,
+		UnwateredMinutes: s.UnwateredMinutes,
+		Ready:            s.State == StateReady,
+		Withered:         s.State == StateWithered,
+	}
+}
+
+func fromPlot(s State, plot survival.FarmPlot, atUnix int64, rules *survival.RuleSet) State {
+	s.Crop = string(plot.Crop)
+	s.TargetMinutes = plot.TargetMinutes
+	s.GrowthMinutes = plot.GrowthMinutes
+	s.WaterMinutes = plot.WaterMinutes
+	s.UnwateredMinutes = plot.UnwateredMinutes
+	s.UpdatedAtUnix = atUnix
+	switch {
+	case plot.Withered:
+		s.State = StateWithered
+		s.ReadyAtUnix = 0
+	case plot.Ready:
+		s.State = StateReady
+	default:
+		s.State = StateGrowing
+		s.ReadyAtUnix = atUnix + int64(rules.FarmMinutesUntilReady(plot))*60
+	}
+	return s
+}
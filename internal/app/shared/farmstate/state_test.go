@@ -0,0 +1,57 @@
+package farmstate
+
+import (
+	"testing"
+	"time"
+
+	"clawvival/internal/domain/survival"
+)
+
+func TestAdvance_GrowsToReadyAndCarriesRemainder(t *testing.T) {
+	rules := survival.DefaultRuleSet()
+	plantedAt := time.Unix(1700000000, 0)
+	s := Planted(survival.CropWheat, 60, plantedAt, rules)
+	if s.State != StateGrowing || s.ReadyAtUnix != plantedAt.Add(time.Hour).Unix() {
+		t.Fatalf("unexpected planted state: %+v", s)
+	}
+
+	s = Advance(s, plantedAt.Add(30*time.Minute+30*time.Second), rules)
+	if s.State != StateGrowing || s.GrowthMinutes != 30 {
+		t.Fatalf("expected 30 growth minutes, got %+v", s)
+	}
+	if s.UpdatedAtUnix != plantedAt.Add(30*time.Minute).Unix() {
+		t.Fatalf("expected partial minute carried over, got updated_at=%d", s.UpdatedAtUnix)
+	}
+
+	s = Advance(s, plantedAt.Add(time.Hour), rules)
+	if s.State != StateReady {
+		t.Fatalf("expected ready after grow time, got %+v", s)
+	}
+}
+
+func TestAdvance_LegacyReadyAtState(t *testing.T) {
+	rules := survival.DefaultRuleSet()
+	s, err := Parse(`{"state":"GROWING","planted_at_unix":1000,"ready_at_unix":4600}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := Advance(s, time.Unix(4599, 0), rules); got.State != StateGrowing {
+		t.Fatalf("expected legacy plot still growing, got %+v", got)
+	}
+	if got := Advance(s, time.Unix(4600, 0), rules); got.State != StateReady || got.Crop != string(survival.CropWheat) {
+		t.Fatalf("expected legacy plot ready as wheat, got %+v", got)
+	}
+}
+
+func TestWater_RejectsWitheredPlot(t *testing.T) {
+	rules := survival.DefaultRuleSet()
+	plantedAt := time.Unix(1700000000, 0)
+	s := Planted(survival.CropBerry, 90, plantedAt, rules)
+	s = Advance(s, plantedAt.Add(time.Duration(rules.Farming.WitherMinutes)*time.Minute), rules)
+	if s.State != StateWithered {
+		t.Fatalf("expected withered plot, got %+v", s)
+	}
+	if _, ok := Water(s, rules); ok {
+		t.Fatalf("expected watering withered plot rejected")
+	}
+}
@@ -0,0 +1,149 @@
+package furnacestate
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"clawvival/internal/domain/survival"
+)
+
+const (
+	StateIdle    = "IDLE"
+	StateBurning = "BURNING"
+	StateNoFuel  = "NO_FUEL"
+)
+
+type Job struct {
+	RecipeID         int `json:"recipe_id"`
+	RemainingMinutes int `json:"remaining_minutes"`
+}
+
+// State is the JSON object_state stored on a furnace world object.
+type State struct {
+	FuelMinutes   int            `json:"fuel_minutes"`
+	Queue         []Job          `json:"queue,omitempty"`
+	Output        map[string]int `json:"output,omitempty"`
+	UpdatedAtUnix int64          `json:"updated_at_unix,omitempty"`
+}
+
+func Parse(raw string) (State, error) {
+	out := State{}
+	if strings.TrimSpace(raw) == "" {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return State{}, err
+	}
+	return out, nil
+}
+
+func (s State) Encode() (string, error) {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// Status summarizes whether queued jobs are progressing.
+func (s State) Status() string {
+	switch {
+	case len(s.Queue) == 0:
+		return StateIdle
+	case s.FuelMinutes <= 0:
+		return StateNoFuel
+	default:
+		return StateBurning
+	}
+}
+
+// FuelLeft is the fuel still unburnt at now. Fuel only burns while jobs run,
+// so this needs the queue but not the recipes.
+func (s State) FuelLeft(now time.Time) int {
+	burnt := 0
+	if s.UpdatedAtUnix > 0 {
+		queued := 0
+		for _, job := range s.Queue {
+			queued += job.RemainingMinutes
+		}
+		burnt = max(0, min(int((now.Unix()-s.UpdatedAtUnix)/60), s.FuelMinutes, queued))
+	}
+	return s.FuelMinutes - burnt
+}
+
+// Advance runs queued jobs up to now. Whole minutes are consumed so the
+// remainder carries over to the next call.
+func Advance(s State, now time.Time, rules *survival.RuleSet) State {
+	if s.UpdatedAtUnix == 0 {
+		s.UpdatedAtUnix = now.Unix()
+		return s
+	}
+	minutes := int((now.Unix() - s.UpdatedAtUnix) / 60)
+	if minutes <= 0 {
+		return s
+	}
+	s.UpdatedAtUnix += int64(minutes) * 60
+	f := s.Furnace()
+	rules.TickFurnace(&f, minutes)
+	return s.withFurnace(f)
+}
+
+func Fuel(s State, itemType string, count int, rules *survival.RuleSet) (State, bool) {
+	f := s.Furnace()
+	if !rules.AddFurnaceFuel(&f, itemType, count) {
+		return s, false
+	}
+	return s.withFurnace(f), true
+}
+
+func Load(s State, recipeID survival.RecipeID, count int, rules *survival.RuleSet) (State, bool) {
+	f := s.Furnace()
+	if !rules.QueueFurnaceJobs(&f, recipeID, count) {
+		return s, false
+	}
+	return s.withFurnace(f), true
+}
+
+// Collected empties the output tray.
+func Collected(s State) State {
+	s.Output = nil
+	return s
+}
+
+// OutputItems lists collectable outputs in a stable order.
+func (s State) OutputItems() []survival.ItemAmount {
+	out := make([]survival.ItemAmount, 0, len(s.Output))
+	for item, qty := range s.Output {
+		if qty > 0 {
+			out = append(out, survival.ItemAmount{ItemType: item, Count: qty})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ItemType < out[j].ItemType })
+	return out
+}
+
+func (s State) Furnace() survival.Furnace {
+	f := survival.Furnace{FuelMinutes: s.FuelMinutes, Output: map[string]int{}}
+	for item, qty := range s.Output {
+		f.Output[item] = qty
+	}
+	for _, job := range s.Queue {
+		f.Queue = append(f.Queue, survival.FurnaceJob{RecipeID: survival.RecipeID(job.RecipeID), RemainingMinutes: job.RemainingMinutes})
+	}
+	return f
+}
+
+func (s State) withFurnace(f survival.Furnace) State {
+	s.FuelMinutes = f.FuelMinutes
+	s.Queue = nil
+	for _, job := range f.Queue {
+		s.Queue = append(s.Queue, Job{RecipeID: int(job.RecipeID), RemainingMinutes: job.RemainingMinutes})
+	}
+	s.Output = nil
+	if len(f.Output) > 0 {
+		s.Output = f.Output
+	}
+	return s
+}
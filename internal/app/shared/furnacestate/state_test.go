@@ -0,0 +1,57 @@
+package furnacestate
+
+import (
+	"testing"
+	"time"
+
+	"clawvival/internal/domain/survival"
+)
+
+func TestAdvance_SmeltsQueuedJobsAndCarriesRemainder(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	s := Advance(State{}, start, survival.DefaultRuleSet())
+	if s.UpdatedAtUnix != start.Unix() || s.Status() != StateIdle {
+		t.Fatalf("expected fresh furnace stamped idle, got %+v", s)
+	}
+	s, ok := Load(s, survival.RecipeJam, 1, survival.DefaultRuleSet())
+	if !ok || s.Status() != StateNoFuel {
+		t.Fatalf("expected loaded furnace waiting for fuel, got %+v", s)
+	}
+	s, ok = Fuel(s, "wood", 1, survival.DefaultRuleSet())
+	if !ok || s.Status() != StateBurning {
+		t.Fatalf("expected fueled furnace burning, got %+v", s)
+	}
+
+	s = Advance(s, start.Add(10*time.Minute+30*time.Second), survival.DefaultRuleSet())
+	if len(s.Queue) != 1 || s.Queue[0].RemainingMinutes != 10 {
+		t.Fatalf("expected half-done jam, got %+v", s)
+	}
+	if s.UpdatedAtUnix != start.Add(10*time.Minute).Unix() {
+		t.Fatalf("expected partial minute carried over, got updated_at=%d", s.UpdatedAtUnix)
+	}
+	s = Advance(s, start.Add(20*time.Minute), survival.DefaultRuleSet())
+	if s.Status() != StateIdle || s.FuelMinutes != 10 {
+		t.Fatalf("expected idle furnace with leftover fuel, got %+v", s)
+	}
+	if items := s.OutputItems(); len(items) != 1 || items[0].ItemType != "jam" || items[0].Count != 1 {
+		t.Fatalf("expected one jam to collect, got %+v", items)
+	}
+	if got := Collected(s); len(got.OutputItems()) != 0 {
+		t.Fatalf("expected empty output after collect, got %+v", got)
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	in := State{FuelMinutes: 45, Queue: []Job{{RecipeID: int(survival.RecipeBrick), RemainingMinutes: 12}}, Output: map[string]int{"brick": 2}, UpdatedAtUnix: 1700000000}
+	raw, err := in.Encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	out, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if out.FuelMinutes != 45 || len(out.Queue) != 1 || out.Queue[0].RemainingMinutes != 12 || out.Output["brick"] != 2 {
+		t.Fatalf("round trip mismatch: %+v", out)
+	}
+}
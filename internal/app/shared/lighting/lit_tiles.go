@@ -0,0 +1,76 @@
+package lighting
+
+import (
+	"strings"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/furnacestate"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+type Map struct {
+	daylight bool
+	lit      map[world.Point]bool
+	warm     map[world.Point]bool
+}
+
+// Compute maps light and heat as of now. A furnace only gives heat while it
+// has fuel left.
+func Compute(timeOfDay string, objects []ports.WorldObjectRecord, now time.Time) Map {
+	m := Map{
+		daylight: strings.EqualFold(strings.TrimSpace(timeOfDay), "day"),
+		lit:      map[world.Point]bool{},
+		warm:     map[world.Point]bool{},
+	}
+	for _, obj := range objects {
+		switch {
+		case isObject(obj, world.ObjectTorch, survival.BuildTorch):
+			markRadius(m.lit, obj.X, obj.Y, survival.TorchLightRadius)
+			markRadius(m.warm, obj.X, obj.Y, survival.TorchHeatRadius)
+		case isObject(obj, world.ObjectFurnace, survival.BuildFurnace):
+			if furnace, err := furnacestate.Parse(obj.ObjectState); err == nil && furnace.FuelLeft(now) > 0 {
+				markRadius(m.warm, obj.X, obj.Y, survival.FurnaceHeatRadius)
+			}
+		}
+	}
+	return m
+}
+
+func markRadius(tiles map[world.Point]bool, cx, cy, radius int) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if abs(dx)+abs(dy) > radius {
+				continue
+			}
+			tiles[world.Point{X: cx + dx, Y: cy + dy}] = true
+		}
+	}
+}
+
+func (m Map) IsLit(x, y int) bool {
+	if m.daylight {
+		return true
+	}
+	return m.lit[world.Point{X: x, Y: y}]
+}
+
+// IsWarm reports whether a torch or fueled furnace heats the tile, day or night.
+func (m Map) IsWarm(x, y int) bool {
+	return m.warm[world.Point{X: x, Y: y}]
+}
+
+func isObject(obj ports.WorldObjectRecord, objectType world.ObjectKind, kind survival.BuildKind) bool {
+	if t := strings.TrimSpace(obj.ObjectType); t != "" {
+		return strings.EqualFold(t, string(objectType))
+	}
+	return obj.Kind == int(kind)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+package lighting
+
+import (
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+)
+
+func TestCompute_DaylightLightsEverything(t *testing.T) {
+	m := Compute("day", nil, time.Time{})
+	if !m.IsLit(100, -100) {
+		t.Fatalf("expected every tile lit during day")
+	}
+}
+
+func TestCompute_TorchLightsTilesWithinRadiusAtNight(t *testing.T) {
+	m := Compute("night", []ports.WorldObjectRecord{
+		{ObjectID: "obj-torch", ObjectType: "torch", X: 2, Y: 2},
+		{ObjectID: "obj-box", ObjectType: "box", X: -10, Y: -10},
+	}, time.Time{})
+	if !m.IsLit(2, 2) || !m.IsLit(5, 2) || !m.IsLit(3, 4) {
+		t.Fatalf("expected tiles within torch radius to be lit")
+	}
+	if m.IsLit(6, 2) || m.IsLit(5, 4) {
+		t.Fatalf("expected tiles outside torch radius to stay dark")
+	}
+	if m.IsLit(-10, -10) {
+		t.Fatalf("expected non-torch objects not to emit light")
+	}
+}
+
+func TestCompute_TorchesAndFurnacesWarmNearbyTiles(t *testing.T) {
+	m := Compute("day", []ports.WorldObjectRecord{
+		{ObjectID: "obj-torch", ObjectType: "torch", X: 0, Y: 0},
+		{ObjectID: "obj-furnace", ObjectType: "furnace", X: 10, Y: 0, ObjectState: `{"fuel_minutes":30}`},
+	}, time.Time{})
+	if !m.IsWarm(1, 0) || m.IsWarm(2, 0) {
+		t.Fatalf("expected torch to warm only adjacent tiles")
+	}
+	if !m.IsWarm(12, 0) || !m.IsWarm(11, 1) || m.IsWarm(13, 0) {
+		t.Fatalf("expected furnace to warm tiles within its heat radius")
+	}
+}
+
+func TestCompute_FurnaceIsColdOnceFuelRunsOut(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := Compute("day", []ports.WorldObjectRecord{
+		{ObjectID: "furnace-empty", ObjectType: "furnace", X: 0, Y: 0, ObjectState: `{"fuel_minutes":0}`},
+		{ObjectID: "furnace-burnt", ObjectType: "furnace", X: 20, Y: 0,
+			ObjectState: `{"fuel_minutes":10,"queue":[{"recipe_id":1,"remaining_minutes":60}],"updated_at_unix":1699999000}`},
+		{ObjectID: "furnace-burning", ObjectType: "furnace", X: 40, Y: 0,
+			ObjectState: `{"fuel_minutes":30,"queue":[{"recipe_id":1,"remaining_minutes":60}],"updated_at_unix":1699999400}`},
+	}, now)
+	if m.IsWarm(1, 0) {
+		t.Fatalf("expected unfueled furnace to give no heat")
+	}
+	if m.IsWarm(21, 0) {
+		t.Fatalf("expected furnace whose fuel burnt out since its last update to give no heat")
+	}
+	if !m.IsWarm(41, 0) {
+		t.Fatalf("expected furnace with fuel left to warm nearby tiles")
+	}
+}
@@ -0,0 +1,110 @@
+package objectwear
+
+import (
+	"strings"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+type Result struct {
+	// Live holds every surviving object with wear applied up to now.
+	Live []ports.WorldObjectRecord
+	// Worn are surviving objects whose HP changed and need persisting.
+	Worn []ports.WorldObjectRecord
+	// Destroyed objects reached zero HP.
+	Destroyed []ports.WorldObjectRecord
+
+	checkedAt map[string]time.Time
+}
+
+// Apply ages objects from their last decay check to now. The snapshot's
+// weather and threats only stand for the last windowMinutes; earlier time
+// decays at the base rate. An object is only touched once at least one HP is
+// lost, and the partial HP left over is kept in WearCarry.
+func Apply(objects []ports.WorldObjectRecord, snapshot world.Snapshot, now time.Time, windowMinutes int) Result {
+	out := Result{Live: make([]ports.WorldObjectRecord, 0, len(objects)), checkedAt: map[string]time.Time{}}
+	for _, obj := range objects {
+		if obj.DecayCheckedAt.IsZero() {
+			out.Live = append(out.Live, obj)
+			continue
+		}
+		minutes := int(now.Sub(obj.DecayCheckedAt).Minutes())
+		loss, carry := survival.ObjectWearSince(TypeKey(obj), snapshot.Weather, adjacentThreats(obj, snapshot.Creatures), minutes, windowMinutes, obj.WearCarry)
+		if loss <= 0 {
+			out.Live = append(out.Live, obj)
+			continue
+		}
+		out.checkedAt[obj.ObjectID] = obj.DecayCheckedAt
+		obj.HP -= loss
+		obj.DecayCheckedAt = now
+		obj.WearCarry = carry
+		if obj.HP <= 0 {
+			obj.HP = 0
+			out.Destroyed = append(out.Destroyed, obj)
+			continue
+		}
+		out.Live = append(out.Live, obj)
+		out.Worn = append(out.Worn, obj)
+	}
+	return out
+}
+
+// CheckedAt is when a worn or destroyed object was last checked before Apply.
+func (r Result) CheckedAt(objectID string) time.Time {
+	return r.checkedAt[objectID]
+}
+
+// Find reports the worn-down copy of objectID and whether it survived.
+func (r Result) Find(objectID string) (ports.WorldObjectRecord, bool, bool) {
+	for _, obj := range r.Destroyed {
+		if obj.ObjectID == objectID {
+			return obj, false, true
+		}
+	}
+	for _, obj := range r.Live {
+		if obj.ObjectID == objectID {
+			return obj, true, true
+		}
+	}
+	return ports.WorldObjectRecord{}, false, false
+}
+
+func DestroyedEvents(destroyed []ports.WorldObjectRecord, now time.Time) []survival.DomainEvent {
+	out := make([]survival.DomainEvent, 0, len(destroyed))
+	for _, obj := range destroyed {
+		out = append(out, survival.DomainEvent{
+			Type:       "object_destroyed",
+			OccurredAt: now,
+			Payload: map[string]any{
+				"object_id":   obj.ObjectID,
+				"object_type": TypeKey(obj),
+				"x":           obj.X,
+				"y":           obj.Y,
+			},
+		})
+	}
+	return out
+}
+
+// TypeKey is the build object type used for decay and repair rules.
+func TypeKey(obj ports.WorldObjectRecord) string {
+	typ := strings.ToLower(strings.TrimSpace(obj.ObjectType))
+	if typ == "bed" && strings.EqualFold(strings.TrimSpace(obj.Quality), "GOOD") {
+		return "bed_good"
+	}
+	return typ
+}
+
+func adjacentThreats(obj ports.WorldObjectRecord, creatures []world.Creature) int {
+	count := 0
+	pos := world.Point{X: obj.X, Y: obj.Y}
+	for _, c := range creatures {
+		if c.Alive() && c.DistanceTo(pos) <= 1 {
+			count++
+		}
+	}
+	return count
+}
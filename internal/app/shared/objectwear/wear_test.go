@@ -0,0 +1,63 @@
+package objectwear
+
+import (
+	"testing"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/world"
+)
+
+func TestApply_WearsDestroysAndSkipsUntracked(t *testing.T) {
+	now := time.Unix(1700036000, 0)
+	checked := now.Add(-8 * time.Hour)
+	objects := []ports.WorldObjectRecord{
+		{ObjectID: "box-1", ObjectType: "box", HP: 100, DecayCheckedAt: checked},
+		{ObjectID: "torch-1", ObjectType: "torch", HP: 30, DecayCheckedAt: checked},
+		{ObjectID: "wall-1", ObjectType: "wall", X: 5, Y: 5, HP: 100, DecayCheckedAt: checked},
+		{ObjectID: "legacy", ObjectType: "box", HP: 100},
+	}
+	wolf := world.Creature{ID: "wolf-1", HP: 10, Pos: world.Point{X: 5, Y: 6}}
+
+	got := Apply(objects, world.Snapshot{Weather: "clear", Creatures: []world.Creature{wolf}}, now, 8*60)
+
+	if len(got.Destroyed) != 1 || got.Destroyed[0].ObjectID != "torch-1" {
+		t.Fatalf("expected torch destroyed, got %+v", got.Destroyed)
+	}
+	if len(got.Live) != 3 || len(got.Worn) != 2 {
+		t.Fatalf("unexpected live/worn split: live=%+v worn=%+v", got.Live, got.Worn)
+	}
+	if box, alive, found := got.Find("box-1"); !found || !alive || box.HP != 92 || !box.DecayCheckedAt.Equal(now) {
+		t.Fatalf("unexpected box wear: %+v", box)
+	}
+	if wall, _, _ := got.Find("wall-1"); wall.HP != 100-8*(1+10) {
+		t.Fatalf("expected wolf to wear the wall, got hp=%d", wall.HP)
+	}
+	if legacy, _, _ := got.Find("legacy"); legacy.HP != 100 {
+		t.Fatalf("expected untracked object untouched, got %+v", legacy)
+	}
+	events := DestroyedEvents(got.Destroyed, now)
+	if len(events) != 1 || events[0].Type != "object_destroyed" || events[0].Payload["object_id"] != "torch-1" {
+		t.Fatalf("unexpected destroyed events: %+v", events)
+	}
+}
+
+func TestApply_ChargesWeatherOnlyForWindowAndCarriesPartialHP(t *testing.T) {
+	now := time.Unix(1700036000, 0)
+	checked := now.Add(-8 * time.Hour)
+	objects := []ports.WorldObjectRecord{
+		{ObjectID: "wall-1", ObjectType: "wall", HP: 100, DecayCheckedAt: checked, WearCarry: 50},
+	}
+
+	got := Apply(objects, world.Snapshot{Weather: "storm"}, now, 30)
+
+	// 7.5h at base 1/h, then 30 min of storm at 5/h, plus the carried 50.
+	total := 50 + 1*(8*60-30) + 5*30
+	wall, alive, found := got.Find("wall-1")
+	if !found || !alive || wall.HP != 100-total/60 || wall.WearCarry != total%60 {
+		t.Fatalf("unexpected windowed wear: %+v", wall)
+	}
+	if !got.CheckedAt("wall-1").Equal(checked) {
+		t.Fatalf("expected previous check kept for the conditional write, got %v", got.CheckedAt("wall-1"))
+	}
+}
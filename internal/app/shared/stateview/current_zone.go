@@ -13,3 +13,12 @@ func CurrentZoneAtPosition(pos survival.Position, tiles []world.Tile) string {
 	}
 	return ""
 }
+
+func CurrentBiomeAtPosition(pos survival.Position, tiles []world.Tile) string {
+	for _, tile := range tiles {
+		if tile.X == pos.X && tile.Y == pos.Y {
+			return string(tile.Biome)
+		}
+	}
+	return ""
+}
@@ -1,13 +1,15 @@
 package stateview
 
-import "clawvival/internal/domain/survival"
+import (
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
 
 func Enrich(state survival.AgentStateAggregate, timeOfDay string, currentTileLit bool) survival.AgentStateAggregate {
 	next := state
-	if next.InventoryCapacity <= 0 {
-		next.InventoryCapacity = survival.DefaultInventoryCapacity
-	}
+	next.InventoryCapacity = survival.InventoryCapacity(next)
 	next.InventoryUsed = computeInventoryUsed(next)
+	next.ToolDurability = heldToolDurability(next)
 	next.StatusEffects = deriveStatusEffects(next, timeOfDay, currentTileLit)
 	return next
 }
@@ -22,6 +24,28 @@ func computeInventoryUsed(state survival.AgentStateAggregate) int {
 	return total
 }
 
+func heldToolDurability(state survival.AgentStateAggregate) map[string]int {
+	var out map[string]int
+	held := func(item string) {
+		if !survival.IsTool(item) {
+			return
+		}
+		if out == nil {
+			out = map[string]int{}
+		}
+		out[item] = survival.ToolDurability(state, item)
+	}
+	for item, count := range state.Inventory {
+		if count > 0 {
+			held(item)
+		}
+	}
+	if tool := survival.Modifiers(state).Tool; tool != "" {
+		held(tool)
+	}
+	return out
+}
+
 func deriveStatusEffects(state survival.AgentStateAggregate, timeOfDay string, currentTileLit bool) []string {
 	effects := make([]string, 0, 4)
 	if state.Vitals.Hunger <= 0 {
@@ -30,6 +54,9 @@ func deriveStatusEffects(state survival.AgentStateAggregate, timeOfDay string, c
 	if state.Vitals.Energy <= survival.LowEnergyThreshold {
 		effects = append(effects, "EXHAUSTED")
 	}
+	if state.Vitals.Thirst <= 0 {
+		effects = append(effects, "DEHYDRATED")
+	}
 	if state.Vitals.HP <= survival.CriticalHPThreshold {
 		effects = append(effects, "CRITICAL")
 	}
@@ -38,3 +65,31 @@ func deriveStatusEffects(state survival.AgentStateAggregate, timeOfDay string, c
 	}
 	return effects
 }
+
+func MarkSheltered(state survival.AgentStateAggregate, sheltered bool) survival.AgentStateAggregate {
+	if !sheltered {
+		return state
+	}
+	next := state
+	next.StatusEffects = append(append([]string{}, state.StatusEffects...), "SHELTERED")
+	return next
+}
+
+// MarkTemperature fills the agent's body temperature at its current tile and
+// flags COLD when it drops below the cold threshold.
+func MarkTemperature(rules *survival.RuleSet, state survival.AgentStateAggregate, snapshot world.Snapshot, nearHeat, sheltered bool) survival.AgentStateAggregate {
+	next := state
+	sleeping := state.OngoingAction != nil && state.OngoingAction.Type == survival.ActionSleep
+	next.Temperature = rules.AgentTemperature(state, survival.WorldSnapshot{
+		TimeOfDay: snapshot.TimeOfDay,
+		Season:    snapshot.Season,
+		Weather:   snapshot.Weather,
+		Biome:     CurrentBiomeAtPosition(state.Position, snapshot.VisibleTiles),
+		NearHeat:  nearHeat,
+		Sheltered: sheltered,
+	}, sleeping)
+	if rules.IsCold(next.Temperature) {
+		next.StatusEffects = append(append([]string{}, state.StatusEffects...), "COLD")
+	}
+	return next
+}
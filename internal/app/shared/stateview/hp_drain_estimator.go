@@ -11,41 +11,50 @@ type HPDrainEstimate struct {
 	EstimatedLoss   int
 	HungerComponent int
 	EnergyComponent int
+	ThirstComponent int
 	Cap             int
 	Causes          []string
 }
 
-func EstimateHPDrain(vitals survival.Vitals, dtMinutes int) HPDrainEstimate {
+func EstimateHPDrain(rules *survival.RuleSet, vitals survival.Vitals, dtMinutes int) HPDrainEstimate {
 	if dtMinutes <= 0 {
 		dtMinutes = survival.StandardTickMinutes
 	}
-	cap := scaledInt(survival.HPDrainCapPer30, dtMinutes)
+	cap := scaledInt(rules.Drains.HPCapPer30, dtMinutes)
 	if cap < 0 {
 		cap = 0
 	}
 
 	hungerPotential := int(math.Round(
-		survival.HPDrainFromHungerCoeff * float64(absMinZero(vitals.Hunger)) * float64(dtMinutes) / float64(survival.StandardTickMinutes),
+		rules.Drains.HPFromHungerCoeff * float64(absMinZero(vitals.Hunger)) * float64(dtMinutes) / float64(survival.StandardTickMinutes),
 	))
 	energyPotential := int(math.Round(
-		survival.HPDrainFromEnergyCoeff * float64(absMinZero(vitals.Energy)) * float64(dtMinutes) / float64(survival.StandardTickMinutes),
+		rules.Drains.HPFromEnergyCoeff * float64(absMinZero(vitals.Energy)) * float64(dtMinutes) / float64(survival.StandardTickMinutes),
 	))
-	hungerApplied, energyApplied := applyDualCap(hungerPotential, energyPotential, cap)
-	loss := hungerApplied + energyApplied
+	thirstPotential := int(math.Round(
+		rules.Drains.HPFromThirstCoeff * float64(absMinZero(vitals.Thirst)) * float64(dtMinutes) / float64(survival.StandardTickMinutes),
+	))
+	applied := applyDrainCap(cap, hungerPotential, energyPotential, thirstPotential)
+	hungerApplied, energyApplied, thirstApplied := applied[0], applied[1], applied[2]
+	loss := hungerApplied + energyApplied + thirstApplied
 
-	causes := make([]string, 0, 2)
+	causes := make([]string, 0, 3)
 	if hungerApplied > 0 {
 		causes = append(causes, "STARVING_HP_DRAIN")
 	}
 	if energyApplied > 0 {
 		causes = append(causes, "EXHAUSTED_HP_DRAIN")
 	}
+	if thirstApplied > 0 {
+		causes = append(causes, "DEHYDRATED_HP_DRAIN")
+	}
 
 	return HPDrainEstimate{
 		IsLosingHP:      loss > 0,
 		EstimatedLoss:   loss,
 		HungerComponent: hungerApplied,
 		EnergyComponent: energyApplied,
+		ThirstComponent: thirstApplied,
 		Cap:             cap,
 		Causes:          causes,
 	}
@@ -62,14 +71,17 @@ func absMinZero(v int) int {
 	return 0
 }
 
-func applyDualCap(primary, secondary, cap int) (int, int) {
-	if cap <= 0 {
-		return 0, 0
+func applyDrainCap(cap int, potentials ...int) []int {
+	applied := make([]int, len(potentials))
+	remaining := cap
+	for i, potential := range potentials {
+		if remaining <= 0 {
+			break
+		}
+		applied[i] = min(potential, remaining)
+		remaining -= applied[i]
 	}
-	primaryApplied := min(primary, cap)
-	remaining := cap - primaryApplied
-	secondaryApplied := min(secondary, remaining)
-	return primaryApplied, secondaryApplied
+	return applied
 }
 
 func min(a, b int) int {
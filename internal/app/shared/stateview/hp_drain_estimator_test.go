@@ -7,10 +7,10 @@ import (
 )
 
 func TestEstimateHPDrain_UsesTuningDefaults(t *testing.T) {
-	got := EstimateHPDrain(survival.Vitals{Hunger: -100, Energy: -100}, 0)
+	got := EstimateHPDrain(survival.DefaultRuleSet(), survival.Vitals{Hunger: -100, Energy: -100}, 0)
 
-	if got.Cap != survival.HPDrainCapPer30 {
-		t.Fatalf("cap = %d, want %d", got.Cap, survival.HPDrainCapPer30)
+	if got.Cap != 8 {
+		t.Fatalf("cap = %d, want 8", got.Cap)
 	}
 	if !got.IsLosingHP {
 		t.Fatalf("expected IsLosingHP=true, got false")
@@ -22,3 +22,13 @@ func TestEstimateHPDrain_UsesTuningDefaults(t *testing.T) {
 		t.Fatalf("expected both hunger and energy components > 0, got %+v", got)
 	}
 }
+
+func TestEstimateHPDrain_FollowsInjectedRuleSet(t *testing.T) {
+	rules := *survival.DefaultRuleSet()
+	rules.Drains.HPCapPer30 = 3
+
+	got := EstimateHPDrain(&rules, survival.Vitals{Hunger: -100, Energy: -100}, 0)
+	if got.Cap != 3 || got.EstimatedLoss != 3 {
+		t.Fatalf("expected injected cap 3, got cap=%d loss=%d", got.Cap, got.EstimatedLoss)
+	}
+}
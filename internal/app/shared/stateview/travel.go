@@ -0,0 +1,64 @@
+package stateview
+
+import (
+	"context"
+	"time"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/app/shared/structures"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+// BlockedTiles lists the impassable tiles among tiles.
+func BlockedTiles(tiles []world.Tile) map[survival.Position]bool {
+	out := map[survival.Position]bool{}
+	for _, tile := range tiles {
+		if !tile.Passable {
+			out[survival.Position{X: tile.X, Y: tile.Y}] = true
+		}
+	}
+	return out
+}
+
+// AdvanceTravel walks an ongoing travel forward to now, checking each step
+// against the walls and doors standing now rather than when it was planned.
+// It reports whether the state changed and needs saving.
+func AdvanceTravel(ctx context.Context, objects ports.WorldObjectRepository, agentID string, state survival.AgentStateAggregate, now time.Time) (survival.AgentStateAggregate, bool, error) {
+	if state.OngoingAction == nil {
+		return state, false, nil
+	}
+	ahead := state.OngoingAction.TravelStepsAhead(state.Position, now)
+	if len(ahead) == 0 {
+		return state, false, nil
+	}
+	var layout structures.Layout
+	if objects != nil {
+		minX, minY, maxX, maxY := ahead[0].X, ahead[0].Y, ahead[0].X, ahead[0].Y
+		for _, step := range ahead {
+			minX, minY = min(minX, step.X), min(minY, step.Y)
+			maxX, maxY = max(maxX, step.X), max(maxY, step.Y)
+		}
+		rows, err := objects.ListInBounds(ctx, agentID, minX, minY, maxX, maxY)
+		if err != nil {
+			return state, false, err
+		}
+		layout = structures.Build(rows)
+	}
+	changed := survival.AdvanceTravel(&state, now, func(p survival.Position) bool {
+		return layout.Blocks(p.X, p.Y, agentID)
+	})
+	return state, changed, nil
+}
+
+// MarkTravelProgress reports how far an ongoing travel has got by now. The
+// position is where AdvanceTravel last left the agent.
+func MarkTravelProgress(state survival.AgentStateAggregate, now time.Time) survival.AgentStateAggregate {
+	if state.OngoingAction == nil || state.OngoingAction.Type != survival.ActionTravel {
+		return state
+	}
+	next := state
+	ongoing := state.OngoingAction.WithTravelProgress(state.Position, now)
+	next.OngoingAction = &ongoing
+	return next
+}
@@ -0,0 +1,100 @@
+package structures
+
+import (
+	"strings"
+
+	"clawvival/internal/app/ports"
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+// Layout indexes the barrier objects (walls and doors) that take part in
+// passability and in shelter detection. Doors have no open or closed state:
+// they always count as wall for shelter and only differ in who may pass.
+type Layout struct {
+	barriers  map[world.Point]ports.WorldObjectRecord
+	sheltered map[world.Point]bool
+}
+
+// Reach is how far outside a tile the objects that shape it can sit: the far
+// wall of an enclosure no wider than world.MaxEnclosureSpan.
+const Reach = world.MaxEnclosureSpan + 1
+
+// Bounds is the box of objects to load to lay out every tile within radius of
+// center.
+func Bounds(center world.Point, radius int) (minX, minY, maxX, maxY int) {
+	r := radius + Reach
+	return center.X - r, center.Y - r, center.X + r, center.Y + r
+}
+
+func Build(objects []ports.WorldObjectRecord) Layout {
+	l := Layout{barriers: map[world.Point]ports.WorldObjectRecord{}}
+	points := map[world.Point]bool{}
+	for _, obj := range objects {
+		kind := objectKind(obj)
+		if kind != world.ObjectWall && kind != world.ObjectDoor {
+			continue
+		}
+		p := world.Point{X: obj.X, Y: obj.Y}
+		l.barriers[p] = obj
+		points[p] = true
+	}
+	l.sheltered = world.EnclosedTiles(points)
+	return l
+}
+
+// Blocks reports whether the tile is closed to the agent: walls always block,
+// doors only let their owner through.
+func (l Layout) Blocks(x, y int, agentID string) bool {
+	obj, ok := l.barriers[world.Point{X: x, Y: y}]
+	if !ok {
+		return false
+	}
+	if objectKind(obj) == world.ObjectDoor {
+		return obj.OwnerAgentID != agentID
+	}
+	return true
+}
+
+func (l Layout) IsSheltered(x, y int) bool {
+	return l.sheltered[world.Point{X: x, Y: y}]
+}
+
+// ApplyPassability returns a copy of tiles with barrier tiles marked impassable for the agent.
+func (l Layout) ApplyPassability(tiles []world.Tile, agentID string) []world.Tile {
+	if len(l.barriers) == 0 {
+		return tiles
+	}
+	out := make([]world.Tile, len(tiles))
+	copy(out, tiles)
+	for i := range out {
+		if l.Blocks(out[i].X, out[i].Y, agentID) {
+			out[i].Passable = false
+		}
+	}
+	return out
+}
+
+func objectKind(obj ports.WorldObjectRecord) world.ObjectKind {
+	if t := strings.TrimSpace(obj.ObjectType); t != "" {
+		return world.ObjectKind(strings.ToLower(t))
+	}
+	switch survival.BuildKind(obj.Kind) {
+	case survival.BuildWall:
+		return world.ObjectWall
+	case survival.BuildDoor:
+		return world.ObjectDoor
+	}
+	return ""
+}
+
+func ReduceThreat(level int, sheltered bool) int {
+	if !sheltered {
+		return level
+	}
+	level -= survival.ShelterThreatReduction
+	if level < 0 {
+		return 0
+	}
+	return level
+}
@@ -0,0 +1,39 @@
+package threats
+
+import (
+	"clawvival/internal/domain/survival"
+	"clawvival/internal/domain/world"
+)
+
+func Contacts(creatures []world.Creature) []survival.ThreatContact {
+	out := make([]survival.ThreatContact, 0, len(creatures))
+	for _, c := range creatures {
+		if !c.Alive() {
+			continue
+		}
+		out = append(out, survival.ThreatContact{
+			ID:     c.ID,
+			Kind:   string(c.Kind),
+			X:      c.Pos.X,
+			Y:      c.Pos.Y,
+			HP:     c.HP,
+			Damage: c.Damage,
+		})
+	}
+	return out
+}
+
+func Nearest(creatures []world.Creature, pos world.Point) (world.Creature, bool) {
+	best := world.Creature{}
+	found := false
+	for _, c := range creatures {
+		if !c.Alive() {
+			continue
+		}
+		if !found || c.DistanceTo(pos) < best.DistanceTo(pos) {
+			best = c
+			found = true
+		}
+	}
+	return best, found
+}
@@ -10,6 +10,9 @@ type Response struct {
 	State              survival.AgentStateAggregate `json:"agent_state"`
 	WorldTimeSeconds   int64                        `json:"world_time_seconds"`
 	TimeOfDay          string                       `json:"time_of_day"`
+	Day                int64                        `json:"day"`
+	Season             string                       `json:"season"`
+	Weather            string                       `json:"weather"`
 	NextPhaseInSeconds int                          `json:"next_phase_in_seconds"`
 	HPDrainFeedback    HPDrainFeedback              `json:"hp_drain_feedback"`
 	World              WorldMeta                    `json:"world"`
@@ -21,23 +24,54 @@ type WorldMeta struct {
 }
 
 type Rules struct {
-	StandardTickMinutes int                       `json:"standard_tick_minutes"`
-	DrainsPer30m        DrainsPer30m              `json:"drains_per_30m"`
-	Thresholds          Thresholds                `json:"thresholds"`
-	Visibility          Visibility                `json:"visibility"`
-	Farming             Farming                   `json:"farming"`
-	Seed                Seed                      `json:"seed"`
-	ProductionRecipes   []ProductionRecipe        `json:"production_recipes"`
-	BuildCosts          map[string]map[string]int `json:"build_costs"`
-	FoodRecoveries      map[string]int            `json:"food_recoveries"`
+	StandardTickMinutes int                               `json:"standard_tick_minutes"`
+	DrainsPer30m        DrainsPer30m                      `json:"drains_per_30m"`
+	Thresholds          Thresholds                        `json:"thresholds"`
+	Visibility          Visibility                        `json:"visibility"`
+	Farming             Farming                           `json:"farming"`
+	Seed                Seed                              `json:"seed"`
+	ProductionRecipes   []ProductionRecipe                `json:"production_recipes"`
+	BuildCosts          map[string]map[string]int         `json:"build_costs"`
+	FoodRecoveries      map[string]int                    `json:"food_recoveries"`
+	ToolDurability      map[string]int                    `json:"tool_durability"`
+	WeatherEffects      map[string]survival.WeatherEffect `json:"weather_effects"`
+	SeasonFarmGrow      map[string]int                    `json:"season_farm_grow_percent"`
+	Temperature         survival.TemperatureModel         `json:"temperature"`
+	Durability          Durability                        `json:"durability"`
+	Furnace             Furnace                           `json:"furnace"`
+	Spoilage            Spoilage                          `json:"spoilage"`
+	Gear                map[string]survival.GearRule      `json:"gear"`
+}
+
+type Spoilage struct {
+	ShelfLifeMinutes     map[string]int `json:"shelf_life_minutes"`
+	StalePercent         int            `json:"stale_percent"`
+	StaleRecoveryPercent int            `json:"stale_recovery_percent"`
+	StoredSpoilPercent   int            `json:"stored_spoil_percent"`
+	SpoilsInto           string         `json:"spoils_into"`
+}
+
+type Furnace struct {
+	FuelMinutes   map[string]int `json:"fuel_minutes"`
+	RecipeMinutes map[int]int    `json:"recipe_minutes"`
+	QueueLimit    int            `json:"queue_limit"`
+}
+
+type Durability struct {
+	MaxHP                     int                       `json:"max_hp"`
+	DecayPerHour              map[string]int            `json:"decay_per_hour"`
+	ThreatObjectDamagePerHour int                       `json:"threat_damage_per_hour"`
+	RepairCosts               map[string]map[string]int `json:"repair_costs"`
 }
 
 type DrainsPer30m struct {
 	HungerDrain            int     `json:"hunger_drain"`
 	EnergyDrain            int     `json:"energy_drain"`
+	ThirstDrain            int     `json:"thirst_drain"`
 	HPDrainModel           string  `json:"hp_drain_model"`
 	HPDrainFromHungerCoeff float64 `json:"hp_drain_from_hunger_coeff"`
 	HPDrainFromEnergyCoeff float64 `json:"hp_drain_from_energy_coeff"`
+	HPDrainFromThirstCoeff float64 `json:"hp_drain_from_thirst_coeff"`
 	HPDrainCap             int     `json:"hp_drain_cap"`
 }
 
@@ -53,9 +87,12 @@ type Visibility struct {
 }
 
 type Farming struct {
-	FarmGrowMinutes  int     `json:"farm_grow_minutes"`
-	WheatYieldRange  []int   `json:"wheat_yield_range"`
-	SeedReturnChance float64 `json:"seed_return_chance"`
+	FarmGrowMinutes       int                          `json:"farm_grow_minutes"`
+	SeedReturnChance      float64                      `json:"seed_return_chance"`
+	WaterBoostMinutes     int                          `json:"water_boost_minutes"`
+	WaterGrowBonusPercent int                          `json:"water_grow_bonus_percent"`
+	WitherMinutes         int                          `json:"wither_minutes"`
+	Crops                 map[string]survival.CropRule `json:"crops"`
 }
 
 type Seed struct {
@@ -73,6 +110,7 @@ type ProductionRecipe struct {
 type ActionCost struct {
 	DeltaHunger  int                          `json:"delta_hunger"`
 	DeltaEnergy  int                          `json:"delta_energy"`
+	DeltaThirst  int                          `json:"delta_thirst"`
 	DeltaHP      int                          `json:"delta_hp,omitempty"`
 	Requirements []string                     `json:"requirements"`
 	Variants     map[string]ActionCostVariant `json:"variants,omitempty"`
@@ -89,6 +127,7 @@ type HPDrainFeedback struct {
 	EstimatedLossPer30 int      `json:"estimated_loss_per_30m"`
 	HungerComponent    int      `json:"hunger_component"`
 	EnergyComponent    int      `json:"energy_component"`
+	ThirstComponent    int      `json:"thirst_component"`
 	CapPer30           int      `json:"cap_per_30m"`
 	Causes             []string `json:"causes"`
 }
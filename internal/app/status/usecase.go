@@ -8,7 +8,9 @@ import (
 
 	"clawvival/internal/app/ports"
 	"clawvival/internal/app/shared/cooldown"
+	"clawvival/internal/app/shared/lighting"
 	"clawvival/internal/app/shared/stateview"
+	"clawvival/internal/app/shared/structures"
 	"clawvival/internal/domain/survival"
 	"clawvival/internal/domain/world"
 )
@@ -16,10 +18,13 @@ import (
 var ErrInvalidRequest = errors.New("invalid status request")
 
 type UseCase struct {
-	StateRepo ports.AgentStateRepository
-	EventRepo ports.EventRepository
-	World     ports.WorldProvider
-	Now       func() time.Time
+	StateRepo  ports.AgentStateRepository
+	EventRepo  ports.EventRepository
+	ObjectRepo ports.WorldObjectRepository
+	World      ports.WorldProvider
+	// Rules defaults to survival.DefaultRuleSet when nil.
+	Rules *survival.RuleSet
+	Now   func() time.Time
 }
 
 func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
@@ -34,7 +39,12 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 	if err != nil {
 		return Response{}, err
 	}
-	state.SessionID = "session-" + req.AgentID
+	state.SessionID = state.ActiveSessionID()
+	// Status only reads, so travel progress is not saved here.
+	state, _, err = stateview.AdvanceTravel(ctx, u.ObjectRepo, req.AgentID, state, nowFn())
+	if err != nil {
+		return Response{}, err
+	}
 	snapshot, err := u.World.SnapshotForAgent(ctx, req.AgentID, world.Point{X: state.Position.X, Y: state.Position.Y})
 	if err != nil {
 		return Response{}, err
@@ -46,19 +56,35 @@ func (u UseCase) Execute(ctx context.Context, req Request) (Response, error) {
 			return Response{}, err
 		}
 	}
-	state = stateview.Enrich(state, snapshot.TimeOfDay, isCurrentTileLit(snapshot.TimeOfDay))
+	var objects []ports.WorldObjectRecord
+	if u.ObjectRepo != nil {
+		minX, minY, maxX, maxY := structures.Bounds(world.Point{X: state.Position.X, Y: state.Position.Y}, 0)
+		objects, err = u.ObjectRepo.ListInBounds(ctx, req.AgentID, minX, minY, maxX, maxY)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+	lit := lighting.Compute(snapshot.TimeOfDay, objects, nowFn())
+	sheltered := structures.Build(objects).IsSheltered(state.Position.X, state.Position.Y)
+	state = stateview.Enrich(state, snapshot.TimeOfDay, lit.IsLit(state.Position.X, state.Position.Y))
+	state = stateview.MarkSheltered(state, sheltered)
+	state = stateview.MarkTemperature(u.ruleSet(), state, snapshot, lit.IsWarm(state.Position.X, state.Position.Y), sheltered)
 	state.CurrentZone = stateview.CurrentZoneAtPosition(state.Position, snapshot.VisibleTiles)
-	state.ActionCooldowns = cooldown.RemainingByAction(events, nowFn())
+	state.ActionCooldowns = cooldown.RemainingByAction(u.ruleSet(), events, nowFn())
+	state = stateview.MarkTravelProgress(state, nowFn())
 	return Response{
 		State:              state,
 		WorldTimeSeconds:   snapshot.WorldTimeSeconds,
 		TimeOfDay:          snapshot.TimeOfDay,
+		Day:                snapshot.Day,
+		Season:             snapshot.Season,
+		Weather:            snapshot.Weather,
 		NextPhaseInSeconds: snapshot.NextPhaseInSeconds,
-		HPDrainFeedback:    toHPDrainFeedback(stateview.EstimateHPDrain(state.Vitals, survival.StandardTickMinutes)),
+		HPDrainFeedback:    toHPDrainFeedback(stateview.EstimateHPDrain(u.ruleSet(), state.Vitals, survival.StandardTickMinutes)),
 		World: WorldMeta{
-			Rules: defaultRules(),
+			Rules: defaultRules(u.ruleSet()),
 		},
-		ActionCosts: defaultActionCosts(),
+		ActionCosts: defaultActionCosts(u.ruleSet()),
 	}, nil
 }
 
@@ -68,21 +94,31 @@ func toHPDrainFeedback(in stateview.HPDrainEstimate) HPDrainFeedback {
 		EstimatedLossPer30: in.EstimatedLoss,
 		HungerComponent:    in.HungerComponent,
 		EnergyComponent:    in.EnergyComponent,
+		ThirstComponent:    in.ThirstComponent,
 		CapPer30:           in.Cap,
 		Causes:             in.Causes,
 	}
 }
 
-func defaultRules() Rules {
+func (u UseCase) ruleSet() *survival.RuleSet {
+	if u.Rules == nil {
+		return survival.DefaultRuleSet()
+	}
+	return u.Rules
+}
+
+func defaultRules(rules *survival.RuleSet) Rules {
 	return Rules{
 		StandardTickMinutes: survival.StandardTickMinutes,
 		DrainsPer30m: DrainsPer30m{
-			HungerDrain:            survival.BaseHungerDrainPer30,
+			HungerDrain:            rules.Drains.BaseHungerPer30,
 			EnergyDrain:            0,
+			ThirstDrain:            rules.Drains.BaseThirstPer30,
 			HPDrainModel:           "dynamic_capped",
-			HPDrainFromHungerCoeff: survival.HPDrainFromHungerCoeff,
-			HPDrainFromEnergyCoeff: survival.HPDrainFromEnergyCoeff,
-			HPDrainCap:             survival.HPDrainCapPer30,
+			HPDrainFromHungerCoeff: rules.Drains.HPFromHungerCoeff,
+			HPDrainFromEnergyCoeff: rules.Drains.HPFromEnergyCoeff,
+			HPDrainFromThirstCoeff: rules.Drains.HPFromThirstCoeff,
+			HPDrainCap:             rules.Drains.HPCapPer30,
 		},
 		Thresholds: Thresholds{
 			CriticalHP: survival.CriticalHPThreshold,
@@ -94,17 +130,43 @@ func defaultRules() Rules {
 			TorchLightRadius:  survival.TorchLightRadius,
 		},
 		Farming: Farming{
-			FarmGrowMinutes:  survival.DefaultFarmGrowMinutes,
-			WheatYieldRange:  []int{survival.WheatYieldMin, survival.WheatYieldMax},
-			SeedReturnChance: survival.SeedReturnChance,
+			FarmGrowMinutes:       rules.CropRuleFor(survival.CropWheat).GrowMinutes,
+			SeedReturnChance:      survival.SeedReturnChance,
+			WaterBoostMinutes:     rules.Farming.WaterBoostMinutes,
+			WaterGrowBonusPercent: rules.Farming.WaterGrowBonusPercent,
+			WitherMinutes:         rules.Farming.WitherMinutes,
+			Crops:                 rules.CropRules(),
 		},
 		Seed: Seed{
 			SeedDropChance:   survival.SeedDropChance,
 			SeedPityMaxFails: survival.SeedPityMaxFails,
 		},
-		ProductionRecipes: toProductionRecipes(survival.ProductionRecipeRules()),
-		BuildCosts:        cloneNestedIntMap(survival.BuildCostRules()),
-		FoodRecoveries:    cloneIntMap(survival.FoodRecoveryRules()),
+		ProductionRecipes: toProductionRecipes(rules.ProductionRecipeRules()),
+		BuildCosts:        cloneNestedIntMap(rules.BuildCostRules()),
+		FoodRecoveries:    cloneIntMap(rules.FoodRecoveryRules()),
+		ToolDurability:    survival.ToolDurabilityRules(),
+		WeatherEffects:    survival.WeatherEffectRules(),
+		SeasonFarmGrow:    survival.SeasonFarmGrowRules(),
+		Temperature:       rules.TemperatureRules(),
+		Durability: Durability{
+			MaxHP:                     survival.ObjectMaxHP,
+			DecayPerHour:              survival.ObjectDecayRules(),
+			ThreatObjectDamagePerHour: survival.ThreatObjectDamagePerHour,
+			RepairCosts:               rules.RepairCostRules(),
+		},
+		Furnace: Furnace{
+			FuelMinutes:   rules.FurnaceFuelRules(),
+			RecipeMinutes: rules.FurnaceRecipeRules(),
+			QueueLimit:    survival.FurnaceQueueLimit,
+		},
+		Spoilage: Spoilage{
+			ShelfLifeMinutes:     survival.FoodShelfLifeRules(),
+			StalePercent:         survival.FoodStalePercent,
+			StaleRecoveryPercent: survival.FoodStaleRecoveryPercent,
+			StoredSpoilPercent:   survival.StoredFoodSpoilPercent,
+			SpoilsInto:           survival.ItemRottenFood,
+		},
+		Gear: survival.GearRules(),
 	}
 }
 
@@ -143,8 +205,8 @@ func cloneNestedIntMap(in map[string]map[string]int) map[string]map[string]int {
 	return out
 }
 
-func defaultActionCosts() map[string]ActionCost {
-	profiles := survival.DefaultActionCostProfiles()
+func defaultActionCosts(rules *survival.RuleSet) map[string]ActionCost {
+	profiles := rules.ActionCostProfiles()
 	out := make(map[string]ActionCost, len(profiles))
 	for action, profile := range profiles {
 		variants := map[string]ActionCostVariant{}
@@ -162,7 +224,7 @@ func defaultActionCosts() map[string]ActionCost {
 			if variants == nil {
 				variants = map[string]ActionCostVariant{}
 			}
-			for itemType, hunger := range survival.FoodRecoveryRules() {
+			for itemType, hunger := range rules.FoodRecoveryRules() {
 				variants[itemType] = ActionCostVariant{
 					DeltaHunger: hunger,
 					DeltaEnergy: 0,
@@ -172,6 +234,7 @@ func defaultActionCosts() map[string]ActionCost {
 		out[string(action)] = ActionCost{
 			DeltaHunger:  profile.DeltaHunger,
 			DeltaEnergy:  profile.DeltaEnergy,
+			DeltaThirst:  profile.DeltaThirst,
 			DeltaHP:      profile.DeltaHP,
 			Requirements: append([]string(nil), profile.Requirements...),
 			Variants:     variants,
@@ -179,7 +242,3 @@ func defaultActionCosts() map[string]ActionCost {
 	}
 	return out
 }
-
-func isCurrentTileLit(timeOfDay string) bool {
-	return strings.EqualFold(strings.TrimSpace(timeOfDay), "day")
-}
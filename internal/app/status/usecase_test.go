@@ -51,22 +51,22 @@ func TestUseCase_IncludesWorldTimeInfo(t *testing.T) {
 	if resp.World.Rules.StandardTickMinutes != survival.StandardTickMinutes {
 		t.Fatalf("expected standard tick %d, got=%d", survival.StandardTickMinutes, resp.World.Rules.StandardTickMinutes)
 	}
-	if resp.World.Rules.DrainsPer30m.HungerDrain != survival.BaseHungerDrainPer30 || resp.World.Rules.DrainsPer30m.EnergyDrain != 0 {
+	if resp.World.Rules.DrainsPer30m.HungerDrain != 0 || resp.World.Rules.DrainsPer30m.EnergyDrain != 0 {
 		t.Fatalf("unexpected drains_per_30m: %+v", resp.World.Rules.DrainsPer30m)
 	}
 	if got := resp.ActionCosts["gather"]; got.DeltaHunger != -2 || got.DeltaEnergy != -6 {
 		t.Fatalf("gather action cost mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["sleep"]; got.DeltaHunger != 15 || got.DeltaEnergy != survival.SleepBaseEnergyRecovery || got.DeltaHP != survival.SleepBaseHPRecovery {
+	if got := resp.ActionCosts["sleep"]; got.DeltaHunger != 15 || got.DeltaEnergy != 35 || got.DeltaHP != 6 {
 		t.Fatalf("sleep action cost mismatch: %+v", got)
 	}
 	if got := resp.ActionCosts["sleep"].Variants["bed_quality_good"]; got.DeltaHunger != 20 || got.DeltaEnergy != 45 || got.DeltaHP != 10 {
 		t.Fatalf("sleep good-bed variant mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["eat"].Variants["berry"]; got.DeltaHunger != survival.FoodBerryHungerRecovery || got.DeltaEnergy != 0 {
+	if got := resp.ActionCosts["eat"].Variants["berry"]; got.DeltaHunger != 20 || got.DeltaEnergy != 0 {
 		t.Fatalf("eat berry variant mismatch: %+v", got)
 	}
-	if got := resp.ActionCosts["eat"].Variants["jam"]; got.DeltaHunger != survival.FoodJamHungerRecovery || got.DeltaEnergy != 0 {
+	if got := resp.ActionCosts["eat"].Variants["jam"]; got.DeltaHunger != 80 || got.DeltaEnergy != 0 {
 		t.Fatalf("eat jam variant mismatch: %+v", got)
 	}
 	if got, ok := resp.ActionCosts["terminate"]; !ok {
@@ -77,8 +77,8 @@ func TestUseCase_IncludesWorldTimeInfo(t *testing.T) {
 	if resp.State.InventoryUsed != 3 {
 		t.Fatalf("expected inventory_used=3, got=%d", resp.State.InventoryUsed)
 	}
-	if got := resp.World.Rules.Farming.WheatYieldRange; len(got) != 2 || got[0] != survival.WheatYieldMin || got[1] != survival.WheatYieldMax {
-		t.Fatalf("expected wheat_yield_range [%d,%d], got=%v", survival.WheatYieldMin, survival.WheatYieldMax, got)
+	if got, want := resp.World.Rules.Farming.Crops["wheat"], survival.DefaultRuleSet().CropRuleFor(survival.CropWheat); got != want {
+		t.Fatalf("expected wheat crop rule %+v, got=%+v", want, got)
 	}
 	if got := resp.World.Rules.ProductionRecipes; len(got) < 4 {
 		t.Fatalf("expected production_recipes, got=%v", got)
@@ -113,9 +113,12 @@ func TestUseCase_IncludesWorldTimeInfo(t *testing.T) {
 			t.Fatalf("unexpected bed_good build cost: %+v", got["bed_good"])
 		}
 	}
-	if got := resp.World.Rules.FoodRecoveries; got["berry"] != survival.FoodBerryHungerRecovery || got["wheat"] != survival.FoodWheatHungerRecovery || got["bread"] != survival.FoodBreadHungerRecovery || got["jam"] != survival.FoodJamHungerRecovery {
+	if got := resp.World.Rules.FoodRecoveries; got["berry"] != 20 || got["wheat"] != 15 || got["bread"] != 30 || got["jam"] != 80 {
 		t.Fatalf("unexpected food recoveries: %+v", got)
 	}
+	if got := resp.World.Rules.ToolDurability; got["tool_axe"] != survival.ToolAxeDurability || got["tool_pickaxe"] != survival.ToolPickaxeDurability {
+		t.Fatalf("unexpected tool durability rules: %+v", got)
+	}
 	if len(resp.State.StatusEffects) == 0 {
 		t.Fatalf("expected status effects for low hp/energy")
 	}
@@ -135,6 +138,32 @@ func TestUseCase_IncludesWorldTimeInfo(t *testing.T) {
 	}
 }
 
+func TestUseCase_ReportsInjectedRuleSet(t *testing.T) {
+	rules := *survival.DefaultRuleSet()
+	rules.Drains.BaseHungerPer30 = 9
+	rules.Foods = map[string]survival.FoodRule{}
+	for name, food := range survival.DefaultRuleSet().Foods {
+		rules.Foods[name] = food
+	}
+	rules.Foods["berry"] = survival.FoodRule{ID: survival.FoodBerry, HungerRecovery: 35}
+
+	repo := statusStateRepo{state: survival.AgentStateAggregate{AgentID: "agent-1"}}
+	uc := UseCase{StateRepo: repo, World: statusWorldProvider{}, Rules: &rules}
+	resp, err := uc.Execute(context.Background(), Request{AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if got := resp.World.Rules.DrainsPer30m.HungerDrain; got != 9 {
+		t.Fatalf("expected injected hunger drain 9, got=%d", got)
+	}
+	if got := resp.World.Rules.FoodRecoveries["berry"]; got != 35 {
+		t.Fatalf("expected injected berry recovery 35, got=%d", got)
+	}
+	if got := resp.ActionCosts["eat"].Variants["berry"].DeltaHunger; got != 35 {
+		t.Fatalf("expected eat berry variant 35, got=%d", got)
+	}
+}
+
 func TestUseCase_RejectsEmptyAgentID(t *testing.T) {
 	uc := UseCase{}
 	if _, err := uc.Execute(context.Background(), Request{}); !errors.Is(err, ErrInvalidRequest) {
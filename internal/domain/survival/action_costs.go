@@ -3,6 +3,7 @@ package survival
 type ActionCostProfile struct {
 	DeltaHunger  int
 	DeltaEnergy  int
+	DeltaThirst  int
 	DeltaHP      int
 	Requirements []string
 	Variants     map[string]ActionCostVariant
@@ -14,88 +15,73 @@ type ActionCostVariant struct {
 	DeltaHP     int
 }
 
-func DefaultActionCostProfiles() map[ActionType]ActionCostProfile {
+var actionCostRequirements = map[ActionType][]string{
+	ActionMove:              {"PASSABLE_TILE"},
+	ActionTravel:            {"KNOWN_PATH"},
+	ActionGather:            {"VISIBLE_TARGET"},
+	ActionCraft:             {"RECIPE_INPUTS"},
+	ActionBuild:             {"BUILD_MATERIALS", "VALID_POS"},
+	ActionEat:               {"HAS_ITEM"},
+	ActionRest:              nil,
+	ActionSleep:             {"BED_ID"},
+	ActionFarmPlant:         {"FARM_ID", "HAS_SEED"},
+	ActionFarmHarvest:       {"FARM_ID", "FARM_READY"},
+	ActionFarmWater:         {"FARM_ID", "FARM_GROWING", "ADJACENT_WATER_OR_WATER_FLASK"},
+	ActionRepair:            {"OBJECT_ID", "OBJECT_DAMAGED", "REPAIR_MATERIALS"},
+	ActionDeconstruct:       {"OWN_OBJECT_ID", "CONTAINER_EMPTY", "NO_READY_CROP", "CAPACITY_AVAILABLE"},
+	ActionFurnaceFuel:       {"OBJECT_ID", "FURNACE", "HAS_FUEL"},
+	ActionFurnaceLoad:       {"OBJECT_ID", "FURNACE", "RECIPE_INPUTS", "QUEUE_SPACE"},
+	ActionFurnaceCollect:    {"OBJECT_ID", "FURNACE", "FURNACE_OUTPUT"},
+	ActionContainerDeposit:  {"CONTAINER_ID", "HAS_ITEMS"},
+	ActionContainerWithdraw: {"CONTAINER_ID", "CAPACITY_AVAILABLE"},
+	ActionRetreat:           nil,
+	ActionAttack:            {"VISIBLE_TARGET", "ADJACENT_TARGET"},
+	ActionDrink:             {"ADJACENT_WATER_OR_WATER_FLASK"},
+	ActionTradeOffer:        {"TO_AGENT_ID", "HAS_ITEMS"},
+	ActionTradeAccept:       {"OPEN_OFFER", "HAS_REQUESTED_ITEMS"},
+	ActionTradeCancel:       {"OWN_OPEN_OFFER"},
+	ActionTerminate:         {"INTERRUPTIBLE_ONGOING_ACTION"},
+	ActionRespawn:           {"DEAD_AGENT"},
+	ActionEquip:             {"GEAR_ITEM", "CAPACITY_AVAILABLE"},
+	ActionUnequip:           {"EQUIPPED_SLOT", "CAPACITY_AVAILABLE"},
+}
+
+// ActionCostProfiles reports the net cost of each action per standard tick,
+// including the baseline drains every settled action pays.
+func (r *RuleSet) ActionCostProfiles() map[ActionType]ActionCostProfile {
 	netHunger := func(actionDelta int) int {
-		return actionDelta - BaseHungerDrainPer30
+		return actionDelta - r.Drains.BaseHungerPer30
 	}
-	return map[ActionType]ActionCostProfile{
-		ActionMove: {
-			DeltaHunger:  netHunger(ActionMoveDeltaHunger),
-			DeltaEnergy:  ActionMoveDeltaEnergy,
-			Requirements: []string{"PASSABLE_TILE"},
-		},
-		ActionGather: {
-			DeltaHunger:  netHunger(ActionGatherDeltaHunger),
-			DeltaEnergy:  ActionGatherDeltaEnergy,
-			Requirements: []string{"VISIBLE_TARGET"},
-		},
-		ActionCraft: {
-			DeltaHunger:  netHunger(ActionCraftDeltaHunger),
-			DeltaEnergy:  ActionCraftDeltaEnergy,
-			Requirements: []string{"RECIPE_INPUTS"},
-		},
-		ActionBuild: {
-			DeltaHunger:  netHunger(ActionBuildDeltaHunger),
-			DeltaEnergy:  ActionBuildDeltaEnergy,
-			Requirements: []string{"BUILD_MATERIALS", "VALID_POS"},
-		},
-		ActionEat: {
-			DeltaHunger:  netHunger(ActionEatDeltaHunger),
-			DeltaEnergy:  ActionEatDeltaEnergy,
-			Requirements: []string{"HAS_ITEM"},
-		},
-		ActionRest: {
-			DeltaHunger:  netHunger(ActionRestDeltaHunger),
-			DeltaEnergy:  ActionRestDeltaEnergy,
-			Requirements: nil,
-		},
-		ActionSleep: {
-			DeltaHunger:  netHunger(ActionSleepDeltaHunger),
-			DeltaEnergy:  ActionSleepDeltaEnergy,
-			DeltaHP:      SleepBaseHPRecovery,
-			Requirements: []string{"BED_ID"},
-			Variants: map[string]ActionCostVariant{
-				"bed_quality_rough": {
-					DeltaHunger: netHunger(ActionSleepDeltaHunger),
-					DeltaEnergy: ActionSleepDeltaEnergy,
-					DeltaHP:     SleepBaseHPRecovery,
-				},
-				"bed_quality_good": {
-					DeltaHunger: netHunger(SleepGoodHungerRecovery),
-					DeltaEnergy: SleepGoodEnergyRecovery,
-					DeltaHP:     SleepGoodHPRecovery,
-				},
-			},
-		},
-		ActionFarmPlant: {
-			DeltaHunger:  netHunger(ActionFarmPlantDeltaHunger),
-			DeltaEnergy:  ActionFarmPlantDeltaEnergy,
-			Requirements: []string{"FARM_ID", "HAS_SEED"},
-		},
-		ActionFarmHarvest: {
-			DeltaHunger:  netHunger(ActionFarmHarvestDeltaHunger),
-			DeltaEnergy:  ActionFarmHarvestDeltaEnergy,
-			Requirements: []string{"FARM_ID", "FARM_READY"},
-		},
-		ActionContainerDeposit: {
-			DeltaHunger:  netHunger(ActionContainerDepositDeltaHunger),
-			DeltaEnergy:  ActionContainerDepositDeltaEnergy,
-			Requirements: []string{"CONTAINER_ID", "HAS_ITEMS"},
-		},
-		ActionContainerWithdraw: {
-			DeltaHunger:  netHunger(ActionContainerWithdrawDeltaHunger),
-			DeltaEnergy:  ActionContainerWithdrawDeltaEnergy,
-			Requirements: []string{"CONTAINER_ID", "CAPACITY_AVAILABLE"},
-		},
-		ActionRetreat: {
-			DeltaHunger:  netHunger(ActionRetreatDeltaHunger),
-			DeltaEnergy:  ActionRetreatDeltaEnergy,
-			Requirements: nil,
+	profiles := make(map[ActionType]ActionCostProfile, len(actionCostRequirements))
+	for actionType, requirements := range actionCostRequirements {
+		profile := ActionCostProfile{Requirements: requirements}
+		switch actionType {
+		case ActionTerminate:
+			delta := r.Delta(actionType)
+			profile.DeltaHunger, profile.DeltaEnergy = delta.Hunger, delta.Energy
+		case ActionRespawn:
+		default:
+			delta := r.Delta(actionType)
+			profile.DeltaHunger = netHunger(delta.Hunger)
+			profile.DeltaEnergy = delta.Energy
+			profile.DeltaThirst = delta.Thirst - r.Drains.BaseThirstPer30
+		}
+		profiles[actionType] = profile
+	}
+	sleep := profiles[ActionSleep]
+	sleep.DeltaHP = r.Sleep.HPRecovery
+	sleep.Variants = map[string]ActionCostVariant{
+		"bed_quality_rough": {
+			DeltaHunger: sleep.DeltaHunger,
+			DeltaEnergy: sleep.DeltaEnergy,
+			DeltaHP:     r.Sleep.HPRecovery,
 		},
-		ActionTerminate: {
-			DeltaHunger:  ActionTerminateDeltaHunger,
-			DeltaEnergy:  ActionTerminateDeltaEnergy,
-			Requirements: []string{"INTERRUPTIBLE_ONGOING_ACTION"},
+		"bed_quality_good": {
+			DeltaHunger: netHunger(r.Sleep.GoodHunger),
+			DeltaEnergy: r.Sleep.GoodEnergy,
+			DeltaHP:     r.Sleep.GoodHP,
 		},
 	}
+	profiles[ActionSleep] = sleep
+	return profiles
 }
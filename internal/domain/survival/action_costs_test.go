@@ -3,7 +3,8 @@ package survival
 import "testing"
 
 func TestDefaultActionCostProfiles(t *testing.T) {
-	profiles := DefaultActionCostProfiles()
+	rules := DefaultRuleSet()
+	profiles := rules.ActionCostProfiles()
 
 	if len(profiles) == 0 {
 		t.Fatal("expected non-empty action cost profiles")
@@ -29,7 +30,7 @@ func TestDefaultActionCostProfiles(t *testing.T) {
 	if !ok {
 		t.Fatal("expected sleep profile")
 	}
-	if sleep.DeltaHunger != 15 || sleep.DeltaEnergy != SleepBaseEnergyRecovery || sleep.DeltaHP != SleepBaseHPRecovery {
+	if sleep.DeltaHunger != 15 || sleep.DeltaEnergy != DefaultRuleSet().Delta(ActionSleep).Energy || sleep.DeltaHP != DefaultRuleSet().Sleep.HPRecovery {
 		t.Fatalf("unexpected sleep profile: %+v", sleep)
 	}
 	if got, ok := sleep.Variants["bed_quality_good"]; !ok {
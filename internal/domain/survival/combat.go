@@ -0,0 +1,64 @@
+package survival
+
+type CombatOutcome struct {
+	TargetID       string
+	TargetKind     string
+	Weapon         string
+	DamageDealt    int
+	DamageTaken    int
+	TargetHPBefore int
+	TargetHPAfter  int
+	TargetKilled   bool
+}
+
+var weaponDamage = map[string]int{
+	"spear": SpearDamage,
+}
+
+func WeaponDamageRules() map[string]int {
+	return cloneIntMap(weaponDamage)
+}
+
+// BestWeapon prefers the equipped weapon and otherwise picks the strongest
+// one carried.
+func BestWeapon(state AgentStateAggregate) (string, int) {
+	if mods := Modifiers(state); mods.Weapon != "" {
+		return mods.Weapon, mods.WeaponDamage
+	}
+	best, damage := "", UnarmedDamage
+	for item, dmg := range weaponDamage {
+		if state.Inventory[item] <= 0 || dmg < damage || (dmg == damage && best != "" && item > best) {
+			continue
+		}
+		best, damage = item, dmg
+	}
+	return best, damage
+}
+
+func ResolveCombat(state AgentStateAggregate, target ThreatContact) CombatOutcome {
+	weapon, damage := BestWeapon(state)
+	out := CombatOutcome{
+		TargetID:       target.ID,
+		TargetKind:     target.Kind,
+		Weapon:         weapon,
+		DamageDealt:    damage,
+		TargetHPBefore: target.HP,
+		TargetHPAfter:  target.HP - damage,
+		DamageTaken:    target.Damage,
+	}
+	if out.TargetHPAfter <= 0 {
+		out.TargetHPAfter = 0
+		out.TargetKilled = true
+		out.DamageTaken = target.Damage / 2
+	}
+	return out
+}
+
+func findThreat(threats []ThreatContact, id string) (ThreatContact, bool) {
+	for _, t := range threats {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return ThreatContact{}, false
+}
@@ -0,0 +1,17 @@
+package survival
+
+import "testing"
+
+func TestResolveCombat_UnarmedAndKillingBlow(t *testing.T) {
+	target := ThreatContact{ID: "crt-1", Kind: "wolf", HP: 10, Damage: 4}
+
+	unarmed := ResolveCombat(AgentStateAggregate{}, target)
+	if unarmed.Weapon != "" || unarmed.DamageDealt != UnarmedDamage || unarmed.TargetKilled || unarmed.DamageTaken != 4 {
+		t.Fatalf("unexpected unarmed outcome: %+v", unarmed)
+	}
+
+	armed := ResolveCombat(AgentStateAggregate{Inventory: map[string]int{"spear": 1}}, target)
+	if armed.Weapon != "spear" || !armed.TargetKilled || armed.TargetHPAfter != 0 || armed.DamageTaken != 2 {
+		t.Fatalf("unexpected killing blow outcome: %+v", armed)
+	}
+}
@@ -0,0 +1,107 @@
+package survival
+
+import "strings"
+
+// HP each object type loses per hour to ordinary wear.
+var objectDecayPerHour = map[string]int{
+	"bed":       1,
+	"bed_good":  1,
+	"box":       1,
+	"farm_plot": 1,
+	"torch":     4,
+	"wall":      1,
+	"door":      2,
+	"furnace":   1,
+}
+
+func ObjectDecayRules() map[string]int {
+	return cloneIntMap(objectDecayPerHour)
+}
+
+// ObjectWear is the HP an object loses over the given minutes from decay,
+// weather and threats standing next to it.
+func ObjectWear(objectType, weather string, adjacentThreats, minutes int) int {
+	loss, _ := ObjectWearSince(objectType, weather, adjacentThreats, minutes, minutes, 0)
+	return loss
+}
+
+// ObjectWearSince is the wear over elapsedMinutes when the current weather and
+// threats are only known for the last windowMinutes; earlier time decays at
+// the base rate. carry and the returned remainder are HP-minutes short of a
+// whole hour-rate HP (0-59), so partial wear is not lost between checks.
+func ObjectWearSince(objectType, weather string, adjacentThreats, elapsedMinutes, windowMinutes, carry int) (int, int) {
+	if elapsedMinutes <= 0 {
+		return 0, carry
+	}
+	windowMinutes = max(0, min(windowMinutes, elapsedMinutes))
+	base := objectDecayPerHour[strings.ToLower(strings.TrimSpace(objectType))]
+	current := base + WeatherEffectFor(weather).ObjectDamagePerHour + adjacentThreats*ThreatObjectDamagePerHour
+	total := carry + base*(elapsedMinutes-windowMinutes) + current*windowMinutes
+	return total / 60, total % 60
+}
+
+// RepairCost is a share of the build cost, rounded up per material.
+func (r *RuleSet) RepairCost(objectType string) (map[string]int, bool) {
+	def, ok := r.Building(objectType)
+	if !ok {
+		return nil, false
+	}
+	out := make(map[string]int, len(def.Cost))
+	for item, qty := range def.Cost {
+		out[item] = (qty*r.Objects.RepairCostPercent + 99) / 100
+	}
+	return out, true
+}
+
+func (r *RuleSet) RepairCostRules() map[string]map[string]int {
+	out := make(map[string]map[string]int, len(r.Buildings))
+	for objectType := range r.Buildings {
+		out[objectType], _ = r.RepairCost(objectType)
+	}
+	return out
+}
+
+func (r *RuleSet) CanRepair(state AgentStateAggregate, objectType string) bool {
+	cost, ok := r.RepairCost(objectType)
+	return ok && hasEnough(&state, cost)
+}
+
+func (r *RuleSet) RepairObject(state *AgentStateAggregate, objectType string) bool {
+	cost, ok := r.RepairCost(objectType)
+	if !ok || !hasEnough(state, cost) {
+		return false
+	}
+	consume(state, cost)
+	return true
+}
+
+// DeconstructRefund returns a share of the build cost, rounded down.
+func (r *RuleSet) DeconstructRefund(objectType string) (map[string]int, bool) {
+	def, ok := r.Building(objectType)
+	if !ok {
+		return nil, false
+	}
+	out := make(map[string]int, len(def.Cost))
+	for item, qty := range def.Cost {
+		if refund := qty * r.Objects.DeconstructRefundPercent / 100; refund > 0 {
+			out[item] = refund
+		}
+	}
+	return out, true
+}
+
+// Deconstruct refunds materials, plus the seed of a crop still growing on a
+// farm plot when returnSeed is set.
+func (r *RuleSet) Deconstruct(state *AgentStateAggregate, objectType string, returnSeed bool) (map[string]int, bool) {
+	refund, ok := r.DeconstructRefund(objectType)
+	if !ok {
+		return nil, false
+	}
+	if returnSeed {
+		refund["seed"]++
+	}
+	for item, qty := range refund {
+		state.AddItem(item, qty)
+	}
+	return refund, true
+}
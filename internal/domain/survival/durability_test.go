@@ -0,0 +1,63 @@
+package survival
+
+import "testing"
+
+func TestObjectWear_CombinesDecayWeatherAndThreats(t *testing.T) {
+	if got := ObjectWear("torch", "clear", 0, 60); got != 4 {
+		t.Fatalf("torch hourly decay mismatch: got=%d want=4", got)
+	}
+	if got := ObjectWear("wall", "storm", 1, 120); got != 2*(1+4+ThreatObjectDamagePerHour) {
+		t.Fatalf("storm+threat wall wear mismatch: got=%d", got)
+	}
+	if got := ObjectWear("box", "clear", 0, 59); got != 0 {
+		t.Fatalf("expected no whole HP lost under an hour, got=%d", got)
+	}
+}
+
+func TestRepairObject_ConsumesHalfBuildCostRoundedUp(t *testing.T) {
+	rules := DefaultRuleSet()
+	cost, ok := rules.RepairCost("wall")
+	if !ok || cost["stone"] != 2 {
+		t.Fatalf("wall repair cost mismatch: %v ok=%v", cost, ok)
+	}
+	state := AgentStateAggregate{Inventory: map[string]int{"stone": 2}}
+	if !rules.RepairObject(&state, "wall") {
+		t.Fatalf("expected repair success")
+	}
+	if state.Inventory["stone"] != 0 {
+		t.Fatalf("expected repair to consume stone, got %v", state.Inventory)
+	}
+	if rules.RepairObject(&state, "wall") {
+		t.Fatalf("expected repair to fail without materials")
+	}
+}
+
+func TestDeconstruct_RefundsHalfCostAndGrowingSeed(t *testing.T) {
+	rules := DefaultRuleSet()
+	state := AgentStateAggregate{}
+	refund, ok := rules.Deconstruct(&state, "farm_plot", true)
+	if !ok {
+		t.Fatalf("expected farm_plot deconstruct success")
+	}
+	if refund["wood"] != 1 || refund["stone"] != 1 || refund["seed"] != 1 {
+		t.Fatalf("unexpected refund: %v", refund)
+	}
+	if state.Inventory["seed"] != 1 || state.Inventory["wood"] != 1 {
+		t.Fatalf("expected refund added to inventory, got %v", state.Inventory)
+	}
+	if refund, _ := rules.DeconstructRefund("torch"); len(refund) != 0 {
+		t.Fatalf("expected single-wood torch to refund nothing, got %v", refund)
+	}
+}
+
+func TestObjectWearSince_ChargesWeatherOnlyInsideWindowAndCarriesRemainder(t *testing.T) {
+	// 8h since the last check, storm and a wolf only for the last 30 minutes.
+	loss, carry := ObjectWearSince("wall", "storm", 1, 8*60, 30, 0)
+	total := 1*(8*60-30) + (1+4+ThreatObjectDamagePerHour)*30
+	if loss != total/60 || carry != total%60 {
+		t.Fatalf("windowed wear mismatch: loss=%d carry=%d want %d/%d", loss, carry, total/60, total%60)
+	}
+	if loss, carry := ObjectWearSince("box", "clear", 0, 30, 30, 30); loss != 1 || carry != 0 {
+		t.Fatalf("expected carried half hour to complete an HP, got loss=%d carry=%d", loss, carry)
+	}
+}
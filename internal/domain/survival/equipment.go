@@ -0,0 +1,146 @@
+package survival
+
+import "strings"
+
+type EquipSlot string
+
+const (
+	SlotTool     EquipSlot = "tool"
+	SlotWeapon   EquipSlot = "weapon"
+	SlotBody     EquipSlot = "body"
+	SlotBackpack EquipSlot = "backpack"
+)
+
+type GearRule struct {
+	Slot          EquipSlot `json:"slot"`
+	CapacityBonus int       `json:"capacity_bonus,omitempty"`
+	Warmth        int       `json:"warmth,omitempty"`
+	Damage        int       `json:"damage,omitempty"`
+}
+
+var gearRules = map[string]GearRule{
+	"tool_axe":     {Slot: SlotTool},
+	"tool_pickaxe": {Slot: SlotTool},
+	"spear":        {Slot: SlotWeapon, Damage: SpearDamage},
+	"coat":         {Slot: SlotBody, Warmth: CoatWarmth},
+	"backpack":     {Slot: SlotBackpack, CapacityBonus: BackpackCapacityBonus},
+}
+
+func GearRules() map[string]GearRule {
+	out := make(map[string]GearRule, len(gearRules))
+	for item, rule := range gearRules {
+		out[item] = rule
+	}
+	return out
+}
+
+func GearRuleFor(itemType string) (GearRule, bool) {
+	rule, ok := gearRules[strings.ToLower(strings.TrimSpace(itemType))]
+	return rule, ok
+}
+
+func ParseEquipSlot(raw string) (EquipSlot, bool) {
+	slot := EquipSlot(strings.ToLower(strings.TrimSpace(raw)))
+	switch slot {
+	case SlotTool, SlotWeapon, SlotBody, SlotBackpack:
+		return slot, true
+	default:
+		return "", false
+	}
+}
+
+// GearModifiers sums the effects of everything equipped. Settlement and the
+// state views read gear only through Modifiers.
+type GearModifiers struct {
+	CapacityBonus int
+	Warmth        int
+	Tool          string
+	Weapon        string
+	WeaponDamage  int
+}
+
+func Modifiers(state AgentStateAggregate) GearModifiers {
+	out := GearModifiers{}
+	for slot, item := range state.Equipment {
+		rule, ok := gearRules[item]
+		if !ok || string(rule.Slot) != slot {
+			continue
+		}
+		out.CapacityBonus += rule.CapacityBonus
+		out.Warmth += rule.Warmth
+		switch rule.Slot {
+		case SlotTool:
+			out.Tool = item
+		case SlotWeapon:
+			out.Weapon, out.WeaponDamage = item, rule.Damage
+		}
+	}
+	return out
+}
+
+func InventoryCapacity(state AgentStateAggregate) int {
+	return DefaultInventoryCapacity + Modifiers(state).CapacityBonus
+}
+
+// AgentTemperature is the body temperature with worn gear.
+func (r *RuleSet) AgentTemperature(state AgentStateAggregate, snapshot WorldSnapshot, sleeping bool) int {
+	return r.BodyTemperature(snapshot, sleeping) + Modifiers(state).Warmth
+}
+
+func CanEquip(state AgentStateAggregate, itemType string) bool {
+	next := cloneAgentState(state)
+	return Equip(&next, itemType) && inventoryUsedCount(next.Inventory) <= InventoryCapacity(next)
+}
+
+// Equip moves the item out of the inventory into its slot; whatever was in
+// the slot goes back to the inventory.
+func Equip(state *AgentStateAggregate, itemType string) bool {
+	item := strings.ToLower(strings.TrimSpace(itemType))
+	rule, ok := gearRules[item]
+	if !ok || !state.ConsumeItem(item, 1) {
+		return false
+	}
+	if state.Equipment == nil {
+		state.Equipment = map[string]string{}
+	}
+	if prev := state.Equipment[string(rule.Slot)]; prev != "" {
+		state.AddItem(prev, 1)
+	}
+	state.Equipment[string(rule.Slot)] = item
+	return true
+}
+
+func CanUnequip(state AgentStateAggregate, slot EquipSlot) bool {
+	next := cloneAgentState(state)
+	return Unequip(&next, slot) && inventoryUsedCount(next.Inventory) <= InventoryCapacity(next)
+}
+
+func Unequip(state *AgentStateAggregate, slot EquipSlot) bool {
+	item := state.Equipment[string(slot)]
+	if item == "" {
+		return false
+	}
+	delete(state.Equipment, string(slot))
+	state.AddItem(item, 1)
+	return true
+}
+
+// holdsItem counts equipped gear as held for tools and weapons.
+func holdsItem(state AgentStateAggregate, item string) bool {
+	if state.Inventory[item] > 0 {
+		return true
+	}
+	rule, ok := gearRules[item]
+	return ok && state.Equipment[string(rule.Slot)] == item
+}
+
+func cloneEquipment(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for slot, item := range in {
+		out[slot] = item
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package survival
+
+import "testing"
+
+func TestEquip_MovesGearIntoSlotAndSwapsPrevious(t *testing.T) {
+	state := AgentStateAggregate{Inventory: map[string]int{"coat": 1, "backpack": 2}}
+	if !Equip(&state, "backpack") {
+		t.Fatalf("expected equip backpack")
+	}
+	if state.Inventory["backpack"] != 1 || state.Equipment[string(SlotBackpack)] != "backpack" {
+		t.Fatalf("unexpected state after equip: inv=%v eq=%v", state.Inventory, state.Equipment)
+	}
+	if got := InventoryCapacity(state); got != DefaultInventoryCapacity+BackpackCapacityBonus {
+		t.Fatalf("capacity mismatch: got=%d", got)
+	}
+	if !Equip(&state, "backpack") || state.Inventory["backpack"] != 1 {
+		t.Fatalf("expected swap to return the worn backpack, inv=%v", state.Inventory)
+	}
+	if Equip(&state, "wood") {
+		t.Fatalf("non-gear items cannot be equipped")
+	}
+}
+
+func TestCanUnequip_BackpackNeedsRoomOnceRemoved(t *testing.T) {
+	state := AgentStateAggregate{
+		Inventory: map[string]int{"stone": DefaultInventoryCapacity},
+		Equipment: map[string]string{string(SlotBackpack): "backpack"},
+	}
+	if CanUnequip(state, SlotBackpack) {
+		t.Fatalf("expected unequip blocked while inventory relies on the backpack")
+	}
+	state.Inventory["stone"] = DefaultInventoryCapacity - 1
+	if !CanUnequip(state, SlotBackpack) {
+		t.Fatalf("expected unequip allowed with a free slot")
+	}
+}
+
+func TestModifiers_FeedTemperatureCombatAndGather(t *testing.T) {
+	rules := DefaultRuleSet()
+	state := AgentStateAggregate{
+		Inventory: map[string]int{},
+		Equipment: map[string]string{
+			string(SlotBody):   "coat",
+			string(SlotWeapon): "spear",
+			string(SlotTool):   "tool_axe",
+		},
+	}
+	snapshot := WorldSnapshot{TimeOfDay: "night", Season: "winter", Weather: "clear", Biome: "plain"}
+	if got, want := rules.AgentTemperature(state, snapshot, false), rules.BodyTemperature(snapshot, false)+CoatWarmth; got != want {
+		t.Fatalf("coat warmth mismatch: got=%d want=%d", got, want)
+	}
+	if weapon, damage := BestWeapon(state); weapon != "spear" || damage != SpearDamage {
+		t.Fatalf("expected equipped spear, got %s/%d", weapon, damage)
+	}
+	ApplyGather(&state, WorldSnapshot{NearbyResource: map[string]int{"wood": 1}})
+	if got := state.Inventory["wood"]; got != 2 {
+		t.Fatalf("expected equipped axe to double wood, got=%d", got)
+	}
+	if got := ToolDurability(state, "tool_axe"); got != ToolAxeDurability-ToolWearPerGather {
+		t.Fatalf("expected equipped axe to wear, got=%d", got)
+	}
+}
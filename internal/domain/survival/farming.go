@@ -0,0 +1,162 @@
+package survival
+
+import (
+	"slices"
+	"strings"
+)
+
+type CropType string
+
+const (
+	CropWheat  CropType = "wheat"
+	CropBerry  CropType = "berry"
+	CropPotato CropType = "potato"
+)
+
+type CropRule struct {
+	GrowMinutes int    `json:"grow_minutes"`
+	YieldItem   string `json:"yield_item"`
+	Yield       int    `json:"yield"`
+}
+
+// knownCrops is the crop catalog; rule files tune these crops but cannot add
+// new ones.
+var knownCrops = []CropType{CropWheat, CropBerry, CropPotato}
+
+func (r *RuleSet) CropRules() map[string]CropRule {
+	out := make(map[string]CropRule, len(r.Farming.Crops))
+	for k, v := range r.Farming.Crops {
+		out[string(k)] = v
+	}
+	return out
+}
+
+// ParseCrop resolves a requested crop name; an empty name plants wheat.
+func ParseCrop(raw string) (CropType, bool) {
+	crop := CropType(strings.ToLower(strings.TrimSpace(raw)))
+	if crop == "" {
+		return CropWheat, true
+	}
+	return crop, slices.Contains(knownCrops, crop)
+}
+
+func (r *RuleSet) CropRuleFor(crop CropType) CropRule {
+	if rule, ok := r.Farming.Crops[crop]; ok {
+		return rule
+	}
+	return r.Farming.Crops[CropWheat]
+}
+
+// CropGrowMinutes scales the crop's base grow time by season and weather.
+func (r *RuleSet) CropGrowMinutes(crop CropType, season, weather string) int {
+	seasonPct, ok := seasonFarmGrowPercent[season]
+	if !ok {
+		seasonPct = 100
+	}
+	minutes := r.CropRuleFor(crop).GrowMinutes * seasonPct / 100 * WeatherEffectFor(weather).FarmGrowPercent / 100
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// FarmPlot tracks a planted crop. Watering leaves a boost that speeds growth
+// for a while; a plot left unwatered for too long withers.
+type FarmPlot struct {
+	Crop             CropType
+	GrowthMinutes    int
+	TargetMinutes    int
+	WaterMinutes     int
+	UnwateredMinutes int
+	Ready            bool
+	Withered         bool
+}
+
+func CanPlantSeed(state AgentStateAggregate) bool {
+	return state.Inventory["seed"] > 0
+}
+
+func (r *RuleSet) PlantCrop(state *AgentStateAggregate, crop CropType, targetMinutes int) (FarmPlot, bool) {
+	if !state.ConsumeItem("seed", 1) {
+		return FarmPlot{}, false
+	}
+	if targetMinutes <= 0 {
+		targetMinutes = r.CropRuleFor(crop).GrowMinutes
+	}
+	return FarmPlot{Crop: crop, TargetMinutes: targetMinutes}, true
+}
+
+func (r *RuleSet) TickFarm(plot *FarmPlot, dtMinutes int) {
+	if plot.Withered || dtMinutes <= 0 {
+		return
+	}
+	target := plot.TargetMinutes
+	if target <= 0 {
+		target = r.CropRuleFor(plot.Crop).GrowMinutes
+	}
+	if !plot.Ready {
+		boosted := min(dtMinutes, plot.WaterMinutes)
+		plot.GrowthMinutes += dtMinutes + boosted*r.Farming.WaterGrowBonusPercent/100
+		if plot.GrowthMinutes >= target {
+			plot.GrowthMinutes = target
+			plot.Ready = true
+		}
+	}
+	plot.WaterMinutes = max(plot.WaterMinutes-dtMinutes, 0)
+	plot.UnwateredMinutes += dtMinutes
+	if plot.UnwateredMinutes >= r.Farming.WitherMinutes {
+		plot.Withered = true
+		plot.Ready = false
+	}
+}
+
+// FarmMinutesUntilReady estimates the remaining grow time, counting any
+// watering boost still in effect.
+func (r *RuleSet) FarmMinutesUntilReady(plot FarmPlot) int {
+	if plot.Ready || plot.Withered {
+		return 0
+	}
+	target := plot.TargetMinutes
+	if target <= 0 {
+		target = r.CropRuleFor(plot.Crop).GrowMinutes
+	}
+	remaining := target - plot.GrowthMinutes
+	rate := 100 + r.Farming.WaterGrowBonusPercent
+	boosted := plot.WaterMinutes * rate / 100
+	if boosted >= remaining {
+		return (remaining*100 + rate - 1) / rate
+	}
+	return plot.WaterMinutes + remaining - boosted
+}
+
+func (r *RuleSet) WaterFarm(plot *FarmPlot) bool {
+	if plot.Withered {
+		return false
+	}
+	plot.WaterMinutes = r.Farming.WaterBoostMinutes
+	plot.UnwateredMinutes = 0
+	return true
+}
+
+func (r *RuleSet) HarvestFarm(state *AgentStateAggregate, plot *FarmPlot) bool {
+	if !plot.Ready {
+		return false
+	}
+	rule := r.CropRuleFor(plot.Crop)
+	state.AddItem(rule.YieldItem, rule.Yield)
+	*plot = FarmPlot{}
+	return true
+}
+
+// WaterFromSource uses a carried water flask when asked to, otherwise an
+// adjacent water tile that the caller has already checked.
+func WaterFromSource(state *AgentStateAggregate, itemType string) bool {
+	if itemType != ItemWaterFlask {
+		return true
+	}
+	if !state.ConsumeItem(ItemWaterFlask, 1) {
+		return false
+	}
+	state.AddItem(ItemFlask, 1)
+	return true
+}
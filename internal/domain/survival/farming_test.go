@@ -0,0 +1,68 @@
+package survival
+
+import "testing"
+
+func TestPlantCrop_UsesCropGrowTimeAndYield(t *testing.T) {
+	rules := DefaultRuleSet()
+	state := AgentStateAggregate{Inventory: map[string]int{"seed": 1}}
+	plot, ok := rules.PlantCrop(&state, CropPotato, 0)
+	if !ok {
+		t.Fatalf("expected plant potato success")
+	}
+	rules.TickFarm(&plot, 149)
+	if plot.Ready {
+		t.Fatalf("potato should not be ready before 150 minutes")
+	}
+	rules.TickFarm(&plot, 1)
+	if !plot.Ready {
+		t.Fatalf("expected potato ready at 150 minutes")
+	}
+	if !rules.HarvestFarm(&state, &plot) {
+		t.Fatalf("expected harvest success")
+	}
+	if got := state.Inventory["potato"]; got != 4 {
+		t.Fatalf("potato yield mismatch: got=%d want=4", got)
+	}
+}
+
+func TestWaterFarm_SpeedsGrowth(t *testing.T) {
+	rules := DefaultRuleSet()
+	plot := FarmPlot{Crop: CropBerry, TargetMinutes: 90}
+	rules.WaterFarm(&plot)
+	if got := rules.FarmMinutesUntilReady(plot); got != 45 {
+		t.Fatalf("watered berry ready estimate mismatch: got=%d want=45", got)
+	}
+	rules.TickFarm(&plot, 45)
+	if !plot.Ready {
+		t.Fatalf("expected watered berry ready after 45 minutes, got %+v", plot)
+	}
+}
+
+func TestTickFarm_WithersWhenNeglected(t *testing.T) {
+	rules := DefaultRuleSet()
+	plot := FarmPlot{Crop: CropWheat, TargetMinutes: 60}
+	rules.TickFarm(&plot, rules.Farming.WitherMinutes-1)
+	if !plot.Ready || plot.Withered {
+		t.Fatalf("expected ready plot before wither threshold, got %+v", plot)
+	}
+	rules.TickFarm(&plot, 1)
+	if !plot.Withered || plot.Ready {
+		t.Fatalf("expected neglected plot to wither, got %+v", plot)
+	}
+	if rules.WaterFarm(&plot) {
+		t.Fatalf("expected withered plot to reject watering")
+	}
+}
+
+func TestParseCrop_DefaultsToWheat(t *testing.T) {
+	rules := DefaultRuleSet()
+	if crop, ok := ParseCrop(""); !ok || crop != CropWheat {
+		t.Fatalf("expected empty crop to default to wheat, got %q ok=%v", crop, ok)
+	}
+	if _, ok := ParseCrop("rice"); ok {
+		t.Fatalf("expected unknown crop rejected")
+	}
+	if got := rules.CropGrowMinutes(CropPotato, "winter", "clear"); got != 300 {
+		t.Fatalf("winter potato grow minutes mismatch: got=%d want=300", got)
+	}
+}
@@ -0,0 +1,235 @@
+package survival
+
+import (
+	"sort"
+	"time"
+)
+
+const ItemRottenFood = "rotten_food"
+
+// Minutes a perishable item keeps before it spoils into rotten_food.
+var foodShelfLifeMinutes = map[string]int{
+	"berry":  720,
+	"bread":  1440,
+	"potato": 2880,
+	"jam":    4320,
+	// Grain keeps far longer than anything baked from it.
+	"wheat": 10080,
+}
+
+// FoodStack is a batch of one perishable item acquired at the same time.
+type FoodStack struct {
+	Count      int       `json:"count"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+func FoodShelfLifeRules() map[string]int {
+	return cloneIntMap(foodShelfLifeMinutes)
+}
+
+func FoodShelfLife(itemType string) (int, bool) {
+	minutes, ok := foodShelfLifeMinutes[itemType]
+	return minutes, ok
+}
+
+// FoodAgeMinutes is how old a stack is at now when it ages at ratePercent of
+// real time.
+func FoodAgeMinutes(stack FoodStack, now time.Time, ratePercent int) int {
+	if stack.AcquiredAt.IsZero() || !now.After(stack.AcquiredAt) {
+		return 0
+	}
+	return int(now.Sub(stack.AcquiredAt).Minutes()) * ratePercent / 100
+}
+
+func IsStaleFood(itemType string, stack FoodStack, now time.Time) bool {
+	shelf, ok := FoodShelfLife(itemType)
+	if !ok {
+		return false
+	}
+	return FoodAgeMinutes(stack, now, 100)*100 >= shelf*FoodStalePercent
+}
+
+// SyncFreshness lines the agent's stacks up with its inventory: untracked
+// perishables are stamped at now and missing ones are taken oldest first.
+func SyncFreshness(state *AgentStateAggregate, now time.Time) {
+	state.Freshness = SyncFoodStacks(state.Inventory, state.Freshness, now)
+}
+
+// SpoilFood turns stacks past their shelf life into rotten_food.
+func SpoilFood(state *AgentStateAggregate, now time.Time) map[string]int {
+	if state.Inventory == nil {
+		return nil
+	}
+	return spoilFoodStacks(state.Inventory, state.Freshness, now, 100)
+}
+
+// SpoilStoredFood is SpoilFood for container contents, which age at
+// StoredFoodSpoilPercent of real time.
+func SpoilStoredFood(inventory map[string]int, stacks map[string][]FoodStack, now time.Time) map[string]int {
+	return spoilFoodStacks(inventory, stacks, now, StoredFoodSpoilPercent)
+}
+
+func SyncFoodStacks(inventory map[string]int, stacks map[string][]FoodStack, now time.Time) map[string][]FoodStack {
+	out := map[string][]FoodStack{}
+	for item, count := range inventory {
+		if _, ok := foodShelfLifeMinutes[item]; !ok || count <= 0 {
+			continue
+		}
+		current := sortedFoodStacks(stacks[item])
+		total := 0
+		for _, stack := range current {
+			total += stack.Count
+		}
+		switch {
+		case total < count:
+			current = append(current, FoodStack{Count: count - total, AcquiredAt: now})
+		case total > count:
+			current = takeOldestFood(current, total-count).rest
+		}
+		out[item] = current
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// OldestFoodStacks returns the oldest stacks covering items without
+// changing stacks.
+func OldestFoodStacks(stacks map[string][]FoodStack, items []ItemAmount) map[string][]FoodStack {
+	out := map[string][]FoodStack{}
+	for _, item := range items {
+		current, ok := stacks[item.ItemType]
+		if !ok || item.Count <= 0 {
+			continue
+		}
+		split := takeOldestFood(sortedFoodStacks(current), item.Count)
+		out[item.ItemType] = append(out[item.ItemType], split.taken...)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func AddFoodStacks(stacks map[string][]FoodStack, add map[string][]FoodStack) map[string][]FoodStack {
+	if len(add) == 0 {
+		return stacks
+	}
+	if stacks == nil {
+		stacks = map[string][]FoodStack{}
+	}
+	for item, batch := range add {
+		stacks[item] = sortedFoodStacks(append(append([]FoodStack(nil), stacks[item]...), batch...))
+	}
+	return stacks
+}
+
+// RestampFoodStacks keeps each stack's age when it moves between places that
+// age at different rates.
+func RestampFoodStacks(stacks map[string][]FoodStack, now time.Time, fromPercent, toPercent int) map[string][]FoodStack {
+	if len(stacks) == 0 {
+		return nil
+	}
+	out := make(map[string][]FoodStack, len(stacks))
+	for item, batch := range stacks {
+		for _, stack := range batch {
+			age := FoodAgeMinutes(stack, now, fromPercent)
+			stretched := age * 100 / toPercent
+			out[item] = append(out[item], FoodStack{Count: stack.Count, AcquiredAt: now.Add(-time.Duration(stretched) * time.Minute)})
+		}
+	}
+	return out
+}
+
+func cloneFoodStacks(in map[string][]FoodStack) map[string][]FoodStack {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string][]FoodStack, len(in))
+	for item, batch := range in {
+		out[item] = append([]FoodStack(nil), batch...)
+	}
+	return out
+}
+
+// popOldestFood removes one unit of itemType and reports whether it was stale.
+func popOldestFood(state *AgentStateAggregate, itemType string, now time.Time) bool {
+	current := sortedFoodStacks(state.Freshness[itemType])
+	if len(current) == 0 {
+		return false
+	}
+	split := takeOldestFood(current, 1)
+	state.Freshness[itemType] = split.rest
+	if len(split.rest) == 0 {
+		delete(state.Freshness, itemType)
+	}
+	return IsStaleFood(itemType, split.taken[0], now)
+}
+
+func spoilFoodStacks(inventory map[string]int, stacks map[string][]FoodStack, now time.Time, ratePercent int) map[string]int {
+	spoiled := map[string]int{}
+	for item, batch := range stacks {
+		shelf, ok := foodShelfLifeMinutes[item]
+		if !ok {
+			continue
+		}
+		kept := batch[:0]
+		for _, stack := range batch {
+			if FoodAgeMinutes(stack, now, ratePercent) < shelf {
+				kept = append(kept, stack)
+				continue
+			}
+			count := min(stack.Count, inventory[item])
+			inventory[item] -= count
+			if inventory[item] <= 0 {
+				delete(inventory, item)
+			}
+			inventory[ItemRottenFood] += count
+			spoiled[item] += count
+		}
+		if len(kept) == 0 {
+			delete(stacks, item)
+			continue
+		}
+		stacks[item] = kept
+	}
+	if len(spoiled) == 0 {
+		return nil
+	}
+	return spoiled
+}
+
+type foodSplit struct {
+	taken []FoodStack
+	rest  []FoodStack
+}
+
+func takeOldestFood(sorted []FoodStack, count int) foodSplit {
+	out := foodSplit{}
+	for _, stack := range sorted {
+		switch {
+		case count <= 0:
+			out.rest = append(out.rest, stack)
+		case stack.Count <= count:
+			out.taken = append(out.taken, stack)
+			count -= stack.Count
+		default:
+			out.taken = append(out.taken, FoodStack{Count: count, AcquiredAt: stack.AcquiredAt})
+			out.rest = append(out.rest, FoodStack{Count: stack.Count - count, AcquiredAt: stack.AcquiredAt})
+			count = 0
+		}
+	}
+	return out
+}
+
+func sortedFoodStacks(in []FoodStack) []FoodStack {
+	out := make([]FoodStack, 0, len(in))
+	for _, stack := range in {
+		if stack.Count > 0 {
+			out = append(out, stack)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AcquiredAt.Before(out[j].AcquiredAt) })
+	return out
+}
@@ -0,0 +1,98 @@
+package survival
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettle_SpoilsOldFoodIntoRottenFood(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	state := AgentStateAggregate{
+		Vitals:    Vitals{HP: 100, Hunger: 80, Energy: 80, Thirst: 80},
+		Inventory: map[string]int{"berry": 3, "wood": 1},
+		Freshness: map[string][]FoodStack{
+			"berry": {{Count: 2, AcquiredAt: now.Add(-13 * time.Hour)}, {Count: 1, AcquiredAt: now.Add(-time.Hour)}},
+		},
+	}
+	out, err := SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, now, WorldSnapshot{})
+	if err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+	if got := out.UpdatedState.Inventory["berry"]; got != 1 {
+		t.Fatalf("expected one fresh berry left, got=%d", got)
+	}
+	if got := out.UpdatedState.Inventory[ItemRottenFood]; got != 2 {
+		t.Fatalf("expected two rotten_food, got=%d", got)
+	}
+	if stacks := out.UpdatedState.Freshness["berry"]; len(stacks) != 1 || stacks[0].Count != 1 {
+		t.Fatalf("expected only the fresh stack tracked, got %+v", stacks)
+	}
+	found := false
+	for _, evt := range out.Events {
+		if evt.Type == "food_spoiled" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected food_spoiled event")
+	}
+}
+
+func TestEatAt_StaleFoodRecoversLessAndEatsOldestFirst(t *testing.T) {
+	rules := DefaultRuleSet()
+	now := time.Unix(1700000000, 0)
+	state := AgentStateAggregate{
+		Vitals:    Vitals{Hunger: 10},
+		Inventory: map[string]int{"bread": 2},
+		Freshness: map[string][]FoodStack{
+			"bread": {{Count: 1, AcquiredAt: now}, {Count: 1, AcquiredAt: now.Add(-13 * time.Hour)}},
+		},
+	}
+	recovery := rules.Foods["bread"].HungerRecovery
+	if !rules.EatAt(&state, FoodBread, now) {
+		t.Fatalf("expected eat success")
+	}
+	if got, want := state.Vitals.Hunger, 10+recovery*FoodStaleRecoveryPercent/100; got != want {
+		t.Fatalf("stale bread hunger mismatch: got=%d want=%d", got, want)
+	}
+	if !rules.EatAt(&state, FoodBread, now) {
+		t.Fatalf("expected second eat success")
+	}
+	if got, want := state.Vitals.Hunger, 10+recovery*FoodStaleRecoveryPercent/100+recovery; got != want {
+		t.Fatalf("fresh bread hunger mismatch: got=%d want=%d", got, want)
+	}
+}
+
+func TestSyncFoodStacks_StampsNewItemsAndDropsOldestFirst(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	old := now.Add(-2 * time.Hour)
+	stacks := map[string][]FoodStack{"potato": {{Count: 2, AcquiredAt: old}}}
+
+	grown := SyncFoodStacks(map[string]int{"potato": 3, "stone": 4}, stacks, now)
+	if got := grown["potato"]; len(got) != 2 || got[1].Count != 1 || !got[1].AcquiredAt.Equal(now) {
+		t.Fatalf("expected new potato stamped at now, got %+v", got)
+	}
+	if _, ok := grown["stone"]; ok {
+		t.Fatalf("non-perishables should not be tracked")
+	}
+	shrunk := SyncFoodStacks(map[string]int{"potato": 1}, grown, now)
+	if got := shrunk["potato"]; len(got) != 1 || !got[0].AcquiredAt.Equal(now) {
+		t.Fatalf("expected oldest potatoes removed first, got %+v", got)
+	}
+}
+
+func TestRestampFoodStacks_StoredFoodAgesSlower(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	held := map[string][]FoodStack{"berry": {{Count: 1, AcquiredAt: now.Add(-6 * time.Hour)}}}
+	stored := RestampFoodStacks(held, now, 100, StoredFoodSpoilPercent)
+	later := now.Add(8 * time.Hour)
+
+	inv := map[string]int{"berry": 1}
+	if spoiled := SpoilStoredFood(inv, stored, later); len(spoiled) != 0 {
+		t.Fatalf("stored berry should still be good after 8h, got spoiled=%v", spoiled)
+	}
+	back := RestampFoodStacks(stored, later, StoredFoodSpoilPercent, 100)
+	if got := FoodAgeMinutes(back["berry"][0], later, 100); got != 600 {
+		t.Fatalf("expected berry aged 6h+4h, got %d minutes", got)
+	}
+}
@@ -0,0 +1,142 @@
+package survival
+
+import "strings"
+
+type FurnaceJob struct {
+	RecipeID         RecipeID
+	RemainingMinutes int
+}
+
+// Furnace burns fuel only while it has queued jobs and moves finished
+// outputs aside until an agent collects them.
+type Furnace struct {
+	FuelMinutes int
+	Queue       []FurnaceJob
+	Output      map[string]int
+}
+
+func (r *RuleSet) FurnaceFuelRules() map[string]int {
+	return cloneIntMap(r.Furnace.FuelMinutes)
+}
+
+func (r *RuleSet) FurnaceRecipeRules() map[int]int {
+	out := make(map[int]int, len(r.Furnace.RecipeMinutes))
+	for rid, minutes := range r.Furnace.RecipeMinutes {
+		out[int(rid)] = minutes
+	}
+	return out
+}
+
+// FurnaceFuelMinutes is the burn time one fuel item adds to a furnace.
+func (r *RuleSet) FurnaceFuelMinutes(itemType string) (int, bool) {
+	minutes, ok := r.Furnace.FuelMinutes[strings.ToLower(strings.TrimSpace(itemType))]
+	return minutes, ok
+}
+
+func (r *RuleSet) IsFurnaceRecipe(recipeID RecipeID) bool {
+	for _, requirement := range r.CraftRequirements(recipeID) {
+		if strings.EqualFold(strings.TrimSpace(requirement), "FURNACE") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RuleSet) CanFuelFurnace(state AgentStateAggregate, itemType string, count int) bool {
+	_, ok := r.FurnaceFuelMinutes(itemType)
+	return ok && count > 0 && state.Inventory[strings.ToLower(strings.TrimSpace(itemType))] >= count
+}
+
+// FuelFurnace takes fuel items from the agent.
+func (r *RuleSet) FuelFurnace(state *AgentStateAggregate, itemType string, count int) bool {
+	if !r.CanFuelFurnace(*state, itemType, count) {
+		return false
+	}
+	return state.ConsumeItem(strings.ToLower(strings.TrimSpace(itemType)), count)
+}
+
+func (r *RuleSet) AddFurnaceFuel(furnace *Furnace, itemType string, count int) bool {
+	minutes, ok := r.FurnaceFuelMinutes(itemType)
+	if !ok || count <= 0 {
+		return false
+	}
+	furnace.FuelMinutes += minutes * count
+	return true
+}
+
+func (r *RuleSet) CanLoadFurnace(state AgentStateAggregate, furnace Furnace, recipeID RecipeID, count int) bool {
+	recipe, ok := r.Recipe(recipeID)
+	if !ok || !r.IsFurnaceRecipe(recipeID) || count <= 0 {
+		return false
+	}
+	if len(furnace.Queue)+count > FurnaceQueueLimit {
+		return false
+	}
+	return hasEnough(&state, scaleIntMap(recipe.In, count))
+}
+
+// LoadFurnace takes the inputs for count jobs from the agent up front.
+func (r *RuleSet) LoadFurnace(state *AgentStateAggregate, recipeID RecipeID, count int) bool {
+	recipe, ok := r.Recipe(recipeID)
+	if !ok || !r.IsFurnaceRecipe(recipeID) || count <= 0 {
+		return false
+	}
+	in := scaleIntMap(recipe.In, count)
+	if !hasEnough(state, in) {
+		return false
+	}
+	consume(state, in)
+	return true
+}
+
+func (r *RuleSet) QueueFurnaceJobs(furnace *Furnace, recipeID RecipeID, count int) bool {
+	if !r.IsFurnaceRecipe(recipeID) || count <= 0 || len(furnace.Queue)+count > FurnaceQueueLimit {
+		return false
+	}
+	for i := 0; i < count; i++ {
+		furnace.Queue = append(furnace.Queue, FurnaceJob{RecipeID: recipeID, RemainingMinutes: r.Furnace.RecipeMinutes[recipeID]})
+	}
+	return true
+}
+
+func (r *RuleSet) TickFurnace(furnace *Furnace, dtMinutes int) {
+	for dtMinutes > 0 && furnace.FuelMinutes > 0 && len(furnace.Queue) > 0 {
+		job := &furnace.Queue[0]
+		step := min(dtMinutes, furnace.FuelMinutes, job.RemainingMinutes)
+		job.RemainingMinutes -= step
+		furnace.FuelMinutes -= step
+		dtMinutes -= step
+		if job.RemainingMinutes > 0 {
+			continue
+		}
+		if furnace.Output == nil {
+			furnace.Output = map[string]int{}
+		}
+		for item, qty := range r.Recipes[job.RecipeID].Out {
+			furnace.Output[item] += qty
+		}
+		furnace.Queue = furnace.Queue[1:]
+	}
+}
+
+// CollectFurnace moves finished outputs into the agent's inventory.
+func CollectFurnace(state *AgentStateAggregate, items []ItemAmount) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if item.Count > 0 {
+			state.AddItem(item.ItemType, item.Count)
+		}
+	}
+	syncToolDurability(state)
+	return true
+}
+
+func scaleIntMap(in map[string]int, factor int) map[string]int {
+	out := make(map[string]int, len(in))
+	for k, v := range in {
+		out[k] = v * factor
+	}
+	return out
+}
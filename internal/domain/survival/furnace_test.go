@@ -0,0 +1,72 @@
+package survival
+
+import "testing"
+
+func TestTickFurnace_BurnsFuelOnlyWhileJobsRun(t *testing.T) {
+	rules := DefaultRuleSet()
+	furnace := Furnace{}
+	if !rules.AddFurnaceFuel(&furnace, "wood", 1) || furnace.FuelMinutes != 30 {
+		t.Fatalf("expected 30 fuel minutes from one wood, got %+v", furnace)
+	}
+	rules.TickFurnace(&furnace, 30)
+	if furnace.FuelMinutes != 30 {
+		t.Fatalf("idle furnace should keep its fuel, got %d", furnace.FuelMinutes)
+	}
+
+	if !rules.QueueFurnaceJobs(&furnace, RecipeBrick, 2) {
+		t.Fatalf("expected brick jobs queued")
+	}
+	rules.TickFurnace(&furnace, 45)
+	if got := furnace.Output["brick"]; got != 1 || len(furnace.Queue) != 1 || furnace.Queue[0].RemainingMinutes != 30 || furnace.FuelMinutes != 0 {
+		t.Fatalf("expected one brick done before fuel ran out, got %+v", furnace)
+	}
+	rules.TickFurnace(&furnace, 60)
+	if got := furnace.Output["brick"]; got != 1 || furnace.Queue[0].RemainingMinutes != 30 {
+		t.Fatalf("expected furnace stalled without fuel, got %+v", furnace)
+	}
+	rules.AddFurnaceFuel(&furnace, "plank", 1)
+	rules.TickFurnace(&furnace, 60)
+	if got := furnace.Output["brick"]; got != 2 || len(furnace.Queue) != 0 || furnace.FuelMinutes != 15 {
+		t.Fatalf("expected second brick and leftover fuel, got %+v", furnace)
+	}
+}
+
+func TestLoadFurnace_TakesInputsAndRespectsQueueLimit(t *testing.T) {
+	rules := DefaultRuleSet()
+	state := AgentStateAggregate{Inventory: map[string]int{"stone": 20}}
+	furnace := Furnace{}
+	if rules.CanLoadFurnace(state, furnace, RecipePlank, 1) {
+		t.Fatalf("plank is not a furnace recipe")
+	}
+	if rules.CanLoadFurnace(state, furnace, RecipeBrick, FurnaceQueueLimit+1) {
+		t.Fatalf("expected queue limit to reject oversized load")
+	}
+	if !rules.LoadFurnace(&state, RecipeBrick, 3) {
+		t.Fatalf("expected load success")
+	}
+	if got := state.Inventory["stone"]; got != 14 {
+		t.Fatalf("expected inputs for three bricks consumed, got stone=%d", got)
+	}
+	furnace.Queue = make([]FurnaceJob, FurnaceQueueLimit)
+	if rules.CanLoadFurnace(state, furnace, RecipeBrick, 1) {
+		t.Fatalf("expected full queue to reject load")
+	}
+}
+
+func TestLoadFurnace_FollowsInjectedRecipeRequirements(t *testing.T) {
+	rules, err := ParseRuleSet(defaultRuleSetJSON)
+	if err != nil {
+		t.Fatalf("parse default rules: %v", err)
+	}
+	brick := rules.Recipes[RecipeBrick]
+	brick.Requirements = nil
+	rules.Recipes[RecipeBrick] = brick
+
+	state := AgentStateAggregate{Inventory: map[string]int{"stone": 20}}
+	if rules.IsFurnaceRecipe(RecipeBrick) || rules.CanLoadFurnace(state, Furnace{}, RecipeBrick, 1) || rules.QueueFurnaceJobs(&Furnace{}, RecipeBrick, 1) {
+		t.Fatalf("expected brick without FURNACE requirement to stay out of the furnace")
+	}
+	if !DefaultRuleSet().IsFurnaceRecipe(RecipeBrick) {
+		t.Fatalf("expected default rules untouched")
+	}
+}
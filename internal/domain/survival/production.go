@@ -1,14 +1,23 @@
 package survival
 
-import "strings"
+import (
+	"sort"
+	"time"
+)
 
 type RecipeID int
 
 const (
-	RecipePlank RecipeID = 1
-	RecipeBread RecipeID = 2
-	RecipeBrick RecipeID = 3
-	RecipeJam   RecipeID = 4
+	RecipePlank    RecipeID = 1
+	RecipeBread    RecipeID = 2
+	RecipeBrick    RecipeID = 3
+	RecipeJam      RecipeID = 4
+	RecipeSpear    RecipeID = 5
+	RecipeAxe      RecipeID = 6
+	RecipePickaxe  RecipeID = 7
+	RecipeFlask    RecipeID = 8
+	RecipeCoat     RecipeID = 9
+	RecipeBackpack RecipeID = 10
 )
 
 type BuildKind int
@@ -23,82 +32,16 @@ const (
 	BuildFurnace BuildKind = 7
 )
 
-type FarmPlot struct {
-	GrowthMinutes int
-	Ready         bool
-}
-
 type FoodID int
 
 const (
-	FoodBerry FoodID = 1
-	FoodBread FoodID = 2
-	FoodWheat FoodID = 3
-	FoodJam   FoodID = 4
+	FoodBerry  FoodID = 1
+	FoodBread  FoodID = 2
+	FoodWheat  FoodID = 3
+	FoodJam    FoodID = 4
+	FoodPotato FoodID = 5
 )
 
-type recipeDef struct {
-	In           map[string]int
-	Out          map[string]int
-	Requirements []string
-}
-
-var recipeDefs = map[RecipeID]recipeDef{
-	RecipePlank: {
-		In:  map[string]int{"wood": 2},
-		Out: map[string]int{"plank": 1},
-	},
-	RecipeBread: {
-		In:  map[string]int{"wheat": 2},
-		Out: map[string]int{"bread": 1},
-	},
-	RecipeBrick: {
-		In:           map[string]int{"stone": 2},
-		Out:          map[string]int{"brick": 1},
-		Requirements: []string{"FURNACE"},
-	},
-	RecipeJam: {
-		In:           map[string]int{"berry": 2, "bread": 1},
-		Out:          map[string]int{"jam": 1},
-		Requirements: []string{"FURNACE"},
-	},
-}
-
-var buildCosts = map[BuildKind]map[string]int{
-	BuildBed:     {"wood": 8},
-	BuildBox:     {"wood": 4},
-	BuildFarm:    {"wood": 2, "stone": 2},
-	BuildTorch:   {"wood": 1},
-	BuildWall:    {"stone": 3},
-	BuildDoor:    {"wood": 2},
-	BuildFurnace: {"stone": 6},
-}
-
-var buildDefsByObjectType = map[string]struct {
-	Kind BuildKind
-	Cost map[string]int
-}{
-	"bed":       {Kind: BuildBed, Cost: map[string]int{"wood": 8}},
-	"bed_rough": {Kind: BuildBed, Cost: map[string]int{"wood": 8}},
-	"bed_good":  {Kind: BuildBed, Cost: map[string]int{"plank": 4, "wood": 2}},
-	"box":       {Kind: BuildBox, Cost: map[string]int{"wood": 4}},
-	"farm_plot": {Kind: BuildFarm, Cost: map[string]int{"wood": 2, "stone": 2}},
-	"torch":     {Kind: BuildTorch, Cost: map[string]int{"wood": 1}},
-	"wall":      {Kind: BuildWall, Cost: map[string]int{"stone": 3}},
-	"door":      {Kind: BuildDoor, Cost: map[string]int{"wood": 2}},
-	"furnace":   {Kind: BuildFurnace, Cost: map[string]int{"stone": 6}},
-}
-
-var foodDefs = map[FoodID]struct {
-	ItemName      string
-	HungerRecover int
-}{
-	FoodBerry: {ItemName: "berry", HungerRecover: FoodBerryHungerRecovery},
-	FoodBread: {ItemName: "bread", HungerRecover: FoodBreadHungerRecovery},
-	FoodWheat: {ItemName: "wheat", HungerRecover: FoodWheatHungerRecovery},
-	FoodJam:   {ItemName: "jam", HungerRecover: FoodJamHungerRecovery},
-}
-
 type BuiltObject struct {
 	Kind BuildKind
 	X    int
@@ -112,34 +55,46 @@ type ProductionRecipeRule struct {
 	Requirements []string
 }
 
-func ApplyGather(state *AgentStateAggregate, snapshot WorldSnapshot) {
+// ApplyGather adds the nearby resources to the inventory and wears every tool
+// that boosted the yield. It returns the tools that broke.
+func ApplyGather(state *AgentStateAggregate, snapshot WorldSnapshot) []string {
 	if state.Inventory == nil {
 		state.Inventory = map[string]int{}
 	}
+	used := map[string]bool{}
 	for item, qty := range snapshot.NearbyResource {
 		if qty <= 0 {
 			continue
 		}
-		state.Inventory[item] += qty * gatherMultiplier(state, item)
+		multiplier := gatherMultiplier(state, item)
+		state.Inventory[item] += weatherAdjustedYield(qty, snapshot.Weather) * multiplier
+		if multiplier > 1 {
+			used[gatherTools[item]] = true
+		}
 	}
+	tools := make([]string, 0, len(used))
+	for tool := range used {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	broken := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		if wearTool(state, tool) {
+			broken = append(broken, tool)
+		}
+	}
+	return broken
 }
 
 func gatherMultiplier(state *AgentStateAggregate, item string) int {
-	switch item {
-	case "wood":
-		if state.Inventory["tool_axe"] > 0 {
-			return 2
-		}
-	case "stone":
-		if state.Inventory["tool_pickaxe"] > 0 {
-			return 2
-		}
+	if tool, ok := gatherTools[item]; ok && holdsItem(*state, tool) {
+		return 2
 	}
 	return 1
 }
 
-func Craft(state *AgentStateAggregate, recipeID RecipeID) bool {
-	recipe, ok := recipeDefs[recipeID]
+func (r *RuleSet) Craft(state *AgentStateAggregate, recipeID RecipeID) bool {
+	recipe, ok := r.Recipe(recipeID)
 	if !ok {
 		return false
 	}
@@ -148,15 +103,12 @@ func Craft(state *AgentStateAggregate, recipeID RecipeID) bool {
 	}
 	consume(state, recipe.In)
 	produce(state, recipe.Out)
+	syncToolDurability(state)
 	return true
 }
 
-func Build(state *AgentStateAggregate, kind BuildKind, x, y int) (BuiltObject, bool) {
-	return BuildObject(state, kindToObjectType(kind), x, y)
-}
-
-func BuildObject(state *AgentStateAggregate, objectType string, x, y int) (BuiltObject, bool) {
-	def, ok := buildDefsByObjectType[strings.ToLower(strings.TrimSpace(objectType))]
+func (r *RuleSet) BuildObject(state *AgentStateAggregate, objectType string, x, y int) (BuiltObject, bool) {
+	def, ok := r.Building(objectType)
 	if !ok {
 		return BuiltObject{}, false
 	}
@@ -167,22 +119,19 @@ func BuildObject(state *AgentStateAggregate, objectType string, x, y int) (Built
 	return BuiltObject{Kind: def.Kind, X: x, Y: y}, true
 }
 
-func CanCraft(state AgentStateAggregate, recipeID RecipeID) bool {
-	recipe, ok := recipeDefs[recipeID]
+func (r *RuleSet) CanCraft(state AgentStateAggregate, recipeID RecipeID) bool {
+	recipe, ok := r.Recipe(recipeID)
 	if !ok {
 		return false
 	}
 	return hasEnough(&state, recipe.In)
 }
 
-func ProductionRecipeRules() []ProductionRecipeRule {
-	ordered := []RecipeID{RecipePlank, RecipeBread, RecipeBrick, RecipeJam}
-	out := make([]ProductionRecipeRule, 0, len(ordered))
-	for _, rid := range ordered {
-		def, ok := recipeDefs[rid]
-		if !ok {
-			continue
-		}
+func (r *RuleSet) ProductionRecipeRules() []ProductionRecipeRule {
+	ids := r.recipeIDs()
+	out := make([]ProductionRecipeRule, 0, len(ids))
+	for _, rid := range ids {
+		def := r.Recipes[rid]
 		out = append(out, ProductionRecipeRule{
 			RecipeID:     int(rid),
 			In:           cloneIntMap(def.In),
@@ -193,94 +142,60 @@ func ProductionRecipeRules() []ProductionRecipeRule {
 	return out
 }
 
-func CraftRequirements(recipeID RecipeID) []string {
-	def, ok := recipeDefs[recipeID]
+func (r *RuleSet) CraftRequirements(recipeID RecipeID) []string {
+	def, ok := r.Recipe(recipeID)
 	if !ok || len(def.Requirements) == 0 {
 		return nil
 	}
 	return append([]string(nil), def.Requirements...)
 }
 
-func BuildCostRules() map[string]map[string]int {
-	out := make(map[string]map[string]int, len(buildDefsByObjectType))
-	for objectType, def := range buildDefsByObjectType {
+func (r *RuleSet) BuildCostRules() map[string]map[string]int {
+	out := make(map[string]map[string]int, len(r.Buildings))
+	for objectType, def := range r.Buildings {
 		out[objectType] = cloneIntMap(def.Cost)
 	}
 	return out
 }
 
-func FoodRecoveryRules() map[string]int {
-	out := make(map[string]int, len(foodDefs))
-	for _, def := range foodDefs {
-		out[def.ItemName] = def.HungerRecover
+func (r *RuleSet) FoodRecoveryRules() map[string]int {
+	out := make(map[string]int, len(r.Foods))
+	for item, def := range r.Foods {
+		out[item] = def.HungerRecovery
 	}
 	return out
 }
 
-func CanBuild(state AgentStateAggregate, kind BuildKind) bool {
-	cost, ok := buildCosts[kind]
-	if !ok {
-		return false
-	}
-	return hasEnough(&state, cost)
-}
-
-func CanBuildObjectType(state AgentStateAggregate, objectType string) bool {
-	def, ok := buildDefsByObjectType[strings.ToLower(strings.TrimSpace(objectType))]
+func (r *RuleSet) CanBuildObjectType(state AgentStateAggregate, objectType string) bool {
+	def, ok := r.Building(objectType)
 	if !ok {
 		return false
 	}
 	return hasEnough(&state, def.Cost)
 }
 
-func CanPlantSeed(state AgentStateAggregate) bool {
-	return state.Inventory["seed"] > 0
-}
-
-func PlantSeed(state *AgentStateAggregate) (FarmPlot, bool) {
-	if !state.ConsumeItem("seed", 1) {
-		return FarmPlot{}, false
-	}
-	return FarmPlot{}, true
-}
-
-func TickFarm(plot *FarmPlot, dtMinutes int) {
-	if plot.Ready || dtMinutes <= 0 {
-		return
-	}
-	plot.GrowthMinutes += dtMinutes
-	if plot.GrowthMinutes >= DefaultFarmGrowMinutes {
-		plot.Ready = true
-	}
-}
-
-func HarvestFarm(state *AgentStateAggregate, plot *FarmPlot) bool {
-	if !plot.Ready {
-		return false
-	}
-	state.AddItem("wheat", 2)
-	plot.Ready = false
-	plot.GrowthMinutes = 0
-	return true
-}
-
-func CanEat(state AgentStateAggregate, foodID FoodID) bool {
-	food, ok := foodDefs[foodID]
+func (r *RuleSet) CanEat(state AgentStateAggregate, foodID FoodID) bool {
+	item, _, ok := r.food(foodID)
 	if !ok {
 		return false
 	}
-	return state.Inventory[food.ItemName] > 0
+	return state.Inventory[item] > 0
 }
 
-func Eat(state *AgentStateAggregate, foodID FoodID) bool {
-	food, ok := foodDefs[foodID]
+// EatAt eats the oldest unit of the food; stale food recovers less hunger.
+func (r *RuleSet) EatAt(state *AgentStateAggregate, foodID FoodID, now time.Time) bool {
+	item, food, ok := r.food(foodID)
 	if !ok {
 		return false
 	}
-	if !state.ConsumeItem(food.ItemName, 1) {
+	if !state.ConsumeItem(item, 1) {
 		return false
 	}
-	state.Vitals.Hunger += food.HungerRecover
+	recovery := food.HungerRecovery
+	if !now.IsZero() && popOldestFood(state, item, now) {
+		recovery = recovery * FoodStaleRecoveryPercent / 100
+	}
+	state.Vitals.Hunger += recovery
 	if state.Vitals.Hunger > 100 {
 		state.Vitals.Hunger = 100
 	}
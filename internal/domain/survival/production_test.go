@@ -1,8 +1,12 @@
 package survival
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestProductionLoop_GatherCraftBuildFarm(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{}
 
 	ApplyGather(&state, WorldSnapshot{NearbyResource: map[string]int{"wood": 3, "stone": 2}})
@@ -11,7 +15,7 @@ func TestProductionLoop_GatherCraftBuildFarm(t *testing.T) {
 	}
 
 	state.AddItem("wood", 5)
-	if ok := Craft(&state, RecipePlank); !ok {
+	if ok := rules.Craft(&state, RecipePlank); !ok {
 		t.Fatalf("expected craft plank success")
 	}
 	if state.Inventory["plank"] == 0 {
@@ -19,30 +23,31 @@ func TestProductionLoop_GatherCraftBuildFarm(t *testing.T) {
 	}
 
 	state.AddItem("wood", 8)
-	if _, ok := Build(&state, BuildBed, 0, 0); !ok {
+	if _, ok := rules.BuildObject(&state, "bed_rough", 0, 0); !ok {
 		t.Fatalf("expected build bed success")
 	}
 
 	state.AddItem("seed", 1)
-	plot, ok := PlantSeed(&state)
+	plot, ok := rules.PlantCrop(&state, CropWheat, 0)
 	if !ok {
 		t.Fatalf("expected plant seed success")
 	}
 	for i := 0; i < 5; i++ {
-		TickFarm(&plot, 30)
+		rules.TickFarm(&plot, 30)
 	}
 	if !plot.Ready {
 		t.Fatalf("expected farm plot ready")
 	}
-	HarvestFarm(&state, &plot)
+	rules.HarvestFarm(&state, &plot)
 	if state.Inventory["wheat"] == 0 {
 		t.Fatalf("expected harvest wheat")
 	}
 }
 
 func TestCraftRejectsMissingInput(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{}
-	if ok := Craft(&state, RecipePlank); ok {
+	if ok := rules.Craft(&state, RecipePlank); ok {
 		t.Fatalf("expected craft fail when missing input")
 	}
 }
@@ -68,6 +73,7 @@ func TestApplyGather_UsesToolEfficiency(t *testing.T) {
 }
 
 func TestEatAndCanEat(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{
 		Vitals: Vitals{Hunger: 70},
 		Inventory: map[string]int{
@@ -75,10 +81,10 @@ func TestEatAndCanEat(t *testing.T) {
 			"bread": 1,
 		},
 	}
-	if !CanEat(state, FoodBerry) {
+	if !rules.CanEat(state, FoodBerry) {
 		t.Fatalf("expected CanEat berry true")
 	}
-	if ok := Eat(&state, FoodBerry); !ok {
+	if ok := rules.EatAt(&state, FoodBerry, time.Time{}); !ok {
 		t.Fatalf("expected Eat berry success")
 	}
 	if got, want := state.Inventory["berry"], 0; got != want {
@@ -88,7 +94,7 @@ func TestEatAndCanEat(t *testing.T) {
 		t.Fatalf("hunger recover mismatch: got=%d want=%d", got, want)
 	}
 
-	if ok := Eat(&state, FoodBread); !ok {
+	if ok := rules.EatAt(&state, FoodBread, time.Time{}); !ok {
 		t.Fatalf("expected Eat bread success")
 	}
 	if got, want := state.Inventory["bread"], 0; got != want {
@@ -100,16 +106,17 @@ func TestEatAndCanEat(t *testing.T) {
 }
 
 func TestEatAndCanEat_Wheat(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{
 		Vitals: Vitals{Hunger: 30},
 		Inventory: map[string]int{
 			"wheat": 1,
 		},
 	}
-	if !CanEat(state, FoodWheat) {
+	if !rules.CanEat(state, FoodWheat) {
 		t.Fatalf("expected CanEat wheat true")
 	}
-	if ok := Eat(&state, FoodWheat); !ok {
+	if ok := rules.EatAt(&state, FoodWheat, time.Time{}); !ok {
 		t.Fatalf("expected Eat wheat success")
 	}
 	if got, want := state.Inventory["wheat"], 0; got != want {
@@ -121,16 +128,17 @@ func TestEatAndCanEat_Wheat(t *testing.T) {
 }
 
 func TestEatAndCanEat_Jam(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{
 		Vitals: Vitals{Hunger: 30},
 		Inventory: map[string]int{
 			"jam": 1,
 		},
 	}
-	if !CanEat(state, FoodJam) {
+	if !rules.CanEat(state, FoodJam) {
 		t.Fatalf("expected CanEat jam true")
 	}
-	if ok := Eat(&state, FoodJam); !ok {
+	if ok := rules.EatAt(&state, FoodJam, time.Time{}); !ok {
 		t.Fatalf("expected Eat jam success")
 	}
 	if got, want := state.Inventory["jam"], 0; got != want {
@@ -142,19 +150,20 @@ func TestEatAndCanEat_Jam(t *testing.T) {
 }
 
 func TestBuildCosts_MVPv1MinimumSet(t *testing.T) {
+	rules := DefaultRuleSet()
 	state := AgentStateAggregate{Inventory: map[string]int{
 		"wood":  14,
 		"stone": 2,
 		"seed":  1,
 		"berry": 2,
 	}}
-	if _, ok := BuildObject(&state, "bed_rough", 0, 0); !ok {
+	if _, ok := rules.BuildObject(&state, "bed_rough", 0, 0); !ok {
 		t.Fatalf("expected bed_rough build success with wood cost")
 	}
-	if _, ok := BuildObject(&state, "box", 1, 0); !ok {
+	if _, ok := rules.BuildObject(&state, "box", 1, 0); !ok {
 		t.Fatalf("expected box build success with wood cost")
 	}
-	if _, ok := BuildObject(&state, "farm_plot", 1, 1); !ok {
+	if _, ok := rules.BuildObject(&state, "farm_plot", 1, 1); !ok {
 		t.Fatalf("expected farm_plot build success with wood+stone cost")
 	}
 	if got := state.Inventory["wood"]; got != 0 {
@@ -169,7 +178,7 @@ func TestBuildCosts_MVPv1MinimumSet(t *testing.T) {
 }
 
 func TestProductionRecipeRules_ExposeStableCatalog(t *testing.T) {
-	rules := ProductionRecipeRules()
+	rules := DefaultRuleSet().ProductionRecipeRules()
 	if len(rules) < 4 {
 		t.Fatalf("expected at least 4 production recipes, got=%d", len(rules))
 	}
@@ -188,15 +197,15 @@ func TestProductionRecipeRules_ExposeStableCatalog(t *testing.T) {
 }
 
 func TestProductionRecipeRules_CoversAllRuntimeRecipes(t *testing.T) {
-	rules := ProductionRecipeRules()
-	if got, want := len(rules), len(recipeDefs); got != want {
+	rules := DefaultRuleSet().ProductionRecipeRules()
+	if got, want := len(rules), len(DefaultRuleSet().Recipes); got != want {
 		t.Fatalf("production recipe count mismatch: got=%d want=%d", got, want)
 	}
 	exported := map[int]ProductionRecipeRule{}
 	for _, r := range rules {
 		exported[r.RecipeID] = r
 	}
-	for id, def := range recipeDefs {
+	for id, def := range DefaultRuleSet().Recipes {
 		r, ok := exported[int(id)]
 		if !ok {
 			t.Fatalf("missing exported recipe for runtime recipe_id=%d", id)
@@ -211,23 +220,23 @@ func TestProductionRecipeRules_CoversAllRuntimeRecipes(t *testing.T) {
 }
 
 func TestFoodRecoveryRules_CoversAllRuntimeFoods(t *testing.T) {
-	rules := FoodRecoveryRules()
-	if got, want := len(rules), len(foodDefs); got != want {
+	rules := DefaultRuleSet().FoodRecoveryRules()
+	if got, want := len(rules), len(DefaultRuleSet().Foods); got != want {
 		t.Fatalf("food recovery count mismatch: got=%d want=%d", got, want)
 	}
-	for _, def := range foodDefs {
-		if got := rules[def.ItemName]; got != def.HungerRecover {
-			t.Fatalf("food recovery mismatch for %s: got=%d want=%d", def.ItemName, got, def.HungerRecover)
+	for item, def := range DefaultRuleSet().Foods {
+		if got := rules[item]; got != def.HungerRecovery {
+			t.Fatalf("food recovery mismatch for %s: got=%d want=%d", item, got, def.HungerRecovery)
 		}
 	}
 }
 
 func TestBuildCostRules_CoversAllRuntimeBuildDefs(t *testing.T) {
-	rules := BuildCostRules()
-	if got, want := len(rules), len(buildDefsByObjectType); got != want {
+	rules := DefaultRuleSet().BuildCostRules()
+	if got, want := len(rules), len(DefaultRuleSet().Buildings); got != want {
 		t.Fatalf("build cost count mismatch: got=%d want=%d", got, want)
 	}
-	for objectType, def := range buildDefsByObjectType {
+	for objectType, def := range DefaultRuleSet().Buildings {
 		cost, ok := rules[objectType]
 		if !ok {
 			t.Fatalf("missing exported build cost for object_type=%s", objectType)
@@ -0,0 +1,72 @@
+package survival
+
+import (
+	"strconv"
+	"time"
+)
+
+// SpawnPosition is where new and respawned agents start.
+var SpawnPosition = Position{X: 0, Y: 0}
+
+func SpawnVitals() Vitals {
+	return Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80}
+}
+
+// LegacySessionID is the session of agents whose state predates stored
+// session IDs.
+func LegacySessionID(agentID string) string {
+	return "session-" + agentID
+}
+
+// ActiveSessionID falls back to the legacy session for states loaded
+// without a stored one.
+func (s AgentStateAggregate) ActiveSessionID() string {
+	if s.SessionID != "" {
+		return s.SessionID
+	}
+	return LegacySessionID(s.AgentID)
+}
+
+func NewSessionID(agentID string, at time.Time) string {
+	return LegacySessionID(agentID) + "-" + strconv.FormatInt(at.UnixNano(), 36)
+}
+
+// Respawn starts a new life for a dead agent. Up to RespawnLegacyItemCount
+// of legacyItem carry over from the old inventory with their age or wear;
+// escrow is kept so open trade offers still settle.
+func Respawn(prev AgentStateAggregate, sessionID, legacyItem string, now time.Time) AgentStateAggregate {
+	next := AgentStateAggregate{
+		AgentID:           prev.AgentID,
+		SessionID:         sessionID,
+		Vitals:            SpawnVitals(),
+		Position:          SpawnPosition,
+		Home:              SpawnPosition,
+		Inventory:         map[string]int{},
+		InventoryCapacity: DefaultInventoryCapacity,
+		DeathCause:        DeathCauseUnknown,
+		Version:           prev.Version,
+		UpdatedAt:         now,
+	}
+	if prev.Escrow != nil {
+		next.Escrow = map[string]int{}
+		for item, count := range prev.Escrow {
+			next.Escrow[item] = count
+		}
+	}
+	if carried := RespawnLegacyCount(prev, legacyItem); carried > 0 {
+		next.AddItem(legacyItem, carried)
+		next.InventoryUsed = carried
+		next.Freshness = SyncFoodStacks(next.Inventory, prev.Freshness, now)
+		next.ToolStacks = cloneToolStacks(prev.ToolStacks)
+		next.ToolDurability = cloneIntMap(prev.ToolDurability)
+		syncToolDurability(&next)
+	}
+	return next
+}
+
+func RespawnLegacyCount(prev AgentStateAggregate, legacyItem string) int {
+	if legacyItem == "" {
+		return 0
+	}
+	return min(prev.Inventory[legacyItem], RespawnLegacyItemCount)
+}
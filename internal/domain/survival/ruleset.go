@@ -0,0 +1,364 @@
+package survival
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RuleSetVersion is the only rule file version this build understands.
+const RuleSetVersion = 1
+
+var ErrInvalidRuleSet = errors.New("invalid rule set")
+
+//go:embed default_rules.json
+var defaultRuleSetJSON []byte
+
+var defaultRuleSet *RuleSet
+
+func init() {
+	defaultRuleSet = mustParseRuleSet(defaultRuleSetJSON)
+}
+
+// RuleSet holds the balance numbers that can be tuned without a rebuild:
+// vitals drains and action costs, sleep, temperature, cooldowns, recipes,
+// build costs, food recovery, crops and farm timings, furnace timings, repair
+// and deconstruct shares and the trade offer lifetime. Values are read-only
+// once loaded; share one instance across services.
+//
+// The rest of tuning.go stays compiled in because free domain helpers and
+// adapters read it without a rule set: tool durability and wear, gear stats
+// and weapon damage, food shelf life and spoilage, resource respawn times,
+// seed drop chances, respawn legacy items, weather and season effects, heat,
+// vision and light radii, shelter threat reduction, inventory capacity,
+// object HP and decay, status thresholds and rest and travel bounds.
+type RuleSet struct {
+	Version      int                        `json:"version"`
+	Drains       DrainRules                 `json:"drains"`
+	ActionDeltas map[ActionType]ActionDelta `json:"action_deltas"`
+	Sleep        SleepRules                 `json:"sleep"`
+	Temperature  TemperatureRule            `json:"temperature"`
+	Cooldowns    map[ActionType]int         `json:"cooldown_seconds"`
+	Recipes      map[RecipeID]RecipeRule    `json:"recipes"`
+	Buildings    map[string]BuildingRule    `json:"buildings"`
+	Foods        map[string]FoodRule        `json:"foods"`
+	Farming      FarmingRules               `json:"farming"`
+	Furnace      FurnaceRules               `json:"furnace"`
+	Objects      ObjectRules                `json:"objects"`
+	Trade        TradeRules                 `json:"trade"`
+}
+
+type DrainRules struct {
+	BaseHungerPer30   int     `json:"base_hunger_per_30"`
+	BaseThirstPer30   int     `json:"base_thirst_per_30"`
+	HPCapPer30        int     `json:"hp_cap_per_30"`
+	HPFromHungerCoeff float64 `json:"hp_from_hunger_coeff"`
+	HPFromEnergyCoeff float64 `json:"hp_from_energy_coeff"`
+	HPFromThirstCoeff float64 `json:"hp_from_thirst_coeff"`
+}
+
+// ActionDelta is the vitals change per standard tick of an action.
+type ActionDelta struct {
+	Hunger int `json:"hunger"`
+	Energy int `json:"energy"`
+	Thirst int `json:"thirst,omitempty"`
+}
+
+// SleepRules cover what the sleep action delta does not: HP recovery, the
+// good bed recovery and the bonus for sleeping under a roof.
+type SleepRules struct {
+	HPRecovery         int `json:"hp_recovery"`
+	GoodHunger         int `json:"good_hunger"`
+	GoodEnergy         int `json:"good_energy"`
+	GoodHP             int `json:"good_hp"`
+	ShelterEnergyBonus int `json:"shelter_energy_bonus"`
+	ShelterHPBonus     int `json:"shelter_hp_bonus"`
+}
+
+type TemperatureRule struct {
+	SeasonBase           map[string]int `json:"season_base"`
+	NightDrop            int            `json:"night_drop"`
+	WeatherDelta         map[string]int `json:"weather_delta"`
+	BiomeDelta           map[string]int `json:"biome_delta"`
+	HeatSourceWarmth     int            `json:"heat_source_warmth"`
+	ShelterWarmth        int            `json:"shelter_warmth"`
+	ShelterSleepWarmth   int            `json:"shelter_sleep_warmth"`
+	ColdThreshold        int            `json:"cold_threshold"`
+	FreezingThreshold    int            `json:"freezing_threshold"`
+	ColdEnergyDrainPer30 int            `json:"cold_energy_drain_per_30m"`
+	FreezingHPDrainPer30 int            `json:"freezing_hp_drain_per_30m"`
+}
+
+type RecipeRule struct {
+	In           map[string]int `json:"in"`
+	Out          map[string]int `json:"out"`
+	Requirements []string       `json:"requirements,omitempty"`
+}
+
+type BuildingRule struct {
+	Kind BuildKind      `json:"kind"`
+	Cost map[string]int `json:"cost"`
+}
+
+type FoodRule struct {
+	ID             FoodID `json:"id"`
+	HungerRecovery int    `json:"hunger_recovery"`
+}
+
+// FarmingRules cover crop growth. Watering leaves a boost that adds
+// WaterGrowBonusPercent growth for WaterBoostMinutes; a plot left unwatered
+// for WitherMinutes withers.
+type FarmingRules struct {
+	Crops                 map[CropType]CropRule `json:"crops"`
+	WaterBoostMinutes     int                   `json:"water_boost_minutes"`
+	WaterGrowBonusPercent int                   `json:"water_grow_bonus_percent"`
+	WitherMinutes         int                   `json:"wither_minutes"`
+}
+
+type FurnaceRules struct {
+	FuelMinutes   map[string]int   `json:"fuel_minutes"`
+	RecipeMinutes map[RecipeID]int `json:"recipe_minutes"`
+}
+
+// ObjectRules are the shares of an object's build cost paid to repair it and
+// refunded when it is deconstructed.
+type ObjectRules struct {
+	RepairCostPercent        int `json:"repair_cost_percent"`
+	DeconstructRefundPercent int `json:"deconstruct_refund_percent"`
+}
+
+type TradeRules struct {
+	OfferTTLMinutes int `json:"offer_ttl_minutes"`
+}
+
+// DefaultRuleSet returns the rules embedded in the binary.
+func DefaultRuleSet() *RuleSet {
+	return defaultRuleSet
+}
+
+// ParseRuleSet decodes a JSON rule file and validates it. Unknown fields are
+// rejected so typos fail at startup instead of silently using zero values.
+func ParseRuleSet(data []byte) (*RuleSet, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var rules RuleSet
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRuleSet, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if defaultRuleSet != nil {
+		if err := rules.matchesCatalog(defaultRuleSet); err != nil {
+			return nil, err
+		}
+	}
+	return &rules, nil
+}
+
+func mustParseRuleSet(data []byte) *RuleSet {
+	rules, err := ParseRuleSet(data)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+func (r *RuleSet) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidRuleSet, fmt.Sprintf(format, args...))
+	}
+	if r.Version != RuleSetVersion {
+		return invalid("unsupported version %d", r.Version)
+	}
+	if r.Drains.BaseHungerPer30 < 0 || r.Drains.BaseThirstPer30 < 0 || r.Drains.HPCapPer30 < 0 ||
+		r.Drains.HPFromHungerCoeff < 0 || r.Drains.HPFromEnergyCoeff < 0 || r.Drains.HPFromThirstCoeff < 0 {
+		return invalid("drains must not be negative")
+	}
+	for actionType := range r.ActionDeltas {
+		if _, ok := actionCostRequirements[actionType]; !ok {
+			return invalid("unknown action %q", actionType)
+		}
+	}
+	for actionType := range actionCostRequirements {
+		if _, ok := r.ActionDeltas[actionType]; !ok && actionType != ActionRespawn {
+			return invalid("missing action %q", actionType)
+		}
+	}
+	if r.Sleep.HPRecovery < 0 || r.Sleep.GoodHunger < 0 || r.Sleep.GoodEnergy < 0 || r.Sleep.GoodHP < 0 ||
+		r.Sleep.ShelterEnergyBonus < 0 || r.Sleep.ShelterHPBonus < 0 {
+		return invalid("sleep recovery must not be negative")
+	}
+	if err := r.Temperature.validate(); err != nil {
+		return invalid("%v", err)
+	}
+	for actionType, seconds := range r.Cooldowns {
+		if _, ok := actionCostRequirements[actionType]; !ok || seconds <= 0 {
+			return invalid("cooldown %q needs a known action and positive seconds", actionType)
+		}
+	}
+	for id, recipe := range r.Recipes {
+		if id <= 0 || !positiveAmounts(recipe.In) || !positiveAmounts(recipe.Out) {
+			return invalid("recipe %d needs positive inputs and outputs", id)
+		}
+	}
+	for objectType, building := range r.Buildings {
+		if building.Kind < BuildBed || building.Kind > BuildFurnace || !positiveAmounts(building.Cost) {
+			return invalid("building %q needs a known kind and a positive cost", objectType)
+		}
+	}
+	for kind := BuildBed; kind <= BuildFurnace; kind++ {
+		if _, ok := r.Buildings[kindToObjectType(kind)]; !ok {
+			return invalid("missing building %q", kindToObjectType(kind))
+		}
+	}
+	ids := map[FoodID]string{}
+	for item, food := range r.Foods {
+		if food.ID <= 0 || food.HungerRecovery <= 0 {
+			return invalid("food %q needs an id and positive hunger recovery", item)
+		}
+		if other, dup := ids[food.ID]; dup {
+			return invalid("foods %q and %q share id %d", other, item, food.ID)
+		}
+		ids[food.ID] = item
+	}
+	if err := r.Farming.validate(); err != nil {
+		return invalid("%v", err)
+	}
+	if !positiveAmounts(r.Furnace.FuelMinutes) {
+		return invalid("furnace fuel minutes must be positive")
+	}
+	for _, id := range r.recipeIDs() {
+		if minutes := r.Furnace.RecipeMinutes[id]; r.IsFurnaceRecipe(id) && minutes <= 0 {
+			return invalid("furnace recipe %d needs positive minutes", id)
+		}
+	}
+	for id := range r.Furnace.RecipeMinutes {
+		if !r.IsFurnaceRecipe(id) {
+			return invalid("recipe %d does not need a furnace", id)
+		}
+	}
+	if r.Objects.RepairCostPercent < 0 || r.Objects.DeconstructRefundPercent < 0 || r.Objects.DeconstructRefundPercent > 100 {
+		return invalid("repair cost and deconstruct refund percents out of range")
+	}
+	if r.Trade.OfferTTLMinutes <= 0 {
+		return invalid("trade offer ttl must be positive")
+	}
+	return nil
+}
+
+// matchesCatalog keeps a rule file to the object and food types the server
+// already knows how to handle; files tune those types but cannot add new ones.
+func (r *RuleSet) matchesCatalog(base *RuleSet) error {
+	for objectType, building := range r.Buildings {
+		if known, ok := base.Buildings[objectType]; !ok || known.Kind != building.Kind {
+			return fmt.Errorf("%w: unknown building %q", ErrInvalidRuleSet, objectType)
+		}
+	}
+	for item, food := range r.Foods {
+		if known, ok := base.Foods[item]; !ok || known.ID != food.ID {
+			return fmt.Errorf("%w: unknown food %q", ErrInvalidRuleSet, item)
+		}
+	}
+	return nil
+}
+
+func (f FarmingRules) validate() error {
+	for crop, rule := range f.Crops {
+		if !slices.Contains(knownCrops, crop) {
+			return fmt.Errorf("unknown crop %q", crop)
+		}
+		if rule.GrowMinutes <= 0 || rule.Yield <= 0 || strings.TrimSpace(rule.YieldItem) == "" {
+			return fmt.Errorf("crop %q needs positive grow minutes and a yield", crop)
+		}
+	}
+	for _, crop := range knownCrops {
+		if _, ok := f.Crops[crop]; !ok {
+			return fmt.Errorf("missing crop %q", crop)
+		}
+	}
+	if f.WaterBoostMinutes < 0 || f.WaterGrowBonusPercent < 0 || f.WitherMinutes <= 0 {
+		return errors.New("farm watering must not be negative and wither minutes must be positive")
+	}
+	return nil
+}
+
+func (t TemperatureRule) validate() error {
+	for _, season := range []string{"spring", "summer", "autumn", "winter"} {
+		if _, ok := t.SeasonBase[season]; !ok {
+			return fmt.Errorf("missing season %q", season)
+		}
+	}
+	if t.NightDrop < 0 || t.HeatSourceWarmth < 0 || t.ShelterWarmth < 0 || t.ShelterSleepWarmth < 0 ||
+		t.ColdEnergyDrainPer30 < 0 || t.FreezingHPDrainPer30 < 0 {
+		return errors.New("temperature warmth and drains must not be negative")
+	}
+	if t.FreezingThreshold > t.ColdThreshold {
+		return errors.New("freezing threshold above cold threshold")
+	}
+	return nil
+}
+
+// Delta returns the per-tick vitals change of an action. Validate requires
+// every action but respawn to be listed.
+func (r *RuleSet) Delta(actionType ActionType) ActionDelta {
+	return r.ActionDeltas[actionType]
+}
+
+// Cooldown returns how long an action blocks itself after settling.
+func (r *RuleSet) Cooldown(actionType ActionType) (time.Duration, bool) {
+	seconds, ok := r.Cooldowns[actionType]
+	return time.Duration(seconds) * time.Second, ok
+}
+
+func (r *RuleSet) Recipe(id RecipeID) (RecipeRule, bool) {
+	recipe, ok := r.Recipes[id]
+	return recipe, ok
+}
+
+func (r *RuleSet) Building(objectType string) (BuildingRule, bool) {
+	building, ok := r.Buildings[strings.ToLower(strings.TrimSpace(objectType))]
+	return building, ok
+}
+
+// FoodID resolves an eatable item type to its food id.
+func (r *RuleSet) FoodID(itemType string) (FoodID, bool) {
+	food, ok := r.Foods[strings.ToLower(strings.TrimSpace(itemType))]
+	return food.ID, ok
+}
+
+func (r *RuleSet) food(id FoodID) (string, FoodRule, bool) {
+	for item, food := range r.Foods {
+		if food.ID == id {
+			return item, food, true
+		}
+	}
+	return "", FoodRule{}, false
+}
+
+func (r *RuleSet) recipeIDs() []RecipeID {
+	ids := make([]RecipeID, 0, len(r.Recipes))
+	for id := range r.Recipes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func positiveAmounts(items map[string]int) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, qty := range items {
+		if qty <= 0 {
+			return false
+		}
+	}
+	return true
+}
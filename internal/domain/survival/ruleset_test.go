@@ -0,0 +1,107 @@
+package survival
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultRuleSet_ListsEveryAction(t *testing.T) {
+	rules := DefaultRuleSet()
+	for actionType := range actionCostRequirements {
+		if _, ok := rules.ActionDeltas[actionType]; !ok && actionType != ActionRespawn {
+			t.Fatalf("missing delta for %s", actionType)
+		}
+	}
+	if got := rules.Delta(ActionTravel); got != (ActionDelta{Hunger: -3, Energy: -6}) {
+		t.Fatalf("travel delta mismatch: %+v", got)
+	}
+	if got := rules.Delta(ActionDrink); got != (ActionDelta{Thirst: 30}) {
+		t.Fatalf("drink delta mismatch: %+v", got)
+	}
+}
+
+func TestParseRuleSet_RejectsInvalidFiles(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":   `{"version":1,"bogus":true}`,
+		"wrong version":   `{"version":2}`,
+		"unknown action":  `{"version":1,"action_deltas":{"fly":{"energy":-1}}}`,
+		"empty recipe":    `{"version":1,"recipes":{"1":{"in":{},"out":{"plank":1}}}}`,
+		"missing builds":  `{"version":1}`,
+		"negative drains": `{"version":1,"drains":{"base_thirst_per_30":-1}}`,
+		"unknown food":    strings.Replace(string(defaultRuleSetJSON), `"potato"`, `"cake"`, 1),
+		"missing action":  strings.Replace(string(defaultRuleSetJSON), `"move": {"hunger": -1, "energy": -2},`, ``, 1),
+		"missing season":  strings.Replace(string(defaultRuleSetJSON), `"winter": 0`, `"monsoon": 0`, 1),
+		"freezing warmer": strings.Replace(string(defaultRuleSetJSON), `"freezing_threshold": 0`, `"freezing_threshold": 9`, 1),
+		"bad cooldown":    strings.Replace(string(defaultRuleSetJSON), `"move": 60`, `"move": 0`, 1),
+		"unknown crop":    strings.Replace(string(defaultRuleSetJSON), `"potato": {"grow_minutes"`, `"rice": {"grow_minutes"`, 1),
+		"no furnace time": strings.Replace(string(defaultRuleSetJSON), `"recipe_minutes": {"3": 30, "4": 20}`, `"recipe_minutes": {"3": 30}`, 1),
+		"no offer ttl":    strings.Replace(string(defaultRuleSetJSON), `"offer_ttl_minutes": 60`, `"offer_ttl_minutes": 0`, 1),
+	}
+	for name, raw := range cases {
+		if _, err := ParseRuleSet([]byte(raw)); !errors.Is(err, ErrInvalidRuleSet) {
+			t.Fatalf("%s: expected ErrInvalidRuleSet, got %v", name, err)
+		}
+	}
+}
+
+func TestSettlementService_UsesInjectedRuleSet(t *testing.T) {
+	rules, err := ParseRuleSet(defaultRuleSetJSON)
+	if err != nil {
+		t.Fatalf("parse default rules: %v", err)
+	}
+	rules.ActionDeltas[ActionGather] = ActionDelta{Hunger: 0, Energy: -20}
+	rules.Recipes[RecipePlank] = RecipeRule{In: map[string]int{"wood": 1}, Out: map[string]int{"plank": 3}}
+	rules.Farming.Crops[CropWheat] = CropRule{GrowMinutes: 60, YieldItem: "wheat", Yield: 5}
+
+	state := AgentStateAggregate{Vitals: Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80}, Inventory: map[string]int{"wood": 1}}
+	svc := SettlementService{Rules: rules}
+	now := time.Unix(1700000000, 0)
+	gathered, err := svc.Settle(state, ActionIntent{Type: ActionGather}, HeartbeatDelta{Minutes: StandardTickMinutes}, now, WorldSnapshot{TimeOfDay: "day"})
+	if err != nil {
+		t.Fatalf("settle gather: %v", err)
+	}
+	if got, want := gathered.UpdatedState.Vitals.Energy, 40; got != want {
+		t.Fatalf("expected injected gather cost, energy got=%d want=%d", got, want)
+	}
+	crafted, err := svc.Settle(state, ActionIntent{Type: ActionCraft, RecipeID: int(RecipePlank)}, HeartbeatDelta{Minutes: StandardTickMinutes}, now, WorldSnapshot{TimeOfDay: "day"})
+	if err != nil {
+		t.Fatalf("settle craft: %v", err)
+	}
+	if got := crafted.UpdatedState.Inventory["plank"]; got != 3 {
+		t.Fatalf("expected injected recipe output, got plank=%d", got)
+	}
+	harvested, err := svc.Settle(state, ActionIntent{Type: ActionFarmHarvest, ItemType: "wheat"}, HeartbeatDelta{Minutes: StandardTickMinutes}, now, WorldSnapshot{TimeOfDay: "day"})
+	if err != nil {
+		t.Fatalf("settle harvest: %v", err)
+	}
+	if got := harvested.UpdatedState.Inventory["wheat"]; got != 5 {
+		t.Fatalf("expected injected crop yield, got wheat=%d", got)
+	}
+	if DefaultRuleSet().Recipes[RecipePlank].Out["plank"] != 1 {
+		t.Fatalf("expected default rules untouched")
+	}
+}
+
+func TestSettlementService_InjectedRuleSetTunesGoodSleepAndCold(t *testing.T) {
+	rules, err := ParseRuleSet(defaultRuleSetJSON)
+	if err != nil {
+		t.Fatalf("parse default rules: %v", err)
+	}
+	rules.Sleep.GoodEnergy = 60
+	rules.Temperature.ColdThreshold = 30
+	rules.Temperature.ColdEnergyDrainPer30 = 5
+
+	state := AgentStateAggregate{Vitals: Vitals{HP: 50, Hunger: 60, Energy: 10, Thirst: 80}}
+	out, err := SettlementService{Rules: rules}.Settle(state, ActionIntent{Type: ActionSleep, BedQuality: "GOOD"}, HeartbeatDelta{Minutes: StandardTickMinutes}, time.Unix(1700000000, 0), WorldSnapshot{TimeOfDay: "day", Season: "spring"})
+	if err != nil {
+		t.Fatalf("settle sleep: %v", err)
+	}
+	if got, want := out.UpdatedState.Vitals.Energy, 10+60-5; got != want {
+		t.Fatalf("expected injected good sleep and cold drain, energy got=%d want=%d", got, want)
+	}
+	if DefaultRuleSet().Sleep.GoodEnergy != 45 || DefaultRuleSet().IsCold(14) {
+		t.Fatalf("expected default rules untouched")
+	}
+}
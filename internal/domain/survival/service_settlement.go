@@ -9,56 +9,83 @@ import (
 
 var ErrInvalidDelta = errors.New("invalid delta minutes")
 
-type SettlementService struct{}
+type SettlementService struct {
+	// Rules defaults to DefaultRuleSet when nil.
+	Rules *RuleSet
+}
+
+func (s SettlementService) RuleSet() *RuleSet {
+	if s.Rules != nil {
+		return s.Rules
+	}
+	return DefaultRuleSet()
+}
 
-func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent, delta HeartbeatDelta, now time.Time, snapshot WorldSnapshot) (SettlementResult, error) {
+func (s SettlementService) Settle(state AgentStateAggregate, intent ActionIntent, delta HeartbeatDelta, now time.Time, snapshot WorldSnapshot) (SettlementResult, error) {
 	deltaMinutes := delta.Minutes
 	if deltaMinutes <= 0 {
 		return SettlementResult{}, ErrInvalidDelta
 	}
+	rules := s.RuleSet()
 	next := cloneAgentState(state)
 	next.UpdatedAt = now
+	SyncFreshness(&next, now)
+	syncToolDurability(&next)
 	actionEvents := make([]DomainEvent, 0, 2)
+	var combat *CombatOutcome
 	hpReasons := make([]map[string]any, 0, 4)
 	hungerReasons := make([]map[string]any, 0, 4)
 	energyReasons := make([]map[string]any, 0, 4)
+	thirstReasons := make([]map[string]any, 0, 4)
 
 	// Baseline drains per standard tick.
-	applyReasonedDelta(&next.Vitals.Hunger, -scaledInt(BaseHungerDrainPer30, deltaMinutes), "BASE_HUNGER_DRAIN", &hungerReasons)
+	applyReasonedDelta(&next.Vitals.Hunger, -scaledInt(rules.Drains.BaseHungerPer30, deltaMinutes), "BASE_HUNGER_DRAIN", &hungerReasons)
+	applyReasonedDelta(&next.Vitals.Thirst, -scaledInt(rules.Drains.BaseThirstPer30, deltaMinutes), "BASE_THIRST_DRAIN", &thirstReasons)
 
 	switch intent.Type {
 	case ActionGather:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionGatherDeltaEnergy, deltaMinutes), "ACTION_GATHER_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionGatherDeltaHunger, deltaMinutes), "ACTION_GATHER_COST", &hungerReasons)
-		ApplyGather(&next, snapshot)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionGather).Energy, deltaMinutes), "ACTION_GATHER_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionGather).Hunger, deltaMinutes), "ACTION_GATHER_COST", &hungerReasons)
+		brokenTools := ApplyGather(&next, snapshot)
+		actionEvents = append(actionEvents, toolBrokenEvents(next, brokenTools, now)...)
 	case ActionRest:
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionRestDeltaHunger, deltaMinutes), "ACTION_REST_RECOVERY", &hungerReasons)
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionRestDeltaEnergy, deltaMinutes), "ACTION_REST_RECOVERY", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionRest).Hunger, deltaMinutes), "ACTION_REST_RECOVERY", &hungerReasons)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionRest).Energy, deltaMinutes), "ACTION_REST_RECOVERY", &energyReasons)
 	case ActionSleep:
-		sleepHunger, sleepEnergy, sleepHP := sleepRecoveryByQuality(intent.BedQuality)
+		sleepHunger, sleepEnergy, sleepHP := sleepRecoveryByQuality(rules, intent.BedQuality)
 		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(sleepHunger, deltaMinutes), "ACTION_SLEEP_RECOVERY", &hungerReasons)
 		applyReasonedDelta(&next.Vitals.Energy, scaledInt(sleepEnergy, deltaMinutes), "ACTION_SLEEP_RECOVERY", &energyReasons)
 		applyReasonedHPDelta(&next.Vitals.HP, scaledInt(sleepHP, deltaMinutes), "ACTION_SLEEP_RECOVERY", &hpReasons)
+		if snapshot.Sheltered {
+			applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Sleep.ShelterEnergyBonus, deltaMinutes), "SHELTER_SLEEP_BONUS", &energyReasons)
+			applyReasonedHPDelta(&next.Vitals.HP, scaledInt(rules.Sleep.ShelterHPBonus, deltaMinutes), "SHELTER_SLEEP_BONUS", &hpReasons)
+		}
 	case ActionMove:
-		moveEnergyCost := scaledInt(-ActionMoveDeltaEnergy, deltaMinutes)
+		moveEnergyCost := scaledInt(-rules.Delta(ActionMove).Energy, deltaMinutes)
 		if moveEnergyCost < 1 {
 			moveEnergyCost = 1
 		}
 		applyReasonedDelta(&next.Vitals.Energy, -moveEnergyCost, "ACTION_MOVE_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionMoveDeltaHunger, deltaMinutes), "ACTION_MOVE_COST", &hungerReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionMove).Hunger, deltaMinutes), "ACTION_MOVE_COST", &hungerReasons)
 		next.Position.X += intent.DX
 		next.Position.Y += intent.DY
+	case ActionTravel:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionTravel).Energy, deltaMinutes), "ACTION_TRAVEL_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionTravel).Hunger, deltaMinutes), "ACTION_TRAVEL_COST", &hungerReasons)
+		if intent.Pos != nil {
+			next.Position = *intent.Pos
+		}
 	case ActionBuild:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionBuildDeltaEnergy, deltaMinutes), "ACTION_BUILD_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionBuildDeltaHunger, deltaMinutes), "ACTION_BUILD_COST", &hungerReasons)
-		if _, ok := buildKindFromIntent(intent.ObjectType); !ok {
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionBuild).Energy, deltaMinutes), "ACTION_BUILD_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionBuild).Hunger, deltaMinutes), "ACTION_BUILD_COST", &hungerReasons)
+		if _, ok := rules.Building(intent.ObjectType); !ok {
 			break
 		}
 		buildX, buildY := next.Position.X, next.Position.Y
 		if intent.Pos != nil {
 			buildX, buildY = intent.Pos.X, intent.Pos.Y
 		}
-		obj, ok := BuildObject(&next, intent.ObjectType, buildX, buildY)
+		obj, ok := rules.BuildObject(&next, intent.ObjectType, buildX, buildY)
 		if ok {
 			actionEvents = append(actionEvents, DomainEvent{
 				Type:       "build_completed",
@@ -72,56 +99,191 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 			})
 		}
 	case ActionFarm, ActionFarmPlant:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionFarmPlantDeltaEnergy, deltaMinutes), "ACTION_FARM_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionFarmPlantDeltaHunger, deltaMinutes), "ACTION_FARM_COST", &hungerReasons)
-		_, _ = PlantSeed(&next)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionFarmPlant).Energy, deltaMinutes), "ACTION_FARM_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionFarmPlant).Hunger, deltaMinutes), "ACTION_FARM_COST", &hungerReasons)
+		if crop, ok := ParseCrop(intent.ItemType); ok {
+			_, _ = rules.PlantCrop(&next, crop, 0)
+		}
 	case ActionFarmHarvest:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionFarmHarvestDeltaEnergy, deltaMinutes), "ACTION_FARM_HARVEST_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionFarmHarvestDeltaHunger, deltaMinutes), "ACTION_FARM_HARVEST_COST", &hungerReasons)
-		next.AddItem("wheat", 2)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionFarmHarvest).Energy, deltaMinutes), "ACTION_FARM_HARVEST_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionFarmHarvest).Hunger, deltaMinutes), "ACTION_FARM_HARVEST_COST", &hungerReasons)
+		if crop, ok := ParseCrop(intent.ItemType); ok {
+			plot := FarmPlot{Crop: crop, Ready: true}
+			_ = rules.HarvestFarm(&next, &plot)
+		}
 		if shouldReturnHarvestSeed(now) {
 			next.AddItem("seed", 1)
 		}
+	case ActionFarmWater:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionFarmWater).Energy, deltaMinutes), "ACTION_FARM_WATER_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionFarmWater).Hunger, deltaMinutes), "ACTION_FARM_WATER_COST", &hungerReasons)
+		_ = WaterFromSource(&next, intent.ItemType)
+	case ActionRepair:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionRepair).Energy, deltaMinutes), "ACTION_REPAIR_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionRepair).Hunger, deltaMinutes), "ACTION_REPAIR_COST", &hungerReasons)
+		if rules.RepairObject(&next, intent.ObjectType) {
+			actionEvents = append(actionEvents, DomainEvent{
+				Type:       "object_repaired",
+				OccurredAt: now,
+				Payload: map[string]any{
+					"object_id":   intent.ObjectID,
+					"object_type": intent.ObjectType,
+					"hp":          ObjectMaxHP,
+				},
+			})
+		}
+	case ActionDeconstruct:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionDeconstruct).Energy, deltaMinutes), "ACTION_DECONSTRUCT_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionDeconstruct).Hunger, deltaMinutes), "ACTION_DECONSTRUCT_COST", &hungerReasons)
+		if refund, ok := rules.Deconstruct(&next, intent.ObjectType, intent.ReturnSeed); ok {
+			actionEvents = append(actionEvents, DomainEvent{
+				Type:       "object_deconstructed",
+				OccurredAt: now,
+				Payload: map[string]any{
+					"object_id":   intent.ObjectID,
+					"object_type": intent.ObjectType,
+					"refund":      refund,
+				},
+			})
+		}
+	case ActionFurnaceFuel, ActionFurnaceLoad, ActionFurnaceCollect:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(intent.Type).Energy, deltaMinutes), "ACTION_FURNACE_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(intent.Type).Hunger, deltaMinutes), "ACTION_FURNACE_COST", &hungerReasons)
+		switch intent.Type {
+		case ActionFurnaceFuel:
+			_ = rules.FuelFurnace(&next, intent.ItemType, intent.Count)
+		case ActionFurnaceLoad:
+			_ = rules.LoadFurnace(&next, RecipeID(intent.RecipeID), intent.Count)
+		case ActionFurnaceCollect:
+			_ = CollectFurnace(&next, intent.Items)
+		}
 	case ActionContainerDeposit, ActionContainerWithdraw:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionContainerDepositDeltaEnergy, deltaMinutes), "ACTION_CONTAINER_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(intent.Type).Energy, deltaMinutes), "ACTION_CONTAINER_COST", &energyReasons)
 		applyContainerTransfer(&next, intent)
+		if intent.Type == ActionContainerWithdraw {
+			next.Freshness = AddFoodStacks(next.Freshness, intent.Stacks)
+			next.ToolStacks = AddToolStacks(next.ToolStacks, intent.ToolStacks)
+		}
 	case ActionRetreat:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionRetreatDeltaEnergy, deltaMinutes), "ACTION_RETREAT_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionRetreat).Energy, deltaMinutes), "ACTION_RETREAT_COST", &energyReasons)
 		if intent.DX != 0 || intent.DY != 0 {
 			next.Position.X += clampStep(intent.DX)
 			next.Position.Y += clampStep(intent.DY)
 		}
 	case ActionCraft:
-		applyReasonedDelta(&next.Vitals.Energy, scaledInt(ActionCraftDeltaEnergy, deltaMinutes), "ACTION_CRAFT_COST", &energyReasons)
-		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(ActionCraftDeltaHunger, deltaMinutes), "ACTION_CRAFT_COST", &hungerReasons)
-		_ = Craft(&next, RecipeID(intent.RecipeID))
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionCraft).Energy, deltaMinutes), "ACTION_CRAFT_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionCraft).Hunger, deltaMinutes), "ACTION_CRAFT_COST", &hungerReasons)
+		_ = rules.Craft(&next, RecipeID(intent.RecipeID))
 	case ActionEat:
 		beforeHunger := next.Vitals.Hunger
 		count := intent.Count
 		if count <= 0 {
 			count = 1
 		}
+		foodID, _ := rules.FoodID(intent.ItemType)
 		for i := 0; i < count; i++ {
-			if !Eat(&next, foodIDFromIntent(intent.ItemType)) {
+			if !rules.EatAt(&next, foodID, now) {
 				break
 			}
 		}
 		appendReason(&hungerReasons, "ACTION_EAT_RECOVERY", next.Vitals.Hunger-beforeHunger)
+	case ActionDrink:
+		beforeThirst := next.Vitals.Thirst
+		if intent.ItemType == ItemWaterFlask {
+			_ = DrinkFromFlask(&next)
+		} else {
+			rules.DrinkFromSource(&next)
+		}
+		appendReason(&thirstReasons, "ACTION_DRINK_RECOVERY", next.Vitals.Thirst-beforeThirst)
+	case ActionTradeOffer:
+		if !EscrowItems(&next, intent.Items) {
+			return SettlementResult{}, ErrTradeItemsMissing
+		}
+	case ActionTradeAccept:
+		if !AcceptTrade(&next, intentGoods(intent), intent.RequestItems, now) {
+			return SettlementResult{}, ErrTradeItemsMissing
+		}
+	case ActionTradeCancel:
+		ReleaseEscrow(&next, intentGoods(intent), now)
+	case ActionEquip, ActionUnequip:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(intent.Type).Energy, deltaMinutes), "ACTION_EQUIP_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(intent.Type).Hunger, deltaMinutes), "ACTION_EQUIP_COST", &hungerReasons)
+		if intent.Type == ActionEquip {
+			_ = Equip(&next, intent.ItemType)
+		} else if slot, ok := ParseEquipSlot(intent.Slot); ok {
+			_ = Unequip(&next, slot)
+		}
+	case ActionAttack:
+		applyReasonedDelta(&next.Vitals.Energy, scaledInt(rules.Delta(ActionAttack).Energy, deltaMinutes), "ACTION_ATTACK_COST", &energyReasons)
+		applyReasonedDelta(&next.Vitals.Hunger, scaledInt(rules.Delta(ActionAttack).Hunger, deltaMinutes), "ACTION_ATTACK_COST", &hungerReasons)
+		if target, ok := findThreat(snapshot.Threats, intent.TargetID); ok {
+			outcome := ResolveCombat(next, target)
+			combat = &outcome
+		}
 	}
 
-	hungerLossPotential := int(math.Round(scaledFloat(HPDrainFromHungerCoeff*float64(absMinZero(next.Vitals.Hunger)), deltaMinutes)))
-	energyLossPotential := int(math.Round(scaledFloat(HPDrainFromEnergyCoeff*float64(absMinZero(next.Vitals.Energy)), deltaMinutes)))
-	hpCap := scaledInt(HPDrainCapPer30, deltaMinutes)
-	hungerApplied, energyApplied := applyDualCap(hungerLossPotential, energyLossPotential, hpCap)
-	hpLoss := hungerApplied + energyApplied
+	if drain := WeatherEffectFor(snapshot.Weather).EnergyDrainPer30; drain > 0 && !snapshot.Sheltered {
+		applyReasonedDelta(&next.Vitals.Energy, -scaledInt(drain, deltaMinutes), "WEATHER_ENERGY_DRAIN", &energyReasons)
+	}
+	next.Temperature = rules.AgentTemperature(next, snapshot, intent.Type == ActionSleep)
+	if rules.IsCold(next.Temperature) {
+		applyReasonedDelta(&next.Vitals.Energy, -scaledInt(rules.Temperature.ColdEnergyDrainPer30, deltaMinutes), "COLD_ENERGY_DRAIN", &energyReasons)
+	}
+	freezeDamage := 0
+	if rules.IsFreezing(next.Temperature) {
+		freezeDamage = scaledInt(rules.Temperature.FreezingHPDrainPer30, deltaMinutes)
+		applyReasonedHPDelta(&next.Vitals.HP, -freezeDamage, "COLD_HP_DRAIN", &hpReasons)
+	}
+
+	hungerLossPotential := int(math.Round(scaledFloat(rules.Drains.HPFromHungerCoeff*float64(absMinZero(next.Vitals.Hunger)), deltaMinutes)))
+	energyLossPotential := int(math.Round(scaledFloat(rules.Drains.HPFromEnergyCoeff*float64(absMinZero(next.Vitals.Energy)), deltaMinutes)))
+	thirstLossPotential := int(math.Round(scaledFloat(rules.Drains.HPFromThirstCoeff*float64(absMinZero(next.Vitals.Thirst)), deltaMinutes)))
+	hpCap := scaledInt(rules.Drains.HPCapPer30, deltaMinutes)
+	applied := applyDrainCap(hpCap, hungerLossPotential, energyLossPotential, thirstLossPotential)
+	hungerApplied, energyApplied, thirstApplied := applied[0], applied[1], applied[2]
+	hpLoss := hungerApplied + energyApplied + thirstApplied
 	if hungerApplied > 0 {
 		appendReason(&hpReasons, "STARVING_HP_DRAIN", -hungerApplied)
 	}
 	if energyApplied > 0 {
 		appendReason(&hpReasons, "EXHAUSTED_HP_DRAIN", -energyApplied)
 	}
+	if thirstApplied > 0 {
+		appendReason(&hpReasons, "DEHYDRATED_HP_DRAIN", -thirstApplied)
+	}
 	applyReasonedHPDelta(&next.Vitals.HP, -hpLoss, "HP_LOSS_APPLIED", &hpReasons)
+	var threatDamage int
+	var attacker *ThreatContact
+	if !snapshot.Sheltered {
+		// Creatures cannot reach agents inside a closed wall ring.
+		threatDamage, attacker = threatDamageAt(next.Position, snapshot.Threats, deltaMinutes, combat)
+	}
+	if threatDamage > 0 {
+		applyReasonedHPDelta(&next.Vitals.HP, -threatDamage, "THREAT_DAMAGE", &hpReasons)
+	}
+	if combat != nil && combat.DamageTaken > 0 {
+		applyReasonedHPDelta(&next.Vitals.HP, -combat.DamageTaken, "COMBAT_DAMAGE", &hpReasons)
+		threatDamage += combat.DamageTaken
+		if target, ok := findThreat(snapshot.Threats, combat.TargetID); ok {
+			attacker = &target
+		}
+	}
+	SyncFreshness(&next, now)
+	syncToolDurability(&next)
+	if spoiled := SpoilFood(&next, now); len(spoiled) > 0 {
+		actionEvents = append(actionEvents, DomainEvent{
+			Type:       "food_spoiled",
+			OccurredAt: now,
+			Payload: map[string]any{
+				"items":       spoiled,
+				"rotten_food": next.Inventory[ItemRottenFood],
+			},
+		})
+	}
 	next.Version++
+	if combat != nil {
+		actionEvents = append(actionEvents, combatResolvedEvent(state, next, intent, *combat, snapshot.WorldTimeSeconds, deltaMinutes, now, hpReasons))
+	}
 
 	events := make([]DomainEvent, 0, 2)
 	events = append(events, DomainEvent{
@@ -134,6 +296,7 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 				"hp":             state.Vitals.HP,
 				"hunger":         state.Vitals.Hunger,
 				"energy":         state.Vitals.Energy,
+				"thirst":         state.Vitals.Thirst,
 				"x":              state.Position.X,
 				"y":              state.Position.Y,
 				"pos":            map[string]int{"x": state.Position.X, "y": state.Position.Y},
@@ -147,6 +310,8 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 				"hp":             next.Vitals.HP,
 				"hunger":         next.Vitals.Hunger,
 				"energy":         next.Vitals.Energy,
+				"thirst":         next.Vitals.Thirst,
+				"temperature":    next.Temperature,
 				"x":              next.Position.X,
 				"y":              next.Position.Y,
 				"pos":            map[string]int{"x": next.Position.X, "y": next.Position.Y},
@@ -154,16 +319,19 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 			},
 			"result": map[string]any{
 				"hp_loss":         hpLoss,
+				"threat_damage":   threatDamage,
 				"inventory_delta": inventoryDelta(state.Inventory, next.Inventory),
 				"vitals_delta": map[string]int{
 					"hp":     next.Vitals.HP - state.Vitals.HP,
 					"hunger": next.Vitals.Hunger - state.Vitals.Hunger,
 					"energy": next.Vitals.Energy - state.Vitals.Energy,
+					"thirst": next.Vitals.Thirst - state.Vitals.Thirst,
 				},
 				"vitals_change_reasons": map[string]any{
 					"hp":     hpReasons,
 					"hunger": hungerReasons,
 					"energy": energyReasons,
+					"thirst": thirstReasons,
 				},
 			},
 		},
@@ -171,7 +339,13 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 
 	resultCode := ResultOK
 	if next.Vitals.HP <= 0 {
-		next.MarkDead(deriveDeathCause(next, intent))
+		next.MarkDead(deriveDeathCause(rules, next, intent, map[DeathCause]int{
+			DeathCauseThreat:      threatDamage,
+			DeathCauseStarvation:  hungerApplied,
+			DeathCauseExhaustion:  energyApplied,
+			DeathCauseDehydration: thirstApplied,
+			DeathCauseHypothermia: freezeDamage,
+		}))
 		events = append(events, DomainEvent{
 			Type:       "game_over",
 			OccurredAt: now,
@@ -181,6 +355,7 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 					"hp":                 state.Vitals.HP,
 					"hunger":             state.Vitals.Hunger,
 					"energy":             state.Vitals.Energy,
+					"thirst":             state.Vitals.Thirst,
 					"position":           map[string]int{"x": state.Position.X, "y": state.Position.Y},
 					"inventory_used":     inventoryUsedCount(state.Inventory),
 					"world_time_seconds": snapshot.WorldTimeSeconds,
@@ -190,6 +365,7 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 					"hp":                 next.Vitals.HP,
 					"hunger":             next.Vitals.Hunger,
 					"energy":             next.Vitals.Energy,
+					"thirst":             next.Vitals.Thirst,
 					"position":           map[string]int{"x": next.Position.X, "y": next.Position.Y},
 					"inventory_used":     inventoryUsedCount(next.Inventory),
 					"world_time_seconds": snapshot.WorldTimeSeconds + int64(deltaMinutes*60),
@@ -199,12 +375,12 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 					"x": next.Home.X,
 					"y": next.Home.Y,
 				},
-				"last_known_threat": nil,
+				"last_known_threat": threatContactPayload(attacker),
 			},
 		})
 		resultCode = ResultGameOver
 	} else if next.Vitals.HP <= CriticalHPThreshold {
-		next.Position = moveToward(next.Position, next.Home)
+		next.Position = moveToward(next.Position, next.Home, snapshot.Blocked)
 		events = append(events, DomainEvent{Type: "critical_hp", OccurredAt: now})
 		events = append(events, DomainEvent{Type: "force_retreat", OccurredAt: now})
 	}
@@ -217,42 +393,6 @@ func (SettlementService) Settle(state AgentStateAggregate, intent ActionIntent,
 	}, nil
 }
 
-func buildKindFromIntent(objectType string) (BuildKind, bool) {
-	switch objectType {
-	case "bed", "bed_rough", "bed_good":
-		return BuildBed, true
-	case "box":
-		return BuildBox, true
-	case "farm_plot":
-		return BuildFarm, true
-	case "torch":
-		return BuildTorch, true
-	case "wall":
-		return BuildWall, true
-	case "door":
-		return BuildDoor, true
-	case "furnace":
-		return BuildFurnace, true
-	default:
-		return 0, false
-	}
-}
-
-func foodIDFromIntent(itemType string) FoodID {
-	switch itemType {
-	case "berry":
-		return FoodBerry
-	case "bread":
-		return FoodBread
-	case "wheat":
-		return FoodWheat
-	case "jam":
-		return FoodJam
-	default:
-		return FoodBerry
-	}
-}
-
 func intentDecisionParams(intent ActionIntent) map[string]any {
 	out := map[string]any{}
 	if intent.Direction != "" {
@@ -291,6 +431,12 @@ func intentDecisionParams(intent ActionIntent) map[string]any {
 	if intent.ContainerID != "" {
 		out["container_id"] = intent.ContainerID
 	}
+	if intent.ObjectID != "" {
+		out["object_id"] = intent.ObjectID
+	}
+	if intent.Slot != "" {
+		out["slot"] = intent.Slot
+	}
 	if len(intent.Items) > 0 {
 		items := make([]map[string]any, 0, len(intent.Items))
 		for _, item := range intent.Items {
@@ -341,38 +487,72 @@ func maxInt(a, b int) int {
 	return b
 }
 
-func moveToward(from, to Position) Position {
-	next := from
-	if from.X < to.X {
-		next.X++
-	} else if from.X > to.X {
-		next.X--
+// moveToward takes one step toward to, falling back to a single-axis step
+// when the diagonal is blocked and staying put when every step is.
+func moveToward(from, to Position, blocked map[Position]bool) Position {
+	dx, dy := sign(to.X-from.X), sign(to.Y-from.Y)
+	for _, step := range []Position{{X: dx, Y: dy}, {X: dx}, {Y: dy}} {
+		next := Position{X: from.X + step.X, Y: from.Y + step.Y}
+		if next != from && !blocked[next] {
+			return next
+		}
 	}
-	if from.Y < to.Y {
-		next.Y++
-	} else if from.Y > to.Y {
-		next.Y--
+	return from
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
 	}
-	return next
 }
 
-func deriveDeathCause(state AgentStateAggregate, intent ActionIntent) DeathCause {
+// deathCausePriority breaks ties between sources that dealt equal HP loss.
+var deathCausePriority = []DeathCause{
+	DeathCauseThreat,
+	DeathCauseStarvation,
+	DeathCauseExhaustion,
+	DeathCauseDehydration,
+	DeathCauseHypothermia,
+}
+
+// deriveDeathCause blames the source with the largest share of the HP lost
+// in the lethal tick, falling back to the depleted vitals.
+func deriveDeathCause(rules *RuleSet, state AgentStateAggregate, intent ActionIntent, hpLoss map[DeathCause]int) DeathCause {
+	cause, most := DeathCauseUnknown, 0
+	for _, c := range deathCausePriority {
+		if hpLoss[c] > most {
+			cause, most = c, hpLoss[c]
+		}
+	}
+	if cause != DeathCauseUnknown {
+		return cause
+	}
 	switch {
 	case state.Vitals.Hunger < 0:
 		return DeathCauseStarvation
 	case state.Vitals.Energy < 0:
 		return DeathCauseExhaustion
+	case state.Vitals.Thirst < 0:
+		return DeathCauseDehydration
+	case rules.IsFreezing(state.Temperature):
+		return DeathCauseHypothermia
 	default:
 		return DeathCauseUnknown
 	}
 }
 
-func sleepRecoveryByQuality(quality string) (hunger, energy, hp int) {
+func sleepRecoveryByQuality(rules *RuleSet, quality string) (hunger, energy, hp int) {
 	switch strings.ToUpper(strings.TrimSpace(quality)) {
 	case "GOOD":
-		return SleepGoodHungerRecovery, SleepGoodEnergyRecovery, SleepGoodHPRecovery
+		return rules.Sleep.GoodHunger, rules.Sleep.GoodEnergy, rules.Sleep.GoodHP
 	default:
-		return ActionSleepDeltaHunger, SleepBaseEnergyRecovery, SleepBaseHPRecovery
+		base := rules.Delta(ActionSleep)
+		return base.Hunger, base.Energy, rules.Sleep.HPRecovery
 	}
 }
 
@@ -411,6 +591,10 @@ func mapDeathCauseForEvent(cause DeathCause) string {
 		return "STARVATION"
 	case DeathCauseThreat:
 		return "THREAT"
+	case DeathCauseDehydration:
+		return "DEHYDRATION"
+	case DeathCauseHypothermia:
+		return "HYPOTHERMIA"
 	default:
 		return "UNKNOWN"
 	}
@@ -427,8 +611,9 @@ func clampStep(v int) int {
 }
 
 func shouldReturnHarvestSeed(now time.Time) bool {
-	// Deterministic 20% return to keep simulation testable and explainable.
-	return now.Unix()%5 == 0
+	// Deterministic one-in-N return at SeedReturnChance to keep simulation
+	// testable and explainable.
+	return now.Unix()%int64(math.Round(1/SeedReturnChance)) == 0
 }
 
 func appendReason(reasons *[]map[string]any, code string, delta int) {
@@ -462,14 +647,19 @@ func applyReasonedHPDelta(target *int, delta int, code string, reasons *[]map[st
 	appendReason(reasons, code, actual)
 }
 
-func applyDualCap(primary, secondary, cap int) (int, int) {
-	if cap <= 0 {
-		return 0, 0
+// applyDrainCap fills the shared HP drain cap in priority order: earlier
+// potentials are applied first and later ones only get what remains.
+func applyDrainCap(cap int, potentials ...int) []int {
+	applied := make([]int, len(potentials))
+	remaining := cap
+	for i, potential := range potentials {
+		if remaining <= 0 {
+			break
+		}
+		applied[i] = min(potential, remaining)
+		remaining -= applied[i]
 	}
-	primaryApplied := min(primary, cap)
-	remaining := cap - primaryApplied
-	secondaryApplied := min(secondary, remaining)
-	return primaryApplied, secondaryApplied
+	return applied
 }
 
 func inventoryDelta(before, after map[string]int) map[string]int {
@@ -498,12 +688,99 @@ func cloneAgentState(in AgentStateAggregate) AgentStateAggregate {
 			out.Inventory[k] = v
 		}
 	}
+	if in.ToolDurability != nil {
+		out.ToolDurability = cloneIntMap(in.ToolDurability)
+	}
+	out.ToolStacks = cloneToolStacks(in.ToolStacks)
+	if in.Escrow != nil {
+		out.Escrow = cloneIntMap(in.Escrow)
+	}
+	out.Freshness = cloneFoodStacks(in.Freshness)
+	out.Equipment = cloneEquipment(in.Equipment)
 	if in.StatusEffects != nil {
 		out.StatusEffects = append([]string(nil), in.StatusEffects...)
 	}
 	if in.OngoingAction != nil {
 		copyAction := *in.OngoingAction
+		copyAction.Path = append([]Position(nil), in.OngoingAction.Path...)
 		out.OngoingAction = &copyAction
 	}
 	return out
 }
+
+func threatDamageAt(pos Position, threats []ThreatContact, deltaMinutes int, combat *CombatOutcome) (int, *ThreatContact) {
+	total := 0
+	var strongest *ThreatContact
+	for i := range threats {
+		t := threats[i]
+		if absInt(t.X-pos.X)+absInt(t.Y-pos.Y) > ThreatAttackRange || t.Damage <= 0 {
+			continue
+		}
+		if combat != nil && combat.TargetID == t.ID {
+			continue
+		}
+		total += scaledInt(t.Damage, deltaMinutes)
+		if strongest == nil || t.Damage > strongest.Damage {
+			strongest = &threats[i]
+		}
+	}
+	return total, strongest
+}
+
+func threatContactPayload(t *ThreatContact) any {
+	if t == nil {
+		return nil
+	}
+	return map[string]any{
+		"id":   t.ID,
+		"type": t.Kind,
+		"pos":  map[string]int{"x": t.X, "y": t.Y},
+	}
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func combatResolvedEvent(before, after AgentStateAggregate, intent ActionIntent, outcome CombatOutcome, worldTimeSeconds int64, deltaMinutes int, now time.Time, hpReasons []map[string]any) DomainEvent {
+	return DomainEvent{
+		Type:       "combat_resolved",
+		OccurredAt: now,
+		Payload: map[string]any{
+			"world_time_before_seconds": worldTimeSeconds,
+			"world_time_after_seconds":  worldTimeSeconds + int64(deltaMinutes*60),
+			"state_before": map[string]any{
+				"hp":     before.Vitals.HP,
+				"hunger": before.Vitals.Hunger,
+				"energy": before.Vitals.Energy,
+				"pos":    map[string]int{"x": before.Position.X, "y": before.Position.Y},
+			},
+			"decision": map[string]any{
+				"intent": string(intent.Type),
+				"params": intentDecisionParams(intent),
+			},
+			"state_after": map[string]any{
+				"hp":     after.Vitals.HP,
+				"hunger": after.Vitals.Hunger,
+				"energy": after.Vitals.Energy,
+				"pos":    map[string]int{"x": after.Position.X, "y": after.Position.Y},
+			},
+			"result": map[string]any{
+				"target_id":        outcome.TargetID,
+				"target_kind":      outcome.TargetKind,
+				"weapon":           outcome.Weapon,
+				"damage_dealt":     outcome.DamageDealt,
+				"damage_taken":     outcome.DamageTaken,
+				"target_hp_before": outcome.TargetHPBefore,
+				"target_hp_after":  outcome.TargetHPAfter,
+				"target_killed":    outcome.TargetKilled,
+				"vitals_change_reasons": map[string]any{
+					"hp": hpReasons,
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package survival
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -605,3 +606,168 @@ func TestSettlementService_EatRespectsCount(t *testing.T) {
 		t.Fatalf("expected hunger to increase after eat count=2, got=%d", got)
 	}
 }
+
+func TestSettlementService_AdjacentThreatDealsDamageWithReason(t *testing.T) {
+	svc := SettlementService{}
+	state := AgentStateAggregate{
+		AgentID:  "a-1",
+		Vitals:   Vitals{HP: 100, Hunger: 80, Energy: 60},
+		Position: Position{X: 0, Y: 0},
+		Version:  1,
+	}
+	snapshot := WorldSnapshot{Threats: []ThreatContact{
+		{ID: "crt-near", Kind: "wolf", X: 1, Y: 0, Damage: 4},
+		{ID: "crt-far", Kind: "bear", X: 4, Y: 0, Damage: 6},
+	}}
+
+	out, err := svc.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), snapshot)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if got, want := out.UpdatedState.Vitals.HP, 96; got != want {
+		t.Fatalf("expected hp=%d after one adjacent bite, got %d", want, got)
+	}
+	result := out.Events[0].Payload["result"].(map[string]any)
+	if result["threat_damage"] != 4 {
+		t.Fatalf("expected threat_damage=4, got %v", result["threat_damage"])
+	}
+	reasons := result["vitals_change_reasons"].(map[string]any)["hp"].([]map[string]any)
+	found := false
+	for _, r := range reasons {
+		if r["code"] == "THREAT_DAMAGE" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected THREAT_DAMAGE reason, got %v", reasons)
+	}
+}
+
+func TestSettlementService_ThreatKillReportsThreatDeathCause(t *testing.T) {
+	svc := SettlementService{}
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 3, Hunger: 80, Energy: 60},
+		Version: 1,
+	}
+	snapshot := WorldSnapshot{Threats: []ThreatContact{{ID: "crt-1", Kind: "wolf", X: 0, Y: 1, Damage: 4}}}
+
+	out, err := svc.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), snapshot)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.ResultCode != ResultGameOver || out.UpdatedState.DeathCause != DeathCauseThreat {
+		t.Fatalf("expected threat game over, got code=%s cause=%s", out.ResultCode, out.UpdatedState.DeathCause)
+	}
+	for _, evt := range out.Events {
+		if evt.Type != "game_over" {
+			continue
+		}
+		last, _ := evt.Payload["last_known_threat"].(map[string]any)
+		if last == nil || last["id"] != "crt-1" {
+			t.Fatalf("expected killer in last_known_threat, got %v", evt.Payload["last_known_threat"])
+		}
+	}
+}
+
+func TestSettlementService_DeathCauseFollowsLargestHPLoss(t *testing.T) {
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 3, Hunger: -100, Energy: 60, Thirst: 80},
+		Version: 1,
+	}
+	snapshot := WorldSnapshot{Threats: []ThreatContact{{ID: "crt-1", Kind: "rat", X: 0, Y: 1, Damage: 1}}}
+
+	out, err := SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), snapshot)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.ResultCode != ResultGameOver {
+		t.Fatalf("expected game over, got %s", out.ResultCode)
+	}
+	if out.UpdatedState.DeathCause != DeathCauseStarvation {
+		t.Fatalf("expected starvation to outweigh a scratch of threat damage, got %s", out.UpdatedState.DeathCause)
+	}
+}
+
+func TestSettlementService_ShelteredSleepBlocksThreatsAndBoostsRecovery(t *testing.T) {
+	svc := SettlementService{}
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 50, Hunger: 80, Energy: 20},
+		Version: 1,
+	}
+	threats := []ThreatContact{{ID: "crt-1", Kind: "wolf", X: 1, Y: 0, Damage: 4}}
+	intent := ActionIntent{Type: ActionSleep}
+
+	open, err := svc.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{Threats: threats})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	sheltered, err := svc.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{Threats: threats, Sheltered: true})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if got := sheltered.Events[0].Payload["result"].(map[string]any)["threat_damage"]; got != 0 {
+		t.Fatalf("expected no threat damage while sheltered, got %v", got)
+	}
+	if sheltered.UpdatedState.Vitals.Energy <= open.UpdatedState.Vitals.Energy {
+		t.Fatalf("expected sheltered sleep to recover more energy, got %d vs %d", sheltered.UpdatedState.Vitals.Energy, open.UpdatedState.Vitals.Energy)
+	}
+	if sheltered.UpdatedState.Vitals.HP <= open.UpdatedState.Vitals.HP {
+		t.Fatalf("expected sheltered sleep to recover more hp, got %d vs %d", sheltered.UpdatedState.Vitals.HP, open.UpdatedState.Vitals.HP)
+	}
+}
+
+func TestSettlementService_DehydrationDrainsHP(t *testing.T) {
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 50, Hunger: 80, Energy: 60, Thirst: -100},
+		Version: 1,
+	}
+	out, err := SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.UpdatedState.Vitals.HP >= 50 {
+		t.Fatalf("expected dehydration to drain hp, got hp=%d", out.UpdatedState.Vitals.HP)
+	}
+	reasons := out.Events[0].Payload["result"].(map[string]any)["vitals_change_reasons"].(map[string]any)["hp"].([]map[string]any)
+	found := false
+	for _, r := range reasons {
+		if r["code"] == "DEHYDRATED_HP_DRAIN" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected DEHYDRATED_HP_DRAIN reason, got %v", reasons)
+	}
+
+	state.Vitals.HP = 3
+	out, err = SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.UpdatedState.DeathCause != DeathCauseDehydration {
+		t.Fatalf("expected dehydration death cause, got %s", out.UpdatedState.DeathCause)
+	}
+}
+
+func TestSettlementService_TradeFailsWhenItemsAreMissing(t *testing.T) {
+	svc := SettlementService{}
+	state := AgentStateAggregate{
+		AgentID:   "a-1",
+		Vitals:    Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+		Inventory: map[string]int{"wood": 1},
+		Version:   1,
+	}
+	for _, intent := range []ActionIntent{
+		{Type: ActionTradeOffer, Items: []ItemAmount{{ItemType: "wood", Count: 2}}},
+		{Type: ActionTradeAccept, Items: []ItemAmount{{ItemType: "stone", Count: 1}}, RequestItems: []ItemAmount{{ItemType: "wood", Count: 2}}},
+	} {
+		_, err := svc.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{})
+		if !errors.Is(err, ErrTradeItemsMissing) {
+			t.Fatalf("%s: expected ErrTradeItemsMissing, got %v", intent.Type, err)
+		}
+	}
+}
@@ -50,7 +50,7 @@ type AgentSession struct {
 
 func NewSession(agentID string, startTick int64) AgentSession {
 	return AgentSession{
-		ID:        "session-" + agentID,
+		ID:        LegacySessionID(agentID),
 		AgentID:   agentID,
 		StartTick: startTick,
 		Status:    SessionAlive,
@@ -0,0 +1,73 @@
+package survival
+
+// Temperatures are whole degrees; only the thresholds matter to the rules.
+type TemperatureModel struct {
+	SeasonBase           map[string]int `json:"season_base"`
+	NightDrop            int            `json:"night_drop"`
+	WeatherDelta         map[string]int `json:"weather_delta"`
+	BiomeDelta           map[string]int `json:"biome_delta"`
+	HeatSourceWarmth     int            `json:"heat_source_warmth"`
+	ShelterWarmth        int            `json:"shelter_warmth"`
+	ShelterSleepWarmth   int            `json:"shelter_sleep_warmth"`
+	TorchHeatRadius      int            `json:"torch_heat_radius"`
+	FurnaceHeatRadius    int            `json:"furnace_heat_radius"`
+	ColdThreshold        int            `json:"cold_threshold"`
+	FreezingThreshold    int            `json:"freezing_threshold"`
+	ColdEnergyDrainPer30 int            `json:"cold_energy_drain_per_30m"`
+	FreezingHPDrainPer30 int            `json:"freezing_hp_drain_per_30m"`
+}
+
+func (r *RuleSet) TemperatureRules() TemperatureModel {
+	t := r.Temperature
+	return TemperatureModel{
+		SeasonBase:           cloneIntMap(t.SeasonBase),
+		NightDrop:            t.NightDrop,
+		WeatherDelta:         cloneIntMap(t.WeatherDelta),
+		BiomeDelta:           cloneIntMap(t.BiomeDelta),
+		HeatSourceWarmth:     t.HeatSourceWarmth,
+		ShelterWarmth:        t.ShelterWarmth,
+		ShelterSleepWarmth:   t.ShelterSleepWarmth,
+		TorchHeatRadius:      TorchHeatRadius,
+		FurnaceHeatRadius:    FurnaceHeatRadius,
+		ColdThreshold:        t.ColdThreshold,
+		FreezingThreshold:    t.FreezingThreshold,
+		ColdEnergyDrainPer30: t.ColdEnergyDrainPer30,
+		FreezingHPDrainPer30: t.FreezingHPDrainPer30,
+	}
+}
+
+func (r *RuleSet) AmbientTemperature(timeOfDay, season, weather, biome string) int {
+	base, ok := r.Temperature.SeasonBase[season]
+	if !ok {
+		base = r.Temperature.SeasonBase["spring"]
+	}
+	t := base + r.Temperature.WeatherDelta[weather] + r.Temperature.BiomeDelta[biome]
+	if timeOfDay == "night" {
+		t -= r.Temperature.NightDrop
+	}
+	return t
+}
+
+// BodyTemperature is the ambient temperature at the agent's tile plus warmth
+// from nearby heat sources and walls. Sleeping indoors traps extra heat.
+func (r *RuleSet) BodyTemperature(snapshot WorldSnapshot, sleeping bool) int {
+	t := r.AmbientTemperature(snapshot.TimeOfDay, snapshot.Season, snapshot.Weather, snapshot.Biome)
+	if snapshot.NearHeat {
+		t += r.Temperature.HeatSourceWarmth
+	}
+	if snapshot.Sheltered {
+		t += r.Temperature.ShelterWarmth
+		if sleeping {
+			t += r.Temperature.ShelterSleepWarmth
+		}
+	}
+	return t
+}
+
+func (r *RuleSet) IsCold(temperature int) bool {
+	return temperature < r.Temperature.ColdThreshold
+}
+
+func (r *RuleSet) IsFreezing(temperature int) bool {
+	return temperature < r.Temperature.FreezingThreshold
+}
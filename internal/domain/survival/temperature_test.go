@@ -0,0 +1,78 @@
+package survival
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBodyTemperature_HeatAndShelterOffsetWinterNight(t *testing.T) {
+	rules := DefaultRuleSet()
+	exposed := WorldSnapshot{TimeOfDay: "night", Season: "winter", Weather: "rain", Biome: "plain"}
+	if got := rules.BodyTemperature(exposed, false); !rules.IsFreezing(got) {
+		t.Fatalf("expected exposed winter rain night to freeze, got %d", got)
+	}
+
+	warm := exposed
+	warm.NearHeat = true
+	warm.Sheltered = true
+	if got := rules.BodyTemperature(warm, true); rules.IsCold(got) {
+		t.Fatalf("expected heat source and shelter to lift temperature out of cold, got %d", got)
+	}
+	if rules.BodyTemperature(warm, true) <= rules.BodyTemperature(warm, false) {
+		t.Fatalf("expected sleeping indoors to add warmth")
+	}
+	if got := rules.BodyTemperature(WorldSnapshot{TimeOfDay: "day", Season: "summer"}, false); rules.IsCold(got) {
+		t.Fatalf("expected summer day to be comfortable, got %d", got)
+	}
+}
+
+func TestSettlementService_ColdExposureDrainsEnergyAndHP(t *testing.T) {
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 100, Hunger: 80, Energy: 60, Thirst: 80},
+		Version: 1,
+	}
+	cold := WorldSnapshot{TimeOfDay: "night", Season: "winter", Weather: "clear", Biome: "plain"}
+	out, err := SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), cold)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.UpdatedState.Temperature != -DefaultRuleSet().Temperature.NightDrop {
+		t.Fatalf("expected temperature=%d, got %d", -DefaultRuleSet().Temperature.NightDrop, out.UpdatedState.Temperature)
+	}
+	reasons := out.Events[0].Payload["result"].(map[string]any)["vitals_change_reasons"].(map[string]any)
+	if !hasReason(reasons["energy"].([]map[string]any), "COLD_ENERGY_DRAIN") {
+		t.Fatalf("expected COLD_ENERGY_DRAIN, got %v", reasons["energy"])
+	}
+	if !hasReason(reasons["hp"].([]map[string]any), "COLD_HP_DRAIN") {
+		t.Fatalf("expected COLD_HP_DRAIN, got %v", reasons["hp"])
+	}
+
+	cold.NearHeat = true
+	out, err = SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), cold)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.UpdatedState.Vitals.HP != 100 {
+		t.Fatalf("expected heat source to prevent cold damage, got hp=%d", out.UpdatedState.Vitals.HP)
+	}
+
+	state.Vitals.HP = 2
+	cold.NearHeat = false
+	out, err = SettlementService{}.Settle(state, ActionIntent{Type: ActionRest}, HeartbeatDelta{Minutes: 30}, time.Now(), cold)
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if out.UpdatedState.DeathCause != DeathCauseHypothermia {
+		t.Fatalf("expected hypothermia death, got %s", out.UpdatedState.DeathCause)
+	}
+}
+
+func hasReason(reasons []map[string]any, code string) bool {
+	for _, r := range reasons {
+		if r["code"] == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package survival
+
+import (
+	"sort"
+	"time"
+)
+
+var toolMaxDurability = map[string]int{
+	"tool_axe":     ToolAxeDurability,
+	"tool_pickaxe": ToolPickaxeDurability,
+}
+
+var gatherTools = map[string]string{
+	"wood":  "tool_axe",
+	"stone": "tool_pickaxe",
+}
+
+func ToolDurabilityRules() map[string]int {
+	return cloneIntMap(toolMaxDurability)
+}
+
+func IsTool(item string) bool {
+	_, ok := toolMaxDurability[item]
+	return ok
+}
+
+// ToolStack is a batch of copies of one tool with the same remaining uses.
+// Stacks travel with the items, so wear survives boxes and trades.
+type ToolStack struct {
+	Count      int `json:"count"`
+	Durability int `json:"durability"`
+}
+
+// ToolDurability returns the remaining uses of the tool currently in hand,
+// which is always the most worn copy held.
+func ToolDurability(state AgentStateAggregate, tool string) int {
+	full, ok := toolMaxDurability[tool]
+	if !ok || !holdsItem(state, tool) {
+		return 0
+	}
+	if stacks := sortedToolStacks(state.ToolStacks[tool]); len(stacks) > 0 {
+		return stacks[0].Durability
+	}
+	// Tools saved before stacks existed only tracked the copy in hand.
+	if d, ok := state.ToolDurability[tool]; ok && d > 0 {
+		return d
+	}
+	return full
+}
+
+// SyncToolStacks lines container stacks up with its inventory: untracked
+// copies start at full durability and surplus copies leave most worn first.
+func SyncToolStacks(inventory map[string]int, stacks map[string][]ToolStack) map[string][]ToolStack {
+	return alignToolStacks(inventory, stacks, nil, nil)
+}
+
+// ToolStacksLeaving returns the agent's copies that go with items when they
+// leave the inventory: most worn first, but never the equipped copy in hand.
+func ToolStacksLeaving(state AgentStateAggregate, items []ItemAmount) map[string][]ToolStack {
+	held := alignToolStacks(heldToolCounts(state), state.ToolStacks, state.ToolDurability, nil)
+	return takeToolStacks(held, items, equippedToolCounts(state))
+}
+
+// ContainerToolStacks returns the container copies that go with items,
+// most worn first.
+func ContainerToolStacks(stacks map[string][]ToolStack, items []ItemAmount) map[string][]ToolStack {
+	return takeToolStacks(stacks, items, nil)
+}
+
+func AddToolStacks(stacks map[string][]ToolStack, add map[string][]ToolStack) map[string][]ToolStack {
+	if len(add) == 0 {
+		return stacks
+	}
+	if stacks == nil {
+		stacks = map[string][]ToolStack{}
+	}
+	for tool, batch := range add {
+		stacks[tool] = sortedToolStacks(append(append([]ToolStack(nil), stacks[tool]...), batch...))
+	}
+	return stacks
+}
+
+// syncToolDurability aligns the agent's stacks with the tools it holds and
+// refreshes the in-hand view in ToolDurability.
+func syncToolDurability(state *AgentStateAggregate) {
+	state.ToolStacks = alignToolStacks(heldToolCounts(*state), state.ToolStacks, state.ToolDurability, equippedToolCounts(*state))
+	for tool := range toolMaxDurability {
+		if !holdsItem(*state, tool) {
+			delete(state.ToolDurability, tool)
+			continue
+		}
+		if state.ToolDurability == nil {
+			state.ToolDurability = map[string]int{}
+		}
+		state.ToolDurability[tool] = ToolDurability(*state, tool)
+	}
+}
+
+// wearTool spends one use of the tool in hand. When it breaks it is removed
+// from the inventory and the next most worn copy, if any, takes its place.
+func wearTool(state *AgentStateAggregate, tool string) bool {
+	syncToolDurability(state)
+	stacks := sortedToolStacks(state.ToolStacks[tool])
+	if len(stacks) == 0 {
+		return false
+	}
+	inHand := stacks[0].Durability - ToolWearPerGather
+	split := takeTools(stacks, 1, 0)
+	rest := split.rest
+	if inHand > 0 {
+		rest = sortedToolStacks(append(rest, ToolStack{Count: 1, Durability: inHand}))
+	}
+	state.ToolStacks[tool] = rest
+	if inHand > 0 {
+		syncToolDurability(state)
+		return false
+	}
+	// The equipped copy is the one in hand.
+	if state.Equipment[string(SlotTool)] == tool {
+		delete(state.Equipment, string(SlotTool))
+	} else {
+		state.ConsumeItem(tool, 1)
+	}
+	syncToolDurability(state)
+	return true
+}
+
+// alignToolStacks matches stacks to held counts. legacy seeds the copy in
+// hand for tools saved before stacks existed; reserved copies (equipped
+// ones) are the most worn and never dropped as surplus.
+func alignToolStacks(held map[string]int, stacks map[string][]ToolStack, legacy map[string]int, reserved map[string]int) map[string][]ToolStack {
+	out := map[string][]ToolStack{}
+	for tool, full := range toolMaxDurability {
+		count := held[tool]
+		if count <= 0 {
+			continue
+		}
+		current := sortedToolStacks(stacks[tool])
+		if len(current) == 0 {
+			if d := legacy[tool]; d > 0 && d < full {
+				current = []ToolStack{{Count: 1, Durability: d}}
+			}
+		}
+		total := 0
+		for _, stack := range current {
+			total += stack.Count
+		}
+		switch {
+		case total < count:
+			current = sortedToolStacks(append(current, ToolStack{Count: count - total, Durability: full}))
+		case total > count:
+			split := takeTools(current, total-count, reserved[tool])
+			current = split.rest
+		}
+		out[tool] = current
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func takeToolStacks(stacks map[string][]ToolStack, items []ItemAmount, reserved map[string]int) map[string][]ToolStack {
+	out := map[string][]ToolStack{}
+	for _, item := range items {
+		current, ok := stacks[item.ItemType]
+		if !ok || item.Count <= 0 {
+			continue
+		}
+		split := takeTools(sortedToolStacks(current), item.Count, reserved[item.ItemType])
+		out[item.ItemType] = append(out[item.ItemType], split.taken...)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func heldToolCounts(state AgentStateAggregate) map[string]int {
+	out := equippedToolCounts(state)
+	for tool := range toolMaxDurability {
+		out[tool] += state.Inventory[tool]
+	}
+	return out
+}
+
+func equippedToolCounts(state AgentStateAggregate) map[string]int {
+	out := map[string]int{}
+	for _, item := range state.Equipment {
+		if _, ok := toolMaxDurability[item]; ok {
+			out[item]++
+		}
+	}
+	return out
+}
+
+type toolSplit struct {
+	taken []ToolStack
+	rest  []ToolStack
+}
+
+// takeTools takes count copies most worn first after skipping the first
+// reserve copies.
+func takeTools(sorted []ToolStack, count, reserve int) toolSplit {
+	out := toolSplit{}
+	for _, stack := range sorted {
+		keep := min(reserve, stack.Count)
+		reserve -= keep
+		take := min(count, stack.Count-keep)
+		count -= take
+		if take > 0 {
+			out.taken = append(out.taken, ToolStack{Count: take, Durability: stack.Durability})
+		}
+		if left := stack.Count - take; left > 0 {
+			out.rest = append(out.rest, ToolStack{Count: left, Durability: stack.Durability})
+		}
+	}
+	return out
+}
+
+func sortedToolStacks(in []ToolStack) []ToolStack {
+	out := make([]ToolStack, 0, len(in))
+	for _, stack := range in {
+		if stack.Count > 0 {
+			out = append(out, stack)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Durability < out[j].Durability })
+	return out
+}
+
+func cloneToolStacks(in map[string][]ToolStack) map[string][]ToolStack {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string][]ToolStack, len(in))
+	for tool, batch := range in {
+		out[tool] = append([]ToolStack(nil), batch...)
+	}
+	return out
+}
+
+func toolBrokenEvents(state AgentStateAggregate, broken []string, now time.Time) []DomainEvent {
+	out := make([]DomainEvent, 0, len(broken))
+	for _, tool := range broken {
+		out = append(out, DomainEvent{
+			Type:       "tool_broken",
+			OccurredAt: now,
+			Payload: map[string]any{
+				"tool":      tool,
+				"remaining": state.Inventory[tool],
+			},
+		})
+	}
+	return out
+}
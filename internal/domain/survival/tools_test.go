@@ -0,0 +1,80 @@
+package survival
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCraftAxeStartsAtFullDurabilityAndGatherWearsIt(t *testing.T) {
+	rules := DefaultRuleSet()
+	state := AgentStateAggregate{Inventory: map[string]int{"wood": 3, "stone": 2}}
+	if !rules.Craft(&state, RecipeAxe) {
+		t.Fatalf("expected axe craft to succeed")
+	}
+	if got := state.ToolDurability["tool_axe"]; got != ToolAxeDurability {
+		t.Fatalf("expected fresh axe durability=%d, got %d", ToolAxeDurability, got)
+	}
+
+	broken := ApplyGather(&state, WorldSnapshot{NearbyResource: map[string]int{"wood": 1, "berry": 1}})
+	if len(broken) != 0 {
+		t.Fatalf("expected no broken tools, got %v", broken)
+	}
+	if got := state.Inventory["wood"]; got != 2 {
+		t.Fatalf("expected doubled wood yield, got %d", got)
+	}
+	if got := state.ToolDurability["tool_axe"]; got != ToolAxeDurability-ToolWearPerGather {
+		t.Fatalf("expected axe to wear by %d, got %d", ToolWearPerGather, got)
+	}
+}
+
+func TestSettlementService_GatherBreaksWornToolAndEmitsEvent(t *testing.T) {
+	state := AgentStateAggregate{
+		AgentID:        "a-1",
+		Vitals:         Vitals{HP: 100, Hunger: 80, Energy: 60},
+		Inventory:      map[string]int{"tool_pickaxe": 1},
+		ToolDurability: map[string]int{"tool_pickaxe": 1},
+		Version:        1,
+	}
+	out, err := SettlementService{}.Settle(state, ActionIntent{Type: ActionGather}, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{
+		NearbyResource: map[string]int{"stone": 1},
+	})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	if got := out.UpdatedState.Inventory["stone"]; got != 2 {
+		t.Fatalf("expected last use to still double yield, got %d", got)
+	}
+	if got := out.UpdatedState.Inventory["tool_pickaxe"]; got != 0 {
+		t.Fatalf("expected broken pickaxe removed, got %d", got)
+	}
+	if _, ok := out.UpdatedState.ToolDurability["tool_pickaxe"]; ok {
+		t.Fatalf("expected durability entry cleared, got %v", out.UpdatedState.ToolDurability)
+	}
+	found := false
+	for _, evt := range out.Events {
+		if evt.Type == "tool_broken" && evt.Payload["tool"] == "tool_pickaxe" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected tool_broken event, got %+v", out.Events)
+	}
+}
+
+func TestToolStacksLeaving_KeepsEquippedCopyInHand(t *testing.T) {
+	state := AgentStateAggregate{
+		Inventory:  map[string]int{"tool_axe": 1},
+		Equipment:  map[string]string{string(SlotTool): "tool_axe"},
+		ToolStacks: map[string][]ToolStack{"tool_axe": {{Count: 1, Durability: 4}, {Count: 1, Durability: 18}}},
+	}
+	leaving := ToolStacksLeaving(state, []ItemAmount{{ItemType: "tool_axe", Count: 1}})
+	if got := leaving["tool_axe"]; len(got) != 1 || got[0].Durability != 18 {
+		t.Fatalf("expected the spare axe to leave, got %+v", leaving)
+	}
+
+	state.ConsumeItem("tool_axe", 1)
+	syncToolDurability(&state)
+	if got := ToolDurability(state, "tool_axe"); got != 4 {
+		t.Fatalf("expected the equipped axe to keep its wear, got %d", got)
+	}
+}
@@ -0,0 +1,138 @@
+package survival
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrTradeItemsMissing = errors.New("trade items missing")
+
+const (
+	TradeStatusOpen      = "open"
+	TradeStatusAccepted  = "accepted"
+	TradeStatusCancelled = "cancelled"
+	TradeStatusExpired   = "expired"
+)
+
+// TradeGoods are the items one side of a trade hands over, with the age of
+// the perishables and the wear of the tools among them.
+type TradeGoods struct {
+	Items  []ItemAmount
+	Stacks map[string][]FoodStack
+	Tools  map[string][]ToolStack
+}
+
+// GoodsLeaving returns items together with the stacks that go with them when
+// they leave the agent's inventory.
+func GoodsLeaving(state AgentStateAggregate, items []ItemAmount, now time.Time) TradeGoods {
+	held := SyncFoodStacks(state.Inventory, state.Freshness, now)
+	return TradeGoods{
+		Items:  items,
+		Stacks: OldestFoodStacks(held, items),
+		Tools:  ToolStacksLeaving(state, items),
+	}
+}
+
+func ItemAmountsToMap(items []ItemAmount) map[string]int {
+	out := map[string]int{}
+	for _, item := range items {
+		if item.ItemType == "" || item.Count <= 0 {
+			continue
+		}
+		out[item.ItemType] += item.Count
+	}
+	return out
+}
+
+func HasItems(state AgentStateAggregate, items []ItemAmount) bool {
+	for itemType, count := range ItemAmountsToMap(items) {
+		if state.Inventory[itemType] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// EscrowItems moves offered items out of the inventory so they cannot be
+// spent while the offer is open. Nothing moves unless every item is held.
+func EscrowItems(state *AgentStateAggregate, items []ItemAmount) bool {
+	if !HasItems(*state, items) {
+		return false
+	}
+	for itemType, count := range ItemAmountsToMap(items) {
+		_ = state.ConsumeItem(itemType, count)
+		if state.Escrow == nil {
+			state.Escrow = map[string]int{}
+		}
+		state.Escrow[itemType] += count
+	}
+	return true
+}
+
+// ReleaseEscrow returns the escrowed goods of a closed offer to the
+// inventory.
+func ReleaseEscrow(state *AgentStateAggregate, goods TradeGoods, now time.Time) {
+	released := make([]ItemAmount, 0, len(goods.Items))
+	for itemType, count := range ItemAmountsToMap(goods.Items) {
+		released = append(released, ItemAmount{ItemType: itemType, Count: takeEscrow(state, itemType, count)})
+	}
+	goods.Items = released
+	receiveGoods(state, goods, now)
+}
+
+// AcceptTrade pays the requested items from the acceptor's inventory and
+// hands over the offered goods.
+func AcceptTrade(state *AgentStateAggregate, offered TradeGoods, requested []ItemAmount, now time.Time) bool {
+	if !HasItems(*state, requested) {
+		return false
+	}
+	for itemType, count := range ItemAmountsToMap(requested) {
+		_ = state.ConsumeItem(itemType, count)
+	}
+	receiveGoods(state, offered, now)
+	return true
+}
+
+// CompleteTradeOffer settles the offerer's side of an accepted trade: the
+// escrowed items leave and the acceptor's payment arrives.
+func CompleteTradeOffer(state *AgentStateAggregate, offered []ItemAmount, payment TradeGoods, now time.Time) {
+	for itemType, count := range ItemAmountsToMap(offered) {
+		takeEscrow(state, itemType, count)
+	}
+	receiveGoods(state, payment, now)
+}
+
+// receiveGoods adds goods to the inventory. Stacks are lined up first so the
+// incoming items are not stamped as new, and again after so stacks beyond
+// what arrived are dropped.
+func receiveGoods(state *AgentStateAggregate, goods TradeGoods, now time.Time) {
+	SyncFreshness(state, now)
+	syncToolDurability(state)
+	for itemType, count := range ItemAmountsToMap(goods.Items) {
+		state.AddItem(itemType, count)
+	}
+	state.Freshness = AddFoodStacks(state.Freshness, goods.Stacks)
+	state.ToolStacks = AddToolStacks(state.ToolStacks, goods.Tools)
+	SyncFreshness(state, now)
+	syncToolDurability(state)
+}
+
+func takeEscrow(state *AgentStateAggregate, itemType string, count int) int {
+	held := state.Escrow[itemType]
+	if count > held {
+		count = held
+	}
+	if count <= 0 {
+		return 0
+	}
+	if held == count {
+		delete(state.Escrow, itemType)
+	} else {
+		state.Escrow[itemType] = held - count
+	}
+	return count
+}
+
+func intentGoods(intent ActionIntent) TradeGoods {
+	return TradeGoods{Items: intent.Items, Stacks: intent.Stacks, Tools: intent.ToolStacks}
+}
@@ -0,0 +1,95 @@
+package survival
+
+import "time"
+
+// SettleIntent is the intent an ongoing action settles with after
+// elapsedMinutes.
+func (o OngoingActionInfo) SettleIntent(elapsedMinutes int) ActionIntent {
+	intent := ActionIntent{Type: o.Type}
+	switch o.Type {
+	case ActionSleep:
+		intent.BedID = o.BedID
+		intent.BedQuality = o.Quality
+	case ActionTravel:
+		if steps := travelSteps(o, elapsedMinutes); steps > 0 {
+			reached := o.Path[steps-1]
+			intent.Pos = &reached
+		}
+	}
+	return intent
+}
+
+// WithTravelProgress fills StepsDone and CurrentPos for a travel as of now.
+func (o OngoingActionInfo) WithTravelProgress(origin Position, now time.Time) OngoingActionInfo {
+	if o.Type != ActionTravel {
+		return o
+	}
+	startAt := o.EndAt.Add(-time.Duration(o.Minutes) * time.Minute)
+	o.StepsDone = travelSteps(o, int(now.Sub(startAt).Minutes()))
+	current := origin
+	if o.StepsDone > 0 {
+		current = o.Path[o.StepsDone-1]
+	}
+	o.CurrentPos = &current
+	return o
+}
+
+// TravelStepsAhead lists the steps from pos that the travel should have
+// taken by now but has not.
+func (o OngoingActionInfo) TravelStepsAhead(pos Position, now time.Time) []Position {
+	if o.Type != ActionTravel {
+		return nil
+	}
+	startAt := o.EndAt.Add(-time.Duration(o.Minutes) * time.Minute)
+	walked := stepsWalked(o, pos)
+	due := travelSteps(o, int(now.Sub(startAt).Minutes()))
+	if due <= walked {
+		return nil
+	}
+	return o.Path[walked:due]
+}
+
+// AdvanceTravel moves a travelling agent one step at a time to where it
+// should be by now. A step onto a blocked tile cuts the travel short where
+// the agent stands, so it ends on the next settle. It reports whether the
+// state changed.
+func AdvanceTravel(state *AgentStateAggregate, now time.Time, blocked func(Position) bool) bool {
+	ongoing := state.OngoingAction
+	if ongoing == nil {
+		return false
+	}
+	changed := false
+	for _, step := range ongoing.TravelStepsAhead(state.Position, now) {
+		if blocked(step) {
+			stopped := *ongoing
+			startAt := ongoing.EndAt.Add(-time.Duration(ongoing.Minutes) * time.Minute)
+			stopped.Minutes = stepsWalked(*ongoing, state.Position) * TravelStepMinutes
+			stopped.EndAt = startAt.Add(time.Duration(stopped.Minutes) * time.Minute)
+			state.OngoingAction = &stopped
+			return true
+		}
+		state.Position = step
+		changed = true
+	}
+	return changed
+}
+
+// stepsWalked is how many steps of the path lead up to pos; paths never
+// revisit a tile, so pos appears at most once.
+func stepsWalked(o OngoingActionInfo, pos Position) int {
+	for i, step := range o.Path {
+		if step == pos {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// travelSteps caps progress at Minutes, which is shorter than the path when
+// the travel was cut short.
+func travelSteps(o OngoingActionInfo, elapsedMinutes int) int {
+	if elapsedMinutes <= 0 {
+		return 0
+	}
+	return min(min(elapsedMinutes, o.Minutes)/TravelStepMinutes, len(o.Path))
+}
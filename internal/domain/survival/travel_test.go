@@ -0,0 +1,67 @@
+package survival
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOngoingTravel_ProgressFollowsElapsedTime(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	travel := OngoingActionInfo{
+		Type:    ActionTravel,
+		Minutes: 3 * TravelStepMinutes,
+		EndAt:   start.Add(time.Duration(3*TravelStepMinutes) * time.Minute),
+		Path:    []Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}},
+	}
+	if intent := travel.SettleIntent(0); intent.Pos != nil {
+		t.Fatalf("expected no movement before the first step, got=%+v", intent.Pos)
+	}
+	if intent := travel.SettleIntent(2 * TravelStepMinutes); intent.Pos == nil || *intent.Pos != (Position{X: 2, Y: 0}) {
+		t.Fatalf("expected second step reached, got=%+v", intent.Pos)
+	}
+	progress := travel.WithTravelProgress(Position{}, start.Add(time.Hour))
+	if progress.StepsDone != 3 || progress.CurrentPos == nil || *progress.CurrentPos != (Position{X: 2, Y: 1}) {
+		t.Fatalf("expected travel capped at destination, got steps=%d pos=%+v", progress.StepsDone, progress.CurrentPos)
+	}
+}
+
+func TestMoveToward_SidestepsBlockedTiles(t *testing.T) {
+	from, to := Position{X: 0, Y: 0}, Position{X: 5, Y: 5}
+	if got := moveToward(from, to, nil); got != (Position{X: 1, Y: 1}) {
+		t.Fatalf("expected diagonal step, got=%+v", got)
+	}
+	blocked := map[Position]bool{{X: 1, Y: 1}: true}
+	if got := moveToward(from, to, blocked); got != (Position{X: 1, Y: 0}) {
+		t.Fatalf("expected sidestep along x, got=%+v", got)
+	}
+	blocked[Position{X: 1, Y: 0}] = true
+	blocked[Position{X: 0, Y: 1}] = true
+	if got := moveToward(from, to, blocked); got != from {
+		t.Fatalf("expected to hold position when boxed in, got=%+v", got)
+	}
+}
+
+func TestAdvanceTravel_MovesPerStepAndStopsAtBlockedTile(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	path := []Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}}
+	state := AgentStateAggregate{OngoingAction: &OngoingActionInfo{
+		Type:    ActionTravel,
+		Minutes: len(path) * TravelStepMinutes,
+		EndAt:   start.Add(time.Duration(len(path)*TravelStepMinutes) * time.Minute),
+		Path:    path,
+	}}
+	open := func(Position) bool { return false }
+	if !AdvanceTravel(&state, start.Add(time.Duration(TravelStepMinutes)*time.Minute), open) || state.Position != path[0] {
+		t.Fatalf("expected first step taken, got=%+v", state.Position)
+	}
+	wall := func(p Position) bool { return p == path[2] }
+	if !AdvanceTravel(&state, start.Add(time.Hour), wall) || state.Position != path[1] {
+		t.Fatalf("expected agent held before the wall, got=%+v", state.Position)
+	}
+	if got := state.OngoingAction; got.Minutes != 2*TravelStepMinutes || !got.EndAt.Equal(start.Add(time.Duration(2*TravelStepMinutes)*time.Minute)) {
+		t.Fatalf("expected travel cut short after two steps, got=%+v", got)
+	}
+	if intent := state.OngoingAction.SettleIntent(state.OngoingAction.Minutes); intent.Pos == nil || *intent.Pos != path[1] {
+		t.Fatalf("expected travel to settle where it stopped, got=%+v", intent.Pos)
+	}
+}
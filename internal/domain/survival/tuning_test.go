@@ -9,20 +9,21 @@ func TestGameplayTuning_Defaults(t *testing.T) {
 	if StandardTickMinutes != 30 {
 		t.Fatalf("StandardTickMinutes = %d, want 30", StandardTickMinutes)
 	}
-	if BaseHungerDrainPer30 != 0 {
-		t.Fatalf("BaseHungerDrainPer30 = %d, want 0", BaseHungerDrainPer30)
+	rules := DefaultRuleSet()
+	if rules.Drains.BaseHungerPer30 != 0 {
+		t.Fatalf("base hunger drain = %d, want 0", rules.Drains.BaseHungerPer30)
 	}
-	if HPDrainCapPer30 != 8 {
-		t.Fatalf("HPDrainCapPer30 = %d, want 8", HPDrainCapPer30)
+	if rules.Drains.HPCapPer30 != 8 {
+		t.Fatalf("hp drain cap = %d, want 8", rules.Drains.HPCapPer30)
 	}
-	if HPDrainFromHungerCoeff != 0.04 || HPDrainFromEnergyCoeff != 0.03 {
-		t.Fatalf("hp drain coeffs = (%v,%v), want (0.04,0.03)", HPDrainFromHungerCoeff, HPDrainFromEnergyCoeff)
+	if rules.Drains.HPFromHungerCoeff != 0.04 || rules.Drains.HPFromEnergyCoeff != 0.03 {
+		t.Fatalf("hp drain coeffs = (%v,%v), want (0.04,0.03)", rules.Drains.HPFromHungerCoeff, rules.Drains.HPFromEnergyCoeff)
 	}
 	if MinRestMinutes != 1 || MaxRestMinutes != 120 {
 		t.Fatalf("rest bounds = (%d,%d), want (1,120)", MinRestMinutes, MaxRestMinutes)
 	}
-	if DefaultFarmGrowMinutes != 60 {
-		t.Fatalf("DefaultFarmGrowMinutes = %d, want 60", DefaultFarmGrowMinutes)
+	if got := rules.CropRuleFor(CropWheat).GrowMinutes; got != 60 {
+		t.Fatalf("wheat grow minutes = %d, want 60", got)
 	}
 	if SeedPityMaxFails != 8 {
 		t.Fatalf("SeedPityMaxFails = %d, want 8", SeedPityMaxFails)
@@ -33,8 +34,8 @@ func TestGameplayTuning_Defaults(t *testing.T) {
 	if DefaultInventoryCapacity != 30 {
 		t.Fatalf("DefaultInventoryCapacity = %d, want 30", DefaultInventoryCapacity)
 	}
-	if SleepBaseEnergyRecovery != 35 || SleepBaseHPRecovery != 6 {
-		t.Fatalf("sleep base recovery = (%d,%d), want (35,6)", SleepBaseEnergyRecovery, SleepBaseHPRecovery)
+	if rules.Delta(ActionSleep).Energy != 35 || rules.Sleep.HPRecovery != 6 {
+		t.Fatalf("sleep base recovery = (%d,%d), want (35,6)", rules.Delta(ActionSleep).Energy, rules.Sleep.HPRecovery)
 	}
 	if CriticalHPThreshold != 15 || LowEnergyThreshold != 20 {
 		t.Fatalf("status thresholds = (%d,%d), want (15,20)", CriticalHPThreshold, LowEnergyThreshold)
@@ -42,31 +43,29 @@ func TestGameplayTuning_Defaults(t *testing.T) {
 	if DefaultRespawnMinutes != 60 {
 		t.Fatalf("DefaultRespawnMinutes = %d, want 60", DefaultRespawnMinutes)
 	}
-	if WheatYieldMin != 1 || WheatYieldMax != 3 {
-		t.Fatalf("wheat yield range = (%d,%d), want (1,3)", WheatYieldMin, WheatYieldMax)
-	}
 	if SeedDropChance != 0.2 || SeedReturnChance != 0.2 {
 		t.Fatalf("seed chances = (%v,%v), want (0.2,0.2)", SeedDropChance, SeedReturnChance)
 	}
 	if VisionRadiusDay != 6 || VisionRadiusNight != 3 || TorchLightRadius != 3 {
 		t.Fatalf("visibility = (%d,%d,%d), want (6,3,3)", VisionRadiusDay, VisionRadiusNight, TorchLightRadius)
 	}
-	if FoodBerryHungerRecovery != 20 || FoodBreadHungerRecovery != 30 || FoodWheatHungerRecovery != 15 || FoodJamHungerRecovery != 80 {
+	foods := rules.FoodRecoveryRules()
+	if foods["berry"] != 20 || foods["bread"] != 30 || foods["wheat"] != 15 || foods["jam"] != 80 {
 		t.Fatalf(
 			"food recovery = (berry:%d,bread:%d,wheat:%d,jam:%d), want (20,30,15,80)",
-			FoodBerryHungerRecovery,
-			FoodBreadHungerRecovery,
-			FoodWheatHungerRecovery,
-			FoodJamHungerRecovery,
+			foods["berry"],
+			foods["bread"],
+			foods["wheat"],
+			foods["jam"],
 		)
 	}
 }
 
 func TestGameplayTuning_CooldownsAndRespawn(t *testing.T) {
-	if got := ActionCooldownDurations[ActionMove]; got != 1*time.Minute {
+	if got, _ := DefaultRuleSet().Cooldown(ActionMove); got != 1*time.Minute {
 		t.Fatalf("move cooldown = %s, want 1m", got)
 	}
-	if got := ActionCooldownDurations[ActionBuild]; got != 5*time.Minute {
+	if got, _ := DefaultRuleSet().Cooldown(ActionBuild); got != 5*time.Minute {
 		t.Fatalf("build cooldown = %s, want 5m", got)
 	}
 	if got := ResourceRespawnDurations["wood"]; got != 60*time.Minute {
@@ -6,6 +6,7 @@ type Vitals struct {
 	HP     int `json:"hp"`
 	Hunger int `json:"hunger"`
 	Energy int `json:"energy"`
+	Thirst int `json:"thirst"`
 }
 
 type Position struct {
@@ -14,22 +15,28 @@ type Position struct {
 }
 
 type AgentStateAggregate struct {
-	AgentID           string             `json:"agent_id"`
-	SessionID         string             `json:"session_id,omitempty"`
-	Vitals            Vitals             `json:"vitals"`
-	Position          Position           `json:"position"`
-	CurrentZone       string             `json:"current_zone,omitempty"`
-	Home              Position           `json:"home"`
-	Inventory         map[string]int     `json:"inventory"`
-	InventoryCapacity int                `json:"inventory_capacity"`
-	InventoryUsed     int                `json:"inventory_used"`
-	ActionCooldowns   map[string]int     `json:"action_cooldowns,omitempty"`
-	StatusEffects     []string           `json:"status_effects"`
-	Dead              bool               `json:"dead"`
-	DeathCause        DeathCause         `json:"death_cause"`
-	OngoingAction     *OngoingActionInfo `json:"ongoing_action,omitempty"`
-	Version           int64              `json:"version"`
-	UpdatedAt         time.Time          `json:"updated_at"`
+	AgentID           string                 `json:"agent_id"`
+	SessionID         string                 `json:"session_id,omitempty"`
+	Vitals            Vitals                 `json:"vitals"`
+	Position          Position               `json:"position"`
+	CurrentZone       string                 `json:"current_zone,omitempty"`
+	Temperature       int                    `json:"temperature"`
+	Home              Position               `json:"home"`
+	Inventory         map[string]int         `json:"inventory"`
+	InventoryCapacity int                    `json:"inventory_capacity"`
+	InventoryUsed     int                    `json:"inventory_used"`
+	ToolDurability    map[string]int         `json:"tool_durability,omitempty"`
+	ToolStacks        map[string][]ToolStack `json:"tool_stacks,omitempty"`
+	Escrow            map[string]int         `json:"escrow,omitempty"`
+	Freshness         map[string][]FoodStack `json:"freshness,omitempty"`
+	Equipment         map[string]string      `json:"equipment,omitempty"`
+	ActionCooldowns   map[string]int         `json:"action_cooldowns,omitempty"`
+	StatusEffects     []string               `json:"status_effects"`
+	Dead              bool                   `json:"dead"`
+	DeathCause        DeathCause             `json:"death_cause"`
+	OngoingAction     *OngoingActionInfo     `json:"ongoing_action,omitempty"`
+	Version           int64                  `json:"version"`
+	UpdatedAt         time.Time              `json:"updated_at"`
 }
 
 type OngoingActionInfo struct {
@@ -38,6 +45,11 @@ type OngoingActionInfo struct {
 	EndAt   time.Time  `json:"end_at"`
 	BedID   string     `json:"bed_id,omitempty"`
 	Quality string     `json:"quality,omitempty"`
+	// Path is the planned travel route, excluding the starting tile.
+	Path []Position `json:"path,omitempty"`
+	// StepsDone and CurrentPos report travel progress when read.
+	StepsDone  int       `json:"steps_done,omitempty"`
+	CurrentPos *Position `json:"current_pos,omitempty"`
 }
 
 type ActionType string
@@ -47,35 +59,65 @@ const (
 	ActionRest              ActionType = "rest"
 	ActionSleep             ActionType = "sleep"
 	ActionMove              ActionType = "move"
+	ActionTravel            ActionType = "travel"
 	ActionBuild             ActionType = "build"
 	ActionFarm              ActionType = "farm"
 	ActionFarmPlant         ActionType = "farm_plant"
 	ActionFarmHarvest       ActionType = "farm_harvest"
+	ActionFarmWater         ActionType = "farm_water"
+	ActionRepair            ActionType = "repair"
+	ActionDeconstruct       ActionType = "deconstruct"
+	ActionFurnaceFuel       ActionType = "furnace_fuel"
+	ActionFurnaceLoad       ActionType = "furnace_load"
+	ActionFurnaceCollect    ActionType = "furnace_collect"
 	ActionContainerDeposit  ActionType = "container_deposit"
 	ActionContainerWithdraw ActionType = "container_withdraw"
 	ActionRetreat           ActionType = "retreat"
 	ActionCraft             ActionType = "craft"
 	ActionEat               ActionType = "eat"
+	ActionAttack            ActionType = "attack"
+	ActionDrink             ActionType = "drink"
+	ActionTradeOffer        ActionType = "trade_offer"
+	ActionTradeAccept       ActionType = "trade_accept"
+	ActionTradeCancel       ActionType = "trade_cancel"
 	ActionTerminate         ActionType = "terminate"
+	ActionRespawn           ActionType = "respawn"
+	ActionEquip             ActionType = "equip"
+	ActionUnequip           ActionType = "unequip"
 )
 
 type ActionIntent struct {
-	Type        ActionType   `json:"type"`
-	Direction   string       `json:"direction,omitempty"`
-	TargetID    string       `json:"target_id,omitempty"`
-	RecipeID    int          `json:"recipe_id,omitempty"`
-	Count       int          `json:"count,omitempty"`
-	ObjectType  string       `json:"object_type,omitempty"`
-	Pos         *Position    `json:"pos,omitempty"`
-	ItemType    string       `json:"item_type,omitempty"`
-	RestMinutes int          `json:"rest_minutes,omitempty"`
-	BedID       string       `json:"bed_id,omitempty"`
-	BedQuality  string       `json:"-"`
-	FarmID      string       `json:"farm_id,omitempty"`
-	ContainerID string       `json:"container_id,omitempty"`
-	Items       []ItemAmount `json:"items,omitempty"`
-	DX          int          `json:"-"`
-	DY          int          `json:"-"`
+	Type        ActionType `json:"type"`
+	Direction   string     `json:"direction,omitempty"`
+	TargetID    string     `json:"target_id,omitempty"`
+	RecipeID    int        `json:"recipe_id,omitempty"`
+	Count       int        `json:"count,omitempty"`
+	ObjectType  string     `json:"object_type,omitempty"`
+	Pos         *Position  `json:"pos,omitempty"`
+	ItemType    string     `json:"item_type,omitempty"`
+	RestMinutes int        `json:"rest_minutes,omitempty"`
+	BedID       string     `json:"bed_id,omitempty"`
+	BedQuality  string     `json:"-"`
+	// ReturnSeed is resolved by the server when a deconstructed farm plot
+	// still has a crop growing on it.
+	ReturnSeed bool `json:"-"`
+	// Stacks carry the age of perishables withdrawn from a container or
+	// received in a trade.
+	Stacks map[string][]FoodStack `json:"-"`
+	// ToolStacks carry the wear of tools withdrawn from a container or
+	// received in a trade.
+	ToolStacks  map[string][]ToolStack `json:"-"`
+	FarmID      string                 `json:"farm_id,omitempty"`
+	ContainerID string                 `json:"container_id,omitempty"`
+	ObjectID    string                 `json:"object_id,omitempty"`
+	Slot        string                 `json:"slot,omitempty"`
+	Items       []ItemAmount           `json:"items,omitempty"`
+	ToAgentID   string                 `json:"to_agent_id,omitempty"`
+	OfferID     string                 `json:"offer_id,omitempty"`
+	// RequestItems are what a trade offer asks for in return for Items.
+	RequestItems []ItemAmount `json:"request_items,omitempty"`
+	DX           int          `json:"-"`
+	DY           int          `json:"-"`
 }
 
 type HeartbeatDelta struct {
@@ -102,11 +144,28 @@ type DomainEvent struct {
 }
 
 type WorldSnapshot struct {
-	TimeOfDay         string         `json:"time_of_day"`
-	ThreatLevel       int            `json:"threat_level"`
-	VisibilityPenalty int            `json:"visibility_penalty"`
-	NearbyResource    map[string]int `json:"nearby_resource"`
-	WorldTimeSeconds  int64          `json:"world_time_seconds"`
+	TimeOfDay         string          `json:"time_of_day"`
+	ThreatLevel       int             `json:"threat_level"`
+	VisibilityPenalty int             `json:"visibility_penalty"`
+	NearbyResource    map[string]int  `json:"nearby_resource"`
+	WorldTimeSeconds  int64           `json:"world_time_seconds"`
+	Threats           []ThreatContact `json:"threats,omitempty"`
+	Sheltered         bool            `json:"sheltered,omitempty"`
+	Season            string          `json:"season,omitempty"`
+	Weather           string          `json:"weather,omitempty"`
+	Biome             string          `json:"biome,omitempty"`
+	NearHeat          bool            `json:"near_heat,omitempty"`
+	// Blocked marks known impassable tiles a forced retreat must avoid.
+	Blocked map[Position]bool `json:"-"`
+}
+
+type ThreatContact struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	HP     int    `json:"hp"`
+	Damage int    `json:"damage"`
 }
 
 type SettlementResult struct {
@@ -118,8 +177,10 @@ type SettlementResult struct {
 type DeathCause string
 
 const (
-	DeathCauseUnknown    DeathCause = "unknown"
-	DeathCauseStarvation DeathCause = "starvation"
-	DeathCauseExhaustion DeathCause = "exhaustion"
-	DeathCauseThreat     DeathCause = "threat"
+	DeathCauseUnknown     DeathCause = "unknown"
+	DeathCauseStarvation  DeathCause = "starvation"
+	DeathCauseExhaustion  DeathCause = "exhaustion"
+	DeathCauseThreat      DeathCause = "threat"
+	DeathCauseDehydration DeathCause = "dehydration"
+	DeathCauseHypothermia DeathCause = "hypothermia"
 )
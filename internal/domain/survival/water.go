@@ -0,0 +1,32 @@
+package survival
+
+const (
+	ItemFlask      = "flask"
+	ItemWaterFlask = "water_flask"
+)
+
+// DrinkFromSource drinks from an adjacent water tile and refills every empty flask.
+func (r *RuleSet) DrinkFromSource(state *AgentStateAggregate) {
+	addThirst(state, r.Delta(ActionDrink).Thirst)
+	if empty := state.Inventory[ItemFlask]; empty > 0 {
+		state.ConsumeItem(ItemFlask, empty)
+		state.AddItem(ItemWaterFlask, empty)
+	}
+}
+
+// DrinkFromFlask empties one carried water flask, keeping the container.
+func DrinkFromFlask(state *AgentStateAggregate) bool {
+	if !state.ConsumeItem(ItemWaterFlask, 1) {
+		return false
+	}
+	state.AddItem(ItemFlask, 1)
+	addThirst(state, WaterFlaskThirstRecovery)
+	return true
+}
+
+func addThirst(state *AgentStateAggregate, amount int) {
+	state.Vitals.Thirst += amount
+	if state.Vitals.Thirst > 100 {
+		state.Vitals.Thirst = 100
+	}
+}
@@ -0,0 +1,61 @@
+package survival
+
+type WeatherEffect struct {
+	VisionPenalty      int `json:"vision_penalty"`
+	EnergyDrainPer30   int `json:"energy_drain_per_30m"`
+	GatherYieldPercent int `json:"gather_yield_percent"`
+	FarmGrowPercent    int `json:"farm_grow_percent"`
+	// HP lost per hour by every built object exposed to the weather.
+	ObjectDamagePerHour int `json:"object_damage_per_hour"`
+}
+
+var weatherEffects = map[string]WeatherEffect{
+	"clear": {GatherYieldPercent: 100, FarmGrowPercent: 100},
+	"rain":  {VisionPenalty: 1, EnergyDrainPer30: 2, GatherYieldPercent: 100, FarmGrowPercent: 75, ObjectDamagePerHour: 1},
+	"storm": {VisionPenalty: 2, EnergyDrainPer30: 4, GatherYieldPercent: 50, FarmGrowPercent: 100, ObjectDamagePerHour: 4},
+	"fog":   {VisionPenalty: 2, GatherYieldPercent: 100, FarmGrowPercent: 100},
+}
+
+// Percent of the base farm grow time per season.
+var seasonFarmGrowPercent = map[string]int{
+	"spring": 100,
+	"summer": 75,
+	"autumn": 100,
+	"winter": 200,
+}
+
+func WeatherEffectFor(weather string) WeatherEffect {
+	if effect, ok := weatherEffects[weather]; ok {
+		return effect
+	}
+	return weatherEffects["clear"]
+}
+
+func WeatherEffectRules() map[string]WeatherEffect {
+	out := make(map[string]WeatherEffect, len(weatherEffects))
+	for k, v := range weatherEffects {
+		out[k] = v
+	}
+	return out
+}
+
+func SeasonFarmGrowRules() map[string]int {
+	return cloneIntMap(seasonFarmGrowPercent)
+}
+
+// VisionRadius narrows the day/night vision radius by the weather penalty.
+func VisionRadius(baseRadius int, weather string) int {
+	r := baseRadius - WeatherEffectFor(weather).VisionPenalty
+	if r < 1 {
+		return 1
+	}
+	return r
+}
+
+func weatherAdjustedYield(qty int, weather string) int {
+	adjusted := qty * WeatherEffectFor(weather).GatherYieldPercent / 100
+	if qty > 0 && adjusted < 1 {
+		return 1
+	}
+	return adjusted
+}
@@ -0,0 +1,57 @@
+package survival
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettlementService_StormDrainsEnergyUnlessSheltered(t *testing.T) {
+	state := AgentStateAggregate{
+		AgentID: "a-1",
+		Vitals:  Vitals{HP: 100, Hunger: 80, Energy: 60},
+		Version: 1,
+	}
+	intent := ActionIntent{Type: ActionRest}
+	clear, err := SettlementService{}.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{Weather: "clear"})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	storm, err := SettlementService{}.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{Weather: "storm"})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	sheltered, err := SettlementService{}.Settle(state, intent, HeartbeatDelta{Minutes: 30}, time.Now(), WorldSnapshot{Weather: "storm", Sheltered: true})
+	if err != nil {
+		t.Fatalf("settle error: %v", err)
+	}
+	drain := WeatherEffectFor("storm").EnergyDrainPer30
+	if got := clear.UpdatedState.Vitals.Energy - storm.UpdatedState.Vitals.Energy; got != drain {
+		t.Fatalf("expected storm to drain %d extra energy, got %d", drain, got)
+	}
+	if sheltered.UpdatedState.Vitals.Energy != clear.UpdatedState.Vitals.Energy {
+		t.Fatalf("expected shelter to block weather drain, got %d vs %d", sheltered.UpdatedState.Vitals.Energy, clear.UpdatedState.Vitals.Energy)
+	}
+}
+
+func TestWeatherAdjustsGatherYieldAndFarmGrowth(t *testing.T) {
+	state := AgentStateAggregate{}
+	ApplyGather(&state, WorldSnapshot{Weather: "storm", NearbyResource: map[string]int{"wood": 4, "berry": 1}})
+	if got := state.Inventory["wood"]; got != 2 {
+		t.Fatalf("expected storm to halve wood yield, got %d", got)
+	}
+	if got := state.Inventory["berry"]; got != 1 {
+		t.Fatalf("expected storm yield to keep at least one item, got %d", got)
+	}
+
+	rules := DefaultRuleSet()
+	wheatMinutes := rules.CropRuleFor(CropWheat).GrowMinutes
+	if got := rules.CropGrowMinutes(CropWheat, "", ""); got != wheatMinutes {
+		t.Fatalf("expected default grow minutes without calendar, got %d", got)
+	}
+	if rules.CropGrowMinutes(CropWheat, "summer", "rain") >= wheatMinutes {
+		t.Fatalf("expected summer rain to speed growth")
+	}
+	if rules.CropGrowMinutes(CropWheat, "winter", "clear") <= wheatMinutes {
+		t.Fatalf("expected winter to slow growth")
+	}
+}
@@ -0,0 +1,99 @@
+package world
+
+import "time"
+
+type Season string
+
+const (
+	SeasonSpring Season = "spring"
+	SeasonSummer Season = "summer"
+	SeasonAutumn Season = "autumn"
+	SeasonWinter Season = "winter"
+)
+
+type Weather string
+
+const (
+	WeatherClear Weather = "clear"
+	WeatherRain  Weather = "rain"
+	WeatherStorm Weather = "storm"
+	WeatherFog   Weather = "fog"
+)
+
+// A calendar day is one full day/night cycle.
+const (
+	DaysPerSeason      = 7
+	WeatherSlotsPerDay = 4
+)
+
+var seasonOrder = []Season{SeasonSpring, SeasonSummer, SeasonAutumn, SeasonWinter}
+
+var weatherOrder = []Weather{WeatherClear, WeatherRain, WeatherStorm, WeatherFog}
+
+// Weights follow weatherOrder and sum to 100.
+var seasonWeatherWeights = map[Season][]int{
+	SeasonSpring: {50, 30, 5, 15},
+	SeasonSummer: {70, 15, 10, 5},
+	SeasonAutumn: {45, 25, 10, 20},
+	SeasonWinter: {40, 15, 20, 25},
+}
+
+var weatherTransitionWeights = map[Weather][]int{
+	WeatherClear: {70, 15, 5, 10},
+	WeatherRain:  {30, 45, 15, 10},
+	WeatherStorm: {20, 50, 25, 5},
+	WeatherFog:   {50, 15, 5, 30},
+}
+
+// DayAt returns the 1-based calendar day.
+func (c Clock) DayAt(now time.Time) int64 {
+	return c.CycleAt(now) + 1
+}
+
+func (c Clock) SeasonAt(now time.Time) Season {
+	return seasonOrder[(c.CycleAt(now)/DaysPerSeason)%int64(len(seasonOrder))]
+}
+
+// WeatherAt walks a Markov chain over the day's weather slots. The chain restarts
+// every day from the season's distribution, so any instant resolves in at most
+// WeatherSlotsPerDay steps and the same clock start always yields the same weather.
+func (c Clock) WeatherAt(now time.Time) Weather {
+	total := c.cfg.DayDuration + c.cfg.NightDuration
+	if total <= 0 {
+		return WeatherClear
+	}
+	elapsed := now.Sub(c.cfg.StartAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	day := int64(elapsed / total)
+	slot := int((elapsed % total) / (total / WeatherSlotsPerDay))
+	if slot >= WeatherSlotsPerDay {
+		slot = WeatherSlotsPerDay - 1
+	}
+	seed := c.cfg.StartAt.Unix()
+	current := pickWeather(seasonWeatherWeights[c.SeasonAt(now)], weatherRoll(seed, day, 0))
+	for i := 1; i <= slot; i++ {
+		current = pickWeather(weatherTransitionWeights[current], weatherRoll(seed, day, i))
+	}
+	return current
+}
+
+func pickWeather(weights []int, roll int) Weather {
+	acc := 0
+	for i, w := range weights {
+		acc += w
+		if roll < acc {
+			return weatherOrder[i]
+		}
+	}
+	return WeatherClear
+}
+
+func weatherRoll(seed, day int64, slot int) int {
+	v := uint64(seed)*0x9e3779b97f4a7c15 ^ uint64(day)*0xbf58476d1ce4e5b9 ^ uint64(slot+1)*0x94d049bb133111eb
+	v ^= v >> 31
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	return int(v % 100)
+}
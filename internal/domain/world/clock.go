@@ -60,3 +60,22 @@ func (c Clock) WorldTimeSecondsAt(now time.Time) int64 {
 	}
 	return int64(elapsed / time.Second)
 }
+
+func (c Clock) CycleAt(now time.Time) int64 {
+	total := c.cfg.DayDuration + c.cfg.NightDuration
+	if total <= 0 {
+		return 0
+	}
+	elapsed := now.Sub(c.cfg.StartAt)
+	if elapsed < 0 {
+		return 0
+	}
+	return int64(elapsed / total)
+}
+
+func (c Clock) PhaseDuration(phase Phase) time.Duration {
+	if phase == PhaseNight {
+		return c.cfg.NightDuration
+	}
+	return c.cfg.DayDuration
+}
@@ -34,3 +34,43 @@ func TestClockPhaseCycle(t *testing.T) {
 		t.Fatalf("expected cycle back to day, got %s", phase)
 	}
 }
+
+func TestClockCalendarAdvancesSeasonsByDay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(ClockConfig{StartAt: start, DayDuration: 10 * time.Minute, NightDuration: 5 * time.Minute})
+
+	if got := clock.DayAt(start); got != 1 {
+		t.Fatalf("expected day 1 at start, got %d", got)
+	}
+	if got := clock.SeasonAt(start); got != SeasonSpring {
+		t.Fatalf("expected spring at start, got %s", got)
+	}
+	later := start.Add(DaysPerSeason * 15 * time.Minute)
+	if got := clock.DayAt(later); got != DaysPerSeason+1 {
+		t.Fatalf("expected day %d, got %d", DaysPerSeason+1, got)
+	}
+	if got := clock.SeasonAt(later); got != SeasonSummer {
+		t.Fatalf("expected summer after one season of days, got %s", got)
+	}
+}
+
+func TestClockWeatherIsDeterministicFromStart(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := ClockConfig{StartAt: start, DayDuration: 10 * time.Minute, NightDuration: 5 * time.Minute}
+	a, b := NewClock(cfg), NewClock(cfg)
+
+	seen := map[Weather]bool{}
+	for i := 0; i < 400; i++ {
+		at := start.Add(time.Duration(i) * 4 * time.Minute)
+		w := a.WeatherAt(at)
+		if w != b.WeatherAt(at) {
+			t.Fatalf("expected identical weather for identical clocks at %s", at)
+		}
+		seen[w] = true
+	}
+	for _, w := range []Weather{WeatherClear, WeatherRain, WeatherStorm, WeatherFog} {
+		if !seen[w] {
+			t.Fatalf("expected weather %s to occur over many days, saw %v", w, seen)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package world
+
+import "time"
+
+type CreatureKind string
+
+const (
+	CreatureWolf CreatureKind = "wolf"
+	CreatureBoar CreatureKind = "boar"
+	CreatureBear CreatureKind = "bear"
+)
+
+const (
+	CreatureAggroRadius  = 5
+	CreatureLeashRadius  = 8
+	CreatureStepInterval = time.Minute
+)
+
+type Creature struct {
+	ID      string       `json:"id"`
+	Kind    CreatureKind `json:"kind"`
+	Pos     Point        `json:"pos"`
+	Home    Point        `json:"home"`
+	HP      int          `json:"hp"`
+	Damage  int          `json:"damage"`
+	MovedAt time.Time    `json:"moved_at"`
+	Chunk   ChunkCoord   `json:"chunk"`
+	Epoch   string       `json:"epoch"`
+}
+
+type CreatureSpawnRule struct {
+	Kind          CreatureKind
+	ChancePercent int
+	HP            int
+	Damage        int
+}
+
+var creatureSpawnRules = map[Zone]map[Phase]CreatureSpawnRule{
+	ZoneForest: {
+		PhaseNight: {Kind: CreatureWolf, ChancePercent: 40, HP: 20, Damage: 4},
+	},
+	ZoneQuarry: {
+		PhaseNight: {Kind: CreatureBear, ChancePercent: 30, HP: 40, Damage: 6},
+	},
+	ZoneWild: {
+		PhaseDay:   {Kind: CreatureBoar, ChancePercent: 25, HP: 25, Damage: 3},
+		PhaseNight: {Kind: CreatureWolf, ChancePercent: 60, HP: 20, Damage: 4},
+	},
+}
+
+func CreatureSpawnRuleFor(zone Zone, phase Phase) (CreatureSpawnRule, bool) {
+	rule, ok := creatureSpawnRules[zone][phase]
+	return rule, ok
+}
+
+func (c Creature) Alive() bool {
+	return c.HP > 0
+}
+
+func (c Creature) DistanceTo(p Point) int {
+	return absInt(c.Pos.X-p.X) + absInt(c.Pos.Y-p.Y)
+}
+
+func (c Creature) DangerScore() int {
+	score := c.Damage * 10
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+// StepCreature advances a creature by one tile. Creatures chase a target within
+// aggro radius and otherwise wander around their spawn point using roll.
+func StepCreature(c Creature, target *Point, passable func(Point) bool, roll int) Creature {
+	if !c.Alive() {
+		return c
+	}
+	candidates := []Point{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1}}
+	if target != nil && c.DistanceTo(*target) <= CreatureAggroRadius {
+		if c.DistanceTo(*target) <= 1 {
+			return c
+		}
+		best := c.Pos
+		bestDist := c.DistanceTo(*target)
+		for _, d := range candidates {
+			next := Point{X: c.Pos.X + d.X, Y: c.Pos.Y + d.Y}
+			if next == *target || !passable(next) {
+				continue
+			}
+			dist := absInt(next.X-target.X) + absInt(next.Y-target.Y)
+			if dist < bestDist {
+				best = next
+				bestDist = dist
+			}
+		}
+		c.Pos = best
+		return c
+	}
+	if roll < 0 {
+		roll = -roll
+	}
+	idx := roll % (len(candidates) + 1)
+	if idx == len(candidates) {
+		return c
+	}
+	next := Point{X: c.Pos.X + candidates[idx].X, Y: c.Pos.Y + candidates[idx].Y}
+	if absInt(next.X-c.Home.X)+absInt(next.Y-c.Home.Y) > CreatureLeashRadius || !passable(next) {
+		return c
+	}
+	c.Pos = next
+	return c
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,37 @@
+package world
+
+import "testing"
+
+func TestStepCreature_ChasesTargetWithinAggroRadius(t *testing.T) {
+	c := Creature{ID: "crt-1", Pos: Point{X: 4, Y: 0}, Home: Point{X: 4, Y: 0}, HP: 10, Damage: 2}
+	target := Point{X: 0, Y: 0}
+	open := func(Point) bool { return true }
+
+	for i := 0; i < 5; i++ {
+		c = StepCreature(c, &target, open, i)
+	}
+	if got := c.DistanceTo(target); got != 1 {
+		t.Fatalf("expected creature to stop adjacent to target, got distance %d at %+v", got, c.Pos)
+	}
+}
+
+func TestStepCreature_WanderStaysWithinLeash(t *testing.T) {
+	c := Creature{ID: "crt-1", Pos: Point{X: 0, Y: 0}, Home: Point{X: 0, Y: 0}, HP: 10}
+	open := func(Point) bool { return true }
+
+	for i := 0; i < 200; i++ {
+		c = StepCreature(c, nil, open, i*7)
+		if d := absInt(c.Pos.X-c.Home.X) + absInt(c.Pos.Y-c.Home.Y); d > CreatureLeashRadius {
+			t.Fatalf("creature wandered beyond leash: %+v", c.Pos)
+		}
+	}
+}
+
+func TestCreatureSpawnRuleFor_SafeZoneNeverSpawns(t *testing.T) {
+	if _, ok := CreatureSpawnRuleFor(ZoneSafe, PhaseNight); ok {
+		t.Fatalf("expected no spawns in safe zone")
+	}
+	if _, ok := CreatureSpawnRuleFor(ZoneForest, PhaseNight); !ok {
+		t.Fatalf("expected night spawns in forest")
+	}
+}
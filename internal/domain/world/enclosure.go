@@ -0,0 +1,76 @@
+package world
+
+// MaxEnclosureSpan caps the width and height of a single enclosed region. A
+// flood fill that spreads wider than this is treated as open ground, so the
+// cost stays bounded however far apart unrelated barriers are.
+const MaxEnclosureSpan = 64
+
+var neighbourSteps = []Point{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1}}
+
+// EnclosedTiles returns the tiles fully surrounded by barriers, i.e. tiles whose
+// 4-neighbour region is closed off and no wider or taller than MaxEnclosureSpan.
+func EnclosedTiles(barriers map[Point]bool) map[Point]bool {
+	out := map[Point]bool{}
+	open := map[Point]bool{}
+	for b := range barriers {
+		for _, d := range neighbourSteps {
+			seed := Point{X: b.X + d.X, Y: b.Y + d.Y}
+			if barriers[seed] || out[seed] || open[seed] {
+				continue
+			}
+			region, closed := floodRegion(seed, barriers, open)
+			target := open
+			if closed {
+				target = out
+			}
+			for p := range region {
+				target[p] = true
+			}
+		}
+	}
+	return out
+}
+
+// floodRegion fills the region around seed and reports whether it is closed.
+// It stops early once the region outgrows MaxEnclosureSpan or touches a tile
+// already known to be open.
+func floodRegion(seed Point, barriers, open map[Point]bool) (map[Point]bool, bool) {
+	region := map[Point]bool{seed: true}
+	queue := []Point{seed}
+	minX, maxX, minY, maxY := seed.X, seed.X, seed.Y, seed.Y
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		minX, maxX = minInt(minX, cur.X), maxInt(maxX, cur.X)
+		minY, maxY = minInt(minY, cur.Y), maxInt(maxY, cur.Y)
+		if maxX-minX > MaxEnclosureSpan || maxY-minY > MaxEnclosureSpan {
+			return region, false
+		}
+		for _, d := range neighbourSteps {
+			next := Point{X: cur.X + d.X, Y: cur.Y + d.Y}
+			if open[next] {
+				return region, false
+			}
+			if barriers[next] || region[next] {
+				continue
+			}
+			region[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return region, true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
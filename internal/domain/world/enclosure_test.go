@@ -0,0 +1,54 @@
+package world
+
+import "testing"
+
+func TestEnclosedTiles_ClosedRingShelterInterior(t *testing.T) {
+	barriers := map[Point]bool{}
+	for x := -1; x <= 1; x++ {
+		barriers[Point{X: x, Y: -1}] = true
+		barriers[Point{X: x, Y: 1}] = true
+	}
+	barriers[Point{X: -1, Y: 0}] = true
+	barriers[Point{X: 1, Y: 0}] = true
+
+	enclosed := EnclosedTiles(barriers)
+	if len(enclosed) != 1 || !enclosed[Point{X: 0, Y: 0}] {
+		t.Fatalf("expected only the ring interior enclosed, got %v", enclosed)
+	}
+
+	delete(barriers, Point{X: 1, Y: 0})
+	if got := EnclosedTiles(barriers); len(got) != 0 {
+		t.Fatalf("expected open ring to shelter nothing, got %v", got)
+	}
+}
+
+func TestEnclosedTiles_DistantWallDoesNotDisableNearbyRing(t *testing.T) {
+	barriers := map[Point]bool{}
+	for x := -1; x <= 1; x++ {
+		barriers[Point{X: x, Y: -1}] = true
+		barriers[Point{X: x, Y: 1}] = true
+	}
+	barriers[Point{X: -1, Y: 0}] = true
+	barriers[Point{X: 1, Y: 0}] = true
+	barriers[Point{X: 500, Y: 500}] = true
+
+	enclosed := EnclosedTiles(barriers)
+	if len(enclosed) != 1 || !enclosed[Point{X: 0, Y: 0}] {
+		t.Fatalf("expected ring interior enclosed despite a distant wall, got %v", enclosed)
+	}
+}
+
+func TestEnclosedTiles_RegionWiderThanSpanIsOpen(t *testing.T) {
+	barriers := map[Point]bool{}
+	span := MaxEnclosureSpan + 3
+	for x := 0; x <= span; x++ {
+		barriers[Point{X: x, Y: 0}] = true
+		barriers[Point{X: x, Y: 2}] = true
+	}
+	barriers[Point{X: 0, Y: 1}] = true
+	barriers[Point{X: span, Y: 1}] = true
+
+	if got := EnclosedTiles(barriers); len(got) != 0 {
+		t.Fatalf("expected oversized enclosure to count as open, got %d tiles", len(got))
+	}
+}
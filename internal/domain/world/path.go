@@ -0,0 +1,83 @@
+package world
+
+import "container/heap"
+
+// FindPath plans a shortest 4-neighbour route with A* over the walkable
+// tiles. The path excludes from and ends at to; from itself need not be
+// walkable.
+func FindPath(from, to Point, walkable map[Point]bool) ([]Point, bool) {
+	if from == to {
+		return nil, true
+	}
+	if !walkable[to] {
+		return nil, false
+	}
+	cost := map[Point]int{from: 0}
+	parent := map[Point]Point{}
+	open := &pathQueue{}
+	heap.Push(open, pathNode{pos: from, priority: manhattan(from, to)})
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(pathNode).pos
+		if cur == to {
+			return rebuildPath(parent, from, to), true
+		}
+		for _, next := range []Point{
+			{X: cur.X + 1, Y: cur.Y},
+			{X: cur.X - 1, Y: cur.Y},
+			{X: cur.X, Y: cur.Y + 1},
+			{X: cur.X, Y: cur.Y - 1},
+		} {
+			if !walkable[next] {
+				continue
+			}
+			step := cost[cur] + 1
+			if known, ok := cost[next]; ok && known <= step {
+				continue
+			}
+			cost[next] = step
+			parent[next] = cur
+			heap.Push(open, pathNode{pos: next, priority: step + manhattan(next, to)})
+		}
+	}
+	return nil, false
+}
+
+func rebuildPath(parent map[Point]Point, from, to Point) []Point {
+	out := []Point{}
+	for cur := to; cur != from; cur = parent[cur] {
+		out = append(out, cur)
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+func manhattan(a, b Point) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx + dy
+}
+
+type pathNode struct {
+	pos      Point
+	priority int
+}
+
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
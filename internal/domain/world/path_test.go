@@ -0,0 +1,45 @@
+package world
+
+import "testing"
+
+func TestFindPath_RoutesAroundWaterWall(t *testing.T) {
+	walkable := map[Point]bool{}
+	for x := 0; x <= 4; x++ {
+		for y := 0; y <= 2; y++ {
+			walkable[Point{X: x, Y: y}] = true
+		}
+	}
+	// A wall at x=2 with a single gap at y=2.
+	walkable[Point{X: 2, Y: 0}] = false
+	walkable[Point{X: 2, Y: 1}] = false
+
+	path, ok := FindPath(Point{X: 0, Y: 0}, Point{X: 4, Y: 0}, walkable)
+	if !ok {
+		t.Fatalf("expected a path through the gap")
+	}
+	if len(path) != 8 || path[len(path)-1] != (Point{X: 4, Y: 0}) {
+		t.Fatalf("expected shortest 8-step path ending at target, got %v", path)
+	}
+	for i, p := range path {
+		if !walkable[p] {
+			t.Fatalf("step %d at %v is not walkable", i, p)
+		}
+		prev := Point{X: 0, Y: 0}
+		if i > 0 {
+			prev = path[i-1]
+		}
+		if manhattan(prev, p) != 1 {
+			t.Fatalf("step %d jumps from %v to %v", i, prev, p)
+		}
+	}
+}
+
+func TestFindPath_FailsWhenTargetUnreachable(t *testing.T) {
+	walkable := map[Point]bool{{X: 0, Y: 0}: true, {X: 3, Y: 0}: true}
+	if _, ok := FindPath(Point{X: 0, Y: 0}, Point{X: 3, Y: 0}, walkable); ok {
+		t.Fatalf("expected no path across unknown tiles")
+	}
+	if _, ok := FindPath(Point{X: 0, Y: 0}, Point{X: 9, Y: 9}, walkable); ok {
+		t.Fatalf("expected no path to a non-walkable target")
+	}
+}
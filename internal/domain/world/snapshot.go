@@ -3,6 +3,9 @@ package world
 type Snapshot struct {
 	WorldTimeSeconds   int64          `json:"world_time_seconds"`
 	TimeOfDay          string         `json:"time_of_day"`
+	Day                int64          `json:"day"`
+	Season             string         `json:"season"`
+	Weather            string         `json:"weather"`
 	ThreatLevel        int            `json:"threat_level"`
 	VisibilityPenalty  int            `json:"visibility_penalty"`
 	NearbyResource     map[string]int `json:"nearby_resource"`
@@ -13,4 +16,5 @@ type Snapshot struct {
 	PhaseChanged       bool           `json:"phase_changed"`
 	PhaseFrom          string         `json:"phase_from"`
 	PhaseTo            string         `json:"phase_to"`
+	Creatures          []Creature     `json:"creatures,omitempty"`
 }